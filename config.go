@@ -32,6 +32,11 @@ func (c *Config) SetDefaults() {
 }
 
 func (c *Config) Validate() error {
+	// The end-to-end cluster name defaults to the cluster name of the kafka config
+	if c.Minion.EndToEnd.ClusterName == "" {
+		c.Minion.EndToEnd.ClusterName = c.Kafka.ClusterName
+	}
+
 	err := c.Kafka.Validate()
 	if err != nil {
 		return fmt.Errorf("failed to validate kafka config: %w", err)
@@ -0,0 +1,61 @@
+package e2e
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// Pause stops sending probe messages until Resume is called, e.g. during a planned maintenance. Messages that are
+// already in flight are still tracked.
+func (s *Service) Pause() {
+	if s.paused.Swap(true) {
+		return
+	}
+	s.pausedGauge.WithLabelValues().Set(1)
+	s.logger.Info("paused sending end-to-end probe messages")
+}
+
+// Resume continues sending probe messages after Pause has been called.
+func (s *Service) Resume() {
+	if !s.paused.Swap(false) {
+		return
+	}
+	s.pausedGauge.WithLabelValues().Set(0)
+	s.logger.Info("resumed sending end-to-end probe messages")
+}
+
+// HandlePause returns an HTTP handler that pauses probing of all given services. Only POST requests are accepted.
+func HandlePause(services []*Service) http.HandlerFunc {
+	return handleSetPaused(services, true)
+}
+
+// HandleResume returns an HTTP handler that resumes probing of all given services. Only POST requests are accepted.
+func HandleResume(services []*Service) http.HandlerFunc {
+	return handleSetPaused(services, false)
+}
+
+func handleSetPaused(services []*Service, paused bool) http.HandlerFunc {
+	type response struct {
+		Paused bool `json:"paused"`
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		for _, svc := range services {
+			if paused {
+				svc.Pause()
+			} else {
+				svc.Resume()
+			}
+		}
+
+		resJson, _ := json.Marshal(response{Paused: paused})
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write(resJson)
+	}
+}
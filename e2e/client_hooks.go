@@ -1,35 +1,190 @@
 package e2e
 
 import (
+	"context"
+	"errors"
 	"net"
+	"strconv"
+	"sync"
 	"sync/atomic"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/twmb/franz-go/pkg/kerr"
 	"github.com/twmb/franz-go/pkg/kgo"
 	"github.com/twmb/franz-go/pkg/kmsg"
 	"go.uber.org/zap"
+
+	"github.com/cloudhut/kminion/v2/kafka"
 )
 
-// in e2e we only use client hooks for logging connect/disconnect messages
+// in e2e we use client hooks for logging connect/disconnect messages and for tracking group and produce events
 type clientHooks struct {
 	logger *zap.Logger
 
 	lastCoordinatorUpdate time.Time
 	currentCoordinator    *atomic.Value // kgo.BrokerMetadata
+
+	// partitionLeaders tracks the broker that last accepted a produce batch for each partition
+	partitionLeaders sync.Map // int32 (partition id) -> int32 (broker id)
+
+	// fetchBrokers tracks the broker that served the last fetched batch for each partition. This differs from the
+	// partition leader if we fetch from a follower.
+	fetchBrokers sync.Map // int32 (partition id) -> int32 (broker id)
+
+	// compressionRatio is the ratio of uncompressed to compressed bytes of the last produced batch
+	compressionRatio *atomic.Value // float64
+
+	// lastJoinDuration is the duration of the last JoinGroup request, so that we can report the join+sync latency
+	// once the following SyncGroup request completes.
+	lastJoinDuration atomic.Int64 // time.Duration
+
+	// lastGroupRequest is the name of the last request of the group management loop (group lifecycle requests and
+	// OffsetFetch) whose response has been read. Group management errors are reported without the request, so they are
+	// attributed to this request.
+	lastGroupRequest atomic.Value // string
+
+	// Rebalance metrics
+	rebalancesTotal         prometheus.Counter
+	partitionsAssignedTotal prometheus.Counter
+	partitionsRevokedTotal  prometheus.Counter
+	groupJoinSyncLatency    prometheus.Histogram
+
+	// consumerLag is owned by the hooks, so that the series of revoked partitions can be removed
+	consumerLag *prometheus.GaugeVec
+
+	// consumedOffsets tracks the offset of the next record to consume for each partition, from which the lag is
+	// derived if a fetch contains no records of the partition
+	consumedOffsets sync.Map // int32 (partition id) -> int64 (offset)
+
+	// produceErrors is fed by the produce callbacks
+	produceErrors *prometheus.CounterVec
+
+	leaderChanges prometheus.Counter
+
+	// Group lifecycle requests (FindCoordinator, JoinGroup, SyncGroup, Heartbeat)
+	groupRequestLatency  *prometheus.HistogramVec
+	groupRequestFailures *prometheus.CounterVec
+
+	// Connection establishment, by broker
+	brokerDialLatency         *prometheus.HistogramVec
+	brokerTLSHandshakeLatency *prometheus.HistogramVec
+	brokerConnectionFailures  *prometheus.CounterVec
 }
 
-func newEndToEndClientHooks(logger *zap.Logger) *clientHooks {
+func newEndToEndClientHooks(cfg Config, logger *zap.Logger, promRegisterer prometheus.Registerer) *clientHooks {
+	rebalancesTotal := prometheus.NewCounter(prometheus.CounterOpts{
+		Subsystem: "end_to_end",
+		Name:      "rebalances_total",
+		Help:      "Number of completed rebalances (successful SyncGroup responses) of kminion's end-to-end consumer group",
+	})
+	partitionsAssignedTotal := prometheus.NewCounter(prometheus.CounterOpts{
+		Subsystem: "end_to_end",
+		Name:      "partitions_assigned_total",
+		Help:      "Number of partitions that have been assigned to kminion's end-to-end consumer",
+	})
+	partitionsRevokedTotal := prometheus.NewCounter(prometheus.CounterOpts{
+		Subsystem: "end_to_end",
+		Name:      "partitions_revoked_total",
+		Help:      "Number of partitions that have been revoked from or lost by kminion's end-to-end consumer",
+	})
+	groupJoinSyncLatency := prometheus.NewHistogram(kafka.WithHistogramType(prometheus.HistogramOpts{
+		Subsystem: "end_to_end",
+		Name:      "group_join_sync_latency_seconds",
+		Help:      "Time it took to join and sync kminion's end-to-end consumer group",
+		Buckets:   cfg.Consumer.commitSlaBuckets(),
+	}, cfg.LatencyMetrics.HistogramType))
+	consumerLag := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Subsystem: "end_to_end",
+		Name:      "consumer_lag",
+		Help:      "Number of messages kminion's end-to-end consumer is behind the high watermark of each assigned partition",
+	}, []string{"partition_id"})
+	produceErrors := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Subsystem: "end_to_end",
+		Name:      "produce_errors_total",
+		Help:      "Number of errors that occurred when producing end-to-end messages, by Kafka error code",
+	}, []string{"error_code"})
+	leaderChanges := prometheus.NewCounter(prometheus.CounterOpts{
+		Subsystem: "end_to_end",
+		Name:      "leader_changes_total",
+		Help:      "Number of leader changes of the end-to-end topic's partitions, as observed by kminion's producer",
+	})
+	groupRequestLatency := prometheus.NewHistogramVec(kafka.WithHistogramType(prometheus.HistogramOpts{
+		Subsystem: "end_to_end",
+		Name:      "group_request_latency_seconds",
+		Help:      "Time it took to complete group lifecycle requests (FindCoordinator, JoinGroup, SyncGroup, Heartbeat) of kminion's end-to-end consumer",
+		Buckets:   cfg.Consumer.commitSlaBuckets(),
+	}, cfg.LatencyMetrics.HistogramType), []string{"request"})
+	groupRequestFailures := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Subsystem: "end_to_end",
+		Name:      "group_request_failures_total",
+		Help:      "Number of group lifecycle requests (FindCoordinator, JoinGroup, SyncGroup, Heartbeat) of kminion's end-to-end consumer that failed, by Kafka error code. Requests that failed to be written or read are counted as TRANSPORT_ERROR. Errors that can't be attributed to a request are counted with the request unknown",
+	}, []string{"request", "error_code"})
+	// Connections are usually established within milliseconds, but may take seconds if e.g. a TLS sidecar is overloaded
+	connectionBuckets := prometheus.ExponentialBuckets(0.001, 2, 14)
+	brokerDialLatency := prometheus.NewHistogramVec(kafka.WithHistogramType(prometheus.HistogramOpts{
+		Subsystem: "end_to_end",
+		Name:      "broker_dial_latency_seconds",
+		Help:      "Time it took kminion's end-to-end client to establish a TCP connection to a broker, excluding the TLS handshake",
+		Buckets:   connectionBuckets,
+	}, cfg.LatencyMetrics.HistogramType), []string{"broker_id"})
+	brokerTLSHandshakeLatency := prometheus.NewHistogramVec(kafka.WithHistogramType(prometheus.HistogramOpts{
+		Subsystem: "end_to_end",
+		Name:      "broker_tls_handshake_latency_seconds",
+		Help:      "Time it took kminion's end-to-end client to complete the TLS handshake with a broker",
+		Buckets:   connectionBuckets,
+	}, cfg.LatencyMetrics.HistogramType), []string{"broker_id"})
+	brokerConnectionFailures := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Subsystem: "end_to_end",
+		Name:      "broker_connection_failures_total",
+		Help:      "Number of failed connection attempts of kminion's end-to-end client, by broker and the phase (dial or tls_handshake) that failed",
+	}, []string{"broker_id", "phase"})
+	promRegisterer.MustRegister(rebalancesTotal, partitionsAssignedTotal, partitionsRevokedTotal, groupJoinSyncLatency, consumerLag, produceErrors, leaderChanges,
+		groupRequestLatency, groupRequestFailures, brokerDialLatency, brokerTLSHandshakeLatency, brokerConnectionFailures)
+
 	return &clientHooks{
 		logger:             logger.Named("e2e_hooks"),
 		currentCoordinator: &atomic.Value{},
+		compressionRatio:   &atomic.Value{},
+
+		rebalancesTotal:         rebalancesTotal,
+		partitionsAssignedTotal: partitionsAssignedTotal,
+		partitionsRevokedTotal:  partitionsRevokedTotal,
+		groupJoinSyncLatency:    groupJoinSyncLatency,
+		consumerLag:             consumerLag,
+		produceErrors:           produceErrors,
+		leaderChanges:           leaderChanges,
+		groupRequestLatency:     groupRequestLatency,
+		groupRequestFailures:    groupRequestFailures,
+
+		brokerDialLatency:         brokerDialLatency,
+		brokerTLSHandshakeLatency: brokerTLSHandshakeLatency,
+		brokerConnectionFailures:  brokerConnectionFailures,
 	}
 }
 
-func (c *clientHooks) OnBrokerConnect(meta kgo.BrokerMetadata, dialDur time.Duration, _ net.Conn, err error) {
+// OnBrokerConnect records the latency of establishing the connection. If TLS is enabled, the dial duration reported
+// by kgo includes the handshake, which is therefore subtracted and reported separately.
+func (c *clientHooks) OnBrokerConnect(meta kgo.BrokerMetadata, dialDur time.Duration, conn net.Conn, err error) {
+	brokerID := strconv.Itoa(int(meta.NodeID))
 	if err != nil {
+		phase := "dial"
+		var handshakeErr *kafka.TLSHandshakeError
+		if errors.As(err, &handshakeErr) {
+			phase = "tls_handshake"
+		}
+		c.brokerConnectionFailures.WithLabelValues(brokerID, phase).Inc()
 		c.logger.Error("kafka connection failed", zap.String("broker_host", meta.Host), zap.Int32("broker_id", meta.NodeID), zap.Error(err))
 		return
 	}
+
+	if tlsConn, ok := conn.(*kafka.TLSConn); ok {
+		handshakeDur := tlsConn.HandshakeDuration()
+		c.brokerTLSHandshakeLatency.WithLabelValues(brokerID).Observe(handshakeDur.Seconds())
+		c.brokerDialLatency.WithLabelValues(brokerID).Observe((dialDur - handshakeDur).Seconds())
+	} else {
+		c.brokerDialLatency.WithLabelValues(brokerID).Observe(dialDur.Seconds())
+	}
 	c.logger.Debug("kafka connection succeeded",
 		zap.String("host", meta.Host), zap.Int32("broker_id", meta.NodeID),
 		zap.Int64("dial_duration_ms", dialDur.Milliseconds()))
@@ -86,3 +241,161 @@ func (c *clientHooks) OnBrokerRead(meta kgo.BrokerMetadata, key int16, bytesRead
 		c.lastCoordinatorUpdate = time.Now()
 	}
 }
+
+// OnBrokerThrottle is called when a broker throttles one of our requests, which usually happens because a quota has
+// been exceeded.
+func (c *clientHooks) OnBrokerThrottle(meta kgo.BrokerMetadata, throttleInterval time.Duration, _ bool) {
+	c.logger.Debug("kafka broker throttled request",
+		zap.Int32("broker_id", meta.NodeID),
+		zap.Int64("throttle_interval_ms", throttleInterval.Milliseconds()))
+}
+
+// OnProduceBatchWritten is called when a batch has been successfully written to a broker. We use this to keep track
+// of each partition's current leader, so that latencies can be attributed to the broker that served them and leader
+// changes can be counted.
+func (c *clientHooks) OnProduceBatchWritten(meta kgo.BrokerMetadata, _ string, partition int32, metrics kgo.ProduceBatchMetrics) {
+	previousLeader, exists := c.partitionLeaders.Swap(partition, meta.NodeID)
+	if exists && previousLeader.(int32) != meta.NodeID {
+		c.leaderChanges.Inc()
+		c.logger.Debug("partition leader changed",
+			zap.Int32("partition", partition),
+			zap.Int32("previous_leader_id", previousLeader.(int32)),
+			zap.Int32("leader_id", meta.NodeID))
+	}
+
+	if metrics.CompressedBytes > 0 {
+		c.compressionRatio.Store(float64(metrics.UncompressedBytes) / float64(metrics.CompressedBytes))
+	}
+}
+
+// OnFetchBatchRead is called when a batch has been fetched from a broker. We use this to keep track of the replica
+// that each partition has been fetched from.
+func (c *clientHooks) OnFetchBatchRead(meta kgo.BrokerMetadata, _ string, partition int32, _ kgo.FetchBatchMetrics) {
+	c.fetchBrokers.Store(partition, meta.NodeID)
+}
+
+// lastCompressionRatio returns the compression ratio of the last produced batch, or 0 if we haven't produced yet.
+func (c *clientHooks) lastCompressionRatio() float64 {
+	ratio, ok := c.compressionRatio.Load().(float64)
+	if !ok {
+		return 0
+	}
+	return ratio
+}
+
+// partitionLeader returns the broker id that last led the given partition. The second return value is false if
+// we haven't successfully produced to that partition yet.
+func (c *clientHooks) partitionLeader(partition int32) (int32, bool) {
+	leader, exists := c.partitionLeaders.Load(partition)
+	if !exists {
+		return -1, false
+	}
+	return leader.(int32), true
+}
+
+// replicaType returns whether the given partition has last been fetched from its leader ("leader") or from a
+// follower ("follower"). The second return value is false if the leader or the fetch broker is not known yet.
+func (c *clientHooks) replicaType(partition int32) (string, bool) {
+	fetchBroker, exists := c.fetchBrokers.Load(partition)
+	if !exists {
+		return "", false
+	}
+	leader, exists := c.partitionLeader(partition)
+	if !exists {
+		return "", false
+	}
+	if fetchBroker.(int32) == leader {
+		return "leader", true
+	}
+	return "follower", true
+}
+
+// OnBrokerE2E is called after a request has been written and its response has been read (or an error occurred). We
+// use it to measure the latencies of the group lifecycle requests and how long it takes to join and sync the
+// consumer group.
+func (c *clientHooks) OnBrokerE2E(_ kgo.BrokerMetadata, key int16, e2e kgo.BrokerE2E) {
+	switch key {
+	case (&kmsg.FindCoordinatorRequest{}).Key(), (&kmsg.JoinGroupRequest{}).Key(),
+		(&kmsg.SyncGroupRequest{}).Key(), (&kmsg.HeartbeatRequest{}).Key():
+		requestName := kmsg.NameForKey(key)
+		if e2e.Err() != nil {
+			c.groupRequestFailures.WithLabelValues(requestName, "TRANSPORT_ERROR").Inc()
+			return
+		}
+		c.lastGroupRequest.Store(requestName)
+		c.groupRequestLatency.WithLabelValues(requestName).Observe(e2e.DurationE2E().Seconds())
+	case (&kmsg.OffsetFetchRequest{}).Key():
+		// The group management loop fetches the committed offsets after each join, errors of which are reported as
+		// group management errors as well
+		if e2e.Err() == nil {
+			c.lastGroupRequest.Store(kmsg.NameForKey(key))
+		}
+	}
+
+	if e2e.Err() != nil {
+		return
+	}
+
+	switch key {
+	case (&kmsg.JoinGroupRequest{}).Key():
+		c.lastJoinDuration.Store(int64(e2e.DurationE2E()))
+	case (&kmsg.SyncGroupRequest{}).Key():
+		joinDuration := time.Duration(c.lastJoinDuration.Swap(0))
+		c.groupJoinSyncLatency.Observe((joinDuration + e2e.DurationE2E()).Seconds())
+		c.rebalancesTotal.Inc()
+	}
+}
+
+// OnGroupManageError is called after an error that caused the consumer to leave the group management loop. Error
+// codes returned by the requests of the loop are counted as failures of the last request whose response has been
+// read, or of an "unknown" request if there is none yet. Transport errors have already been counted by OnBrokerE2E.
+func (c *clientHooks) OnGroupManageError(err error) {
+	var kafkaErr *kerr.Error
+	if !errors.As(err, &kafkaErr) {
+		return
+	}
+	requestName, ok := c.lastGroupRequest.Load().(string)
+	if !ok {
+		requestName = "unknown"
+	}
+	c.groupRequestFailures.WithLabelValues(requestName, kafkaErr.Message).Inc()
+}
+
+// onPartitionsAssigned is registered as kgo.OnPartitionsAssigned callback
+func (c *clientHooks) onPartitionsAssigned(_ context.Context, _ *kgo.Client, assigned map[string][]int32) {
+	c.partitionsAssignedTotal.Add(float64(countPartitions(assigned)))
+}
+
+// onPartitionsRevoked is registered as kgo.OnPartitionsRevoked and kgo.OnPartitionsLost callback
+func (c *clientHooks) onPartitionsRevoked(_ context.Context, _ *kgo.Client, revoked map[string][]int32) {
+	c.partitionsRevokedTotal.Add(float64(countPartitions(revoked)))
+
+	// Another consumer is responsible for these partitions now, so we must not report a stale lag for them
+	for _, partitions := range revoked {
+		for _, partition := range partitions {
+			c.consumerLag.DeleteLabelValues(strconv.Itoa(int(partition)))
+			c.consumedOffsets.Delete(partition)
+		}
+	}
+}
+
+// recordProduceError counts a failed produce by its error code.
+func (c *clientHooks) recordProduceError(err error) {
+	c.produceErrors.WithLabelValues(produceErrorCode(err)).Inc()
+}
+
+// recordConsumerLag sets the consumer lag of the given partition, given the offset of the next record to consume.
+func (c *clientHooks) recordConsumerLag(partition int32, nextOffset int64, lag int64) {
+	c.consumedOffsets.Store(partition, nextOffset)
+	c.consumerLag.WithLabelValues(strconv.Itoa(int(partition))).Set(float64(lag))
+}
+
+// consumedOffset returns the offset of the next record to consume of the given partition, if a record of the
+// partition has been consumed since it was assigned.
+func (c *clientHooks) consumedOffset(partition int32) (int64, bool) {
+	offset, exists := c.consumedOffsets.Load(partition)
+	if !exists {
+		return 0, false
+	}
+	return offset.(int64), true
+}
@@ -0,0 +1,74 @@
+package e2e
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/twmb/franz-go/pkg/kerr"
+	"github.com/twmb/franz-go/pkg/kgo"
+	"github.com/twmb/franz-go/pkg/kmsg"
+	"go.uber.org/zap"
+)
+
+func TestGroupRequestFailures(t *testing.T) {
+	cfg := Config{}
+	cfg.SetDefaults()
+	hooks := newEndToEndClientHooks(cfg, zap.NewNop(), prometheus.NewRegistry())
+
+	hooks.OnBrokerE2E(kgo.BrokerMetadata{}, kmsg.Heartbeat.Int16(), kgo.BrokerE2E{})
+	hooks.OnGroupManageError(fmt.Errorf("heartbeat failed: %w", kerr.UnknownMemberID))
+	hooks.OnGroupManageError(fmt.Errorf("context canceled"))
+	hooks.OnBrokerE2E(kgo.BrokerMetadata{}, kmsg.JoinGroup.Int16(), kgo.BrokerE2E{ReadErr: fmt.Errorf("connection reset")})
+
+	assert.Equal(t, 1.0, testutil.ToFloat64(hooks.groupRequestFailures.WithLabelValues("Heartbeat", "UNKNOWN_MEMBER_ID")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(hooks.groupRequestFailures.WithLabelValues("JoinGroup", "TRANSPORT_ERROR")))
+	assert.Equal(t, 2, testutil.CollectAndCount(hooks.groupRequestFailures), "errors without an error code must not be counted")
+}
+
+func TestGroupRequestFailuresAttribution(t *testing.T) {
+	cfg := Config{}
+	cfg.SetDefaults()
+	hooks := newEndToEndClientHooks(cfg, zap.NewNop(), prometheus.NewRegistry())
+
+	// Errors before any group request has been read can't be attributed
+	hooks.OnGroupManageError(kerr.CoordinatorNotAvailable)
+	assert.Equal(t, 1.0, testutil.ToFloat64(hooks.groupRequestFailures.WithLabelValues("unknown", "COORDINATOR_NOT_AVAILABLE")))
+
+	// Errors of fetching the committed offsets after a join must not be attributed to the SyncGroup request
+	hooks.OnBrokerE2E(kgo.BrokerMetadata{}, kmsg.SyncGroup.Int16(), kgo.BrokerE2E{})
+	hooks.OnBrokerE2E(kgo.BrokerMetadata{}, kmsg.OffsetFetch.Int16(), kgo.BrokerE2E{})
+	hooks.OnGroupManageError(kerr.GroupAuthorizationFailed)
+	assert.Equal(t, 1.0, testutil.ToFloat64(hooks.groupRequestFailures.WithLabelValues("OffsetFetch", "GROUP_AUTHORIZATION_FAILED")))
+	assert.Equal(t, 2, testutil.CollectAndCount(hooks.groupRequestFailures))
+}
+
+func TestUpdateConsumerLag(t *testing.T) {
+	cfg := Config{}
+	cfg.SetDefaults()
+	svc := &Service{clientHooks: newEndToEndClientHooks(cfg, zap.NewNop(), prometheus.NewRegistry())}
+	lag := func() float64 { return testutil.ToFloat64(svc.clientHooks.consumerLag.WithLabelValues("0")) }
+
+	// Without a consumed record, the consumer's position is unknown
+	svc.updateConsumerLag(kgo.FetchTopicPartition{FetchPartition: kgo.FetchPartition{Partition: 0, HighWatermark: 10}})
+	assert.Equal(t, 0, testutil.CollectAndCount(svc.clientHooks.consumerLag))
+
+	svc.updateConsumerLag(kgo.FetchTopicPartition{FetchPartition: kgo.FetchPartition{
+		Partition:     0,
+		HighWatermark: 10,
+		Records:       []*kgo.Record{{Offset: 5}, {Offset: 6}},
+	}})
+	assert.Equal(t, 3.0, lag())
+
+	// Empty fetches derive the lag from the last consumed record
+	svc.updateConsumerLag(kgo.FetchTopicPartition{FetchPartition: kgo.FetchPartition{Partition: 0, HighWatermark: 12}})
+	assert.Equal(t, 5.0, lag())
+
+	// Revoked partitions are forgotten
+	svc.clientHooks.onPartitionsRevoked(context.Background(), nil, map[string][]int32{"e2e": {0}})
+	svc.updateConsumerLag(kgo.FetchTopicPartition{FetchPartition: kgo.FetchPartition{Partition: 0, HighWatermark: 12}})
+	assert.Equal(t, 0, testutil.CollectAndCount(svc.clientHooks.consumerLag))
+}
@@ -3,22 +3,63 @@ package e2e
 import (
 	"fmt"
 	"time"
+
+	"github.com/cloudhut/kminion/v2/kafka"
 )
 
 type Config struct {
 	Enabled         bool                   `koanf:"enabled"`
 	TopicManagement EndToEndTopicConfig    `koanf:"topicManagement"`
 	ProbeInterval   time.Duration          `koanf:"probeInterval"`
+	WarmupDuration  time.Duration          `koanf:"warmupDuration"`
 	Producer        EndToEndProducerConfig `koanf:"producer"`
 	Consumer        EndToEndConsumerConfig `koanf:"consumer"`
+
+	// ClusterName can be referenced as {{.ClusterName}} in the topic names and the consumer group id prefix, so that
+	// the same config can be deployed to multiple clusters.
+	ClusterName string `koanf:"clusterName"`
+
+	// PauseEndpoint serves the admin endpoints /admin/e2e/pause and /admin/e2e/resume, which pause and resume sending
+	// probe messages. Enable it only if the kminion HTTP endpoint is not publicly reachable.
+	PauseEndpoint bool `koanf:"pauseEndpoint"`
+
+	// PartitionGranularity controls whether the produce and roundtrip latency histograms carry a partition_id label.
+	// Disabling it aggregates the latencies across all partitions, which reduces the number of exported series.
+	PartitionGranularity bool `koanf:"partitionGranularity"`
+
+	// LatencyMetrics controls whether the produce, roundtrip and offset commit latencies are exported as histograms,
+	// summaries or both.
+	LatencyMetrics EndToEndLatencyMetricsConfig `koanf:"latencyMetrics"`
+
+	// Tracing emits an OpenTelemetry trace for each probe message to an OTLP endpoint.
+	Tracing EndToEndTracingConfig `koanf:"tracing"`
+
+	// SLO exports burn rates of availability and latency SLOs, which are derived from the probe messages.
+	SLO EndToEndSLOConfig `koanf:"slo"`
+
+	// ResultsTopic publishes a structured result record for each probe message to a Kafka topic.
+	ResultsTopic EndToEndResultsTopicConfig `koanf:"resultsTopic"`
+
+	// Topics can be used to run multiple end-to-end probes at the same time, each with its own topic (e.g. one per
+	// storage tier). Unset properties are inherited from TopicManagement. If empty, only the topic configured in
+	// TopicManagement is probed.
+	Topics []EndToEndTopicConfig `koanf:"topics"`
 }
 
 func (c *Config) SetDefaults() {
 	c.Enabled = false
 	c.ProbeInterval = 100 * time.Millisecond
+	c.WarmupDuration = 0
+	c.ClusterName = ""
+	c.PauseEndpoint = false
+	c.PartitionGranularity = true
 	c.TopicManagement.SetDefaults()
 	c.Producer.SetDefaults()
 	c.Consumer.SetDefaults()
+	c.LatencyMetrics.SetDefaults()
+	c.Tracing.SetDefaults()
+	c.SLO.SetDefaults()
+	c.ResultsTopic.SetDefaults()
 }
 
 func (c *Config) Validate() error {
@@ -32,11 +73,37 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("failed to validate probeInterval config, the duration can't be zero")
 	}
 
-	err := c.TopicManagement.Validate()
+	if c.WarmupDuration < 0 {
+		return fmt.Errorf("failed to validate warmupDuration config, the duration must not be negative")
+	}
+
+	// Names are rendered before validating the topic and consumer configs, so that the rendered names are validated
+	err := c.renderNameTemplates()
+	if err != nil {
+		return fmt.Errorf("failed to render templated names: %w", err)
+	}
+
+	err = c.TopicManagement.Validate()
 	if err != nil {
 		return fmt.Errorf("failed to validate topicManagement config: %w", err)
 	}
 
+	topicNames := make(map[string]struct{})
+	for i, topic := range c.TopicConfigs() {
+		if topic.Name == "" {
+			return fmt.Errorf("failed to validate topics config, topic at index %v has no name", i)
+		}
+		if _, exists := topicNames[topic.Name]; exists {
+			return fmt.Errorf("failed to validate topics config, topic '%v' is configured more than once", topic.Name)
+		}
+		topicNames[topic.Name] = struct{}{}
+
+		err = topic.Validate()
+		if err != nil {
+			return fmt.Errorf("failed to validate config of topic '%v': %w", topic.Name, err)
+		}
+	}
+
 	_, err = time.ParseDuration(c.ProbeInterval.String())
 	if err != nil {
 		return fmt.Errorf("failed to parse '%s' to time.Duration: %v", c.ProbeInterval.String(), err)
@@ -52,5 +119,86 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("failed to validate consumer config: %w", err)
 	}
 
+	err = c.LatencyMetrics.Validate()
+	if err != nil {
+		return fmt.Errorf("failed to validate latencyMetrics config: %w", err)
+	}
+
+	err = c.Tracing.Validate()
+	if err != nil {
+		return fmt.Errorf("failed to validate tracing config: %w", err)
+	}
+
+	err = c.SLO.Validate()
+	if err != nil {
+		return fmt.Errorf("failed to validate slo config: %w", err)
+	}
+
+	err = c.ResultsTopic.Validate()
+	if err != nil {
+		return fmt.Errorf("failed to validate resultsTopic config: %w", err)
+	}
+
+	return nil
+}
+
+// TopicConfigs returns the effective configs of all topics that shall be probed. One end-to-end service should be
+// started for each of these topics.
+func (c *Config) TopicConfigs() []EndToEndTopicConfig {
+	if len(c.Topics) == 0 {
+		return []EndToEndTopicConfig{c.TopicManagement}
+	}
+
+	topics := make([]EndToEndTopicConfig, len(c.Topics))
+	for i, topic := range c.Topics {
+		topics[i] = topic.inheritFrom(c.TopicManagement)
+	}
+	return topics
+}
+
+// groupMembership returns the id of the consumer group that shall be used for the topic and, if static membership is
+// enabled, the consumer's group instance id. Static members must rejoin the same group after a restart, so both are
+// derived from the configured instance id. They also contain the topic name, so that the consumers of the
+// different topics don't fence each other.
+func (c *Config) groupMembership(minionID string) (groupID string, instanceID string) {
+	if !c.Consumer.StaticMembership {
+		return fmt.Sprintf("%v-%v", c.Consumer.GroupIdPrefix, minionID), ""
+	}
+
+	instanceID = fmt.Sprintf("%v-%v", c.Consumer.InstanceID, c.TopicManagement.Name)
+	return fmt.Sprintf("%v-%v", c.Consumer.GroupIdPrefix, instanceID), instanceID
+}
+
+// renderNameTemplates replaces the templated topic names, consumer group id prefix and instance id with their rendered
+// values.
+func (c *Config) renderNameTemplates() error {
+	data, err := kafka.NewNameTemplateData(c.ClusterName)
+	if err != nil {
+		return err
+	}
+
+	c.TopicManagement.Name, err = kafka.RenderNameTemplate(c.TopicManagement.Name, data)
+	if err != nil {
+		return fmt.Errorf("topicManagement.name: %w", err)
+	}
+	for i := range c.Topics {
+		c.Topics[i].Name, err = kafka.RenderNameTemplate(c.Topics[i].Name, data)
+		if err != nil {
+			return fmt.Errorf("topics[%d].name: %w", i, err)
+		}
+	}
+	c.Consumer.GroupIdPrefix, err = kafka.RenderNameTemplate(c.Consumer.GroupIdPrefix, data)
+	if err != nil {
+		return fmt.Errorf("consumer.groupIdPrefix: %w", err)
+	}
+	// Static members must use the same instance id after a restart, hence it's derived from the pod name by default
+	if c.Consumer.StaticMembership && c.Consumer.InstanceID == "" {
+		c.Consumer.InstanceID = data.PodName
+	}
+	c.Consumer.InstanceID, err = kafka.RenderNameTemplate(c.Consumer.InstanceID, data)
+	if err != nil {
+		return fmt.Errorf("consumer.instanceId: %w", err)
+	}
+
 	return nil
 }
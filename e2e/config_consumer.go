@@ -3,6 +3,15 @@ package e2e
 import (
 	"fmt"
 	"time"
+
+	"github.com/twmb/franz-go/pkg/kgo"
+)
+
+const (
+	BalancerCooperativeSticky = "cooperative-sticky"
+	BalancerSticky            = "sticky"
+	BalancerRange             = "range"
+	BalancerRoundRobin        = "roundrobin"
 )
 
 type EndToEndConsumerConfig struct {
@@ -13,6 +22,30 @@ type EndToEndConsumerConfig struct {
 	// the message. Therefore this should always be higher than the produceTimeout / SLA.
 	RoundtripSla time.Duration `koanf:"roundtripSla"`
 	CommitSla    time.Duration `koanf:"commitSla"`
+
+	// RoundtripSlaBuckets and CommitSlaBuckets are the bucket boundaries (in seconds) of the roundtrip and offset
+	// commit latency histograms. If empty, the buckets are derived from the respective SLA.
+	RoundtripSlaBuckets []float64 `koanf:"roundtripSlaBuckets"`
+	CommitSlaBuckets    []float64 `koanf:"commitSlaBuckets"`
+
+	// StaticMembership enables static group membership (group.instance.id) for the e2e consumer, so that restarts
+	// within the session timeout don't trigger a rebalance.
+	StaticMembership bool `koanf:"staticMembership"`
+
+	// InstanceID is the group.instance.id that shall be used if static membership is enabled. If empty, the pod name
+	// is used (the POD_NAME env variable, or the hostname if unset). The consumer of each topic appends the topic name
+	// to it, and the consumer group id is then derived from that instead of a random id, so that a restarted kminion
+	// rejoins its groups.
+	InstanceID string `koanf:"instanceId"`
+
+	// Balancer is the group balancer that shall be used for the e2e consumer group. "cooperative-sticky" uses
+	// incremental rebalancing, while "sticky", "range" and "roundrobin" use eager rebalancing.
+	Balancer string `koanf:"balancer"`
+
+	// Rack is the rack id of the consumer. If set, the consumer fetches from the closest replica (KIP-392), which
+	// requires the brokers to have a replica.selector.class configured. The roundtrip latency is then additionally
+	// reported by replica type (leader or follower). Defaults to the rack id of the kafka config.
+	Rack string `koanf:"rack"`
 }
 
 func (c *EndToEndConsumerConfig) SetDefaults() {
@@ -20,6 +53,10 @@ func (c *EndToEndConsumerConfig) SetDefaults() {
 	c.DeleteStaleConsumerGroups = false
 	c.RoundtripSla = 20 * time.Second
 	c.CommitSla = 5 * time.Second
+	c.StaticMembership = false
+	c.InstanceID = ""
+	c.Balancer = BalancerCooperativeSticky
+	c.Rack = ""
 }
 
 func (c *EndToEndConsumerConfig) Validate() error {
@@ -35,5 +72,49 @@ func (c *EndToEndConsumerConfig) Validate() error {
 		return fmt.Errorf("consumer.commitSla must be greater than zero")
 	}
 
+	if err := validateHistogramBuckets(c.RoundtripSlaBuckets); err != nil {
+		return fmt.Errorf("consumer.roundtripSlaBuckets is invalid: %w", err)
+	}
+
+	if err := validateHistogramBuckets(c.CommitSlaBuckets); err != nil {
+		return fmt.Errorf("consumer.commitSlaBuckets is invalid: %w", err)
+	}
+
+	switch c.Balancer {
+	case BalancerCooperativeSticky, BalancerSticky, BalancerRange, BalancerRoundRobin:
+	default:
+		return fmt.Errorf("consumer.balancer '%v' is invalid. Valid values are cooperative-sticky, sticky, range or roundrobin", c.Balancer)
+	}
+
 	return nil
 }
+
+// groupBalancer returns the kgo group balancer for the configured balancer.
+func (c *EndToEndConsumerConfig) groupBalancer() kgo.GroupBalancer {
+	switch c.Balancer {
+	case BalancerSticky:
+		return kgo.StickyBalancer()
+	case BalancerRange:
+		return kgo.RangeBalancer()
+	case BalancerRoundRobin:
+		return kgo.RoundRobinBalancer()
+	default:
+		return kgo.CooperativeStickyBalancer()
+	}
+}
+
+// roundtripSlaBuckets returns the histogram buckets for the roundtrip latency.
+func (c *EndToEndConsumerConfig) roundtripSlaBuckets() []float64 {
+	if len(c.RoundtripSlaBuckets) > 0 {
+		return c.RoundtripSlaBuckets
+	}
+	return createHistogramBuckets(c.RoundtripSla)
+}
+
+// commitSlaBuckets returns the histogram buckets for the offset commit latency.
+func (c *EndToEndConsumerConfig) commitSlaBuckets() []float64 {
+	if len(c.CommitSlaBuckets) > 0 {
+		return c.CommitSlaBuckets
+	}
+	return createHistogramBuckets(c.CommitSla)
+}
@@ -0,0 +1,87 @@
+package e2e
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/cloudhut/kminion/v2/kafka"
+)
+
+const (
+	LatencyMetricsHistogram = "histogram"
+	LatencyMetricsSummary   = "summary"
+	LatencyMetricsBoth      = "both"
+)
+
+type EndToEndLatencyMetricsConfig struct {
+	// Type controls whether the produce, roundtrip and offset commit latencies are exported as histograms, as
+	// summaries or as both.
+	Type string `koanf:"type"`
+
+	// SummaryObjectives are the quantiles that shall be calculated by the summaries (e.g. 0.5, 0.95, 0.99).
+	SummaryObjectives []float64 `koanf:"summaryObjectives"`
+
+	// SummaryMaxAge is the duration for which observations are kept for calculating the quantiles.
+	SummaryMaxAge time.Duration `koanf:"summaryMaxAge"`
+
+	// HistogramType is the type of the end-to-end histograms: classic, native (sparse, exponential buckets) or both.
+	// Unlike Type, it also applies to the histograms of the group requests, connections and produce disruptions. The
+	// histograms of the shared client hooks (client_*) are not affected.
+	HistogramType string `koanf:"histogramType"`
+}
+
+func (c *EndToEndLatencyMetricsConfig) SetDefaults() {
+	c.Type = LatencyMetricsHistogram
+	c.SummaryObjectives = []float64{0.5, 0.95, 0.99}
+	c.SummaryMaxAge = 10 * time.Minute
+	c.HistogramType = kafka.HistogramTypeClassic
+}
+
+func (c *EndToEndLatencyMetricsConfig) Validate() error {
+	switch c.Type {
+	case LatencyMetricsHistogram, LatencyMetricsSummary, LatencyMetricsBoth:
+	default:
+		return fmt.Errorf("type '%v' is invalid. Valid values are histogram, summary or both", c.Type)
+	}
+
+	if err := kafka.ValidateHistogramType(c.HistogramType); err != nil {
+		return err
+	}
+
+	if !c.summariesEnabled() {
+		return nil
+	}
+
+	if len(c.SummaryObjectives) == 0 {
+		return fmt.Errorf("at least one summary objective must be configured if summaries are enabled")
+	}
+	for _, quantile := range c.SummaryObjectives {
+		if quantile <= 0 || quantile >= 1 {
+			return fmt.Errorf("summary objective '%v' is invalid, it must be between 0 and 1", quantile)
+		}
+	}
+
+	if c.SummaryMaxAge <= 0 {
+		return fmt.Errorf("summaryMaxAge must be greater than zero")
+	}
+
+	return nil
+}
+
+func (c *EndToEndLatencyMetricsConfig) histogramsEnabled() bool {
+	return c.Type == LatencyMetricsHistogram || c.Type == LatencyMetricsBoth
+}
+
+func (c *EndToEndLatencyMetricsConfig) summariesEnabled() bool {
+	return c.Type == LatencyMetricsSummary || c.Type == LatencyMetricsBoth
+}
+
+// summaryObjectives returns the configured quantiles along with their allowed absolute error. The error shrinks
+// towards the tail, so that e.g. p99 is calculated more accurately than p50.
+func (c *EndToEndLatencyMetricsConfig) summaryObjectives() map[float64]float64 {
+	objectives := make(map[float64]float64, len(c.SummaryObjectives))
+	for _, quantile := range c.SummaryObjectives {
+		objectives[quantile] = (1 - quantile) / 10
+	}
+	return objectives
+}
@@ -3,27 +3,253 @@ package e2e
 import (
 	"fmt"
 	"time"
+
+	"github.com/twmb/franz-go/pkg/kgo"
+)
+
+const (
+	PayloadModeCompressible   = "compressible"
+	PayloadModeIncompressible = "incompressible"
+
+	CompressionNone   = "none"
+	CompressionGzip   = "gzip"
+	CompressionSnappy = "snappy"
+	CompressionLz4    = "lz4"
+	CompressionZstd   = "zstd"
+
+	PartitioningStrategyAll        = "all"
+	PartitioningStrategyRoundRobin = "round-robin"
+	PartitioningStrategySticky     = "sticky"
+	PartitioningStrategyKeyHash    = "key-hash"
+
+	RequiredAcksAll    = "all"
+	RequiredAcksLeader = "leader"
+	RequiredAcksNone   = "none"
 )
 
 type EndToEndProducerConfig struct {
 	AckSla       time.Duration `koanf:"ackSla"`
 	RequiredAcks string        `koanf:"requiredAcks"`
+
+	// AckSlaBuckets are the bucket boundaries (in seconds) of the produce latency histograms. If empty, the buckets
+	// are derived from the AckSla.
+	AckSlaBuckets []float64 `koanf:"ackSlaBuckets"`
+
+	// MessageSize is the desired size in bytes of each probe message's value. Messages are padded with a payload
+	// until they reach this size. If set to 0 no padding will be added.
+	MessageSize int `koanf:"messageSize"`
+
+	// PayloadMode defines the content of the padding. It can be "compressible" (a single repeated character) or
+	// "incompressible" (random characters).
+	PayloadMode string `koanf:"payloadMode"`
+
+	// RateLimit is the number of probe messages per second that shall be sent to each partition. If set to 0 the
+	// top level probeInterval will be used instead.
+	RateLimit float64 `koanf:"rateLimit"`
+
+	// Jitter is the maximum random delay that is added to each probe interval, so that multiple kminion instances
+	// don't send their probes at the very same time.
+	Jitter time.Duration `koanf:"jitter"`
+
+	// Transactional enables the exactly-once mode. Each round of probe messages is produced within a Kafka
+	// transaction and the consumer only reads committed messages.
+	Transactional bool `koanf:"transactional"`
+
+	// TransactionalIDPrefix is the prefix of the transactional id. The kminion instance id is appended automatically.
+	TransactionalIDPrefix string `koanf:"transactionalIdPrefix"`
+
+	// Compression is the codec that shall be used to compress produced batches. Valid values are "none", "gzip",
+	// "snappy", "lz4" and "zstd".
+	Compression string `koanf:"compression"`
+
+	// Partitions restricts the probe messages to the given partition ids. If empty, every partition is probed.
+	Partitions []int32 `koanf:"partitions"`
+
+	// TargetEachBrokerOnce sends probe messages to only one partition per leading broker, so that every broker is
+	// covered exactly once per interval. This reduces the probe traffic for topics with many partitions.
+	TargetEachBrokerOnce bool `koanf:"targetEachBrokerOnce"`
+
+	// PartitioningStrategy defines which of the probed partitions receive a message in each interval:
+	// - "all": one message to every probed partition, which covers all brokers in each interval
+	// - "round-robin": one message per interval, cycling through the probed partitions
+	// - "sticky": one message per interval, to a randomly chosen partition that changes every StickyDuration
+	// - "key-hash": one message per interval with a synthetic key, whose hash over all partitions of the topic determines
+	//   the partition. Keys of partitions that are not probed are skipped.
+	PartitioningStrategy string `koanf:"partitioningStrategy"`
+
+	// StickyDuration is the duration after which the sticky partitioning strategy switches to another partition.
+	StickyDuration time.Duration `koanf:"stickyDuration"`
+
+	// KeyCount is the number of distinct synthetic keys that are used by the key-hash partitioning strategy.
+	KeyCount int `koanf:"keyCount"`
+
+	// Concurrency is the number of producers that send probe messages in parallel. The probed partitions are
+	// distributed across the producers, each of which uses its own sequence numbers and partitioning strategy state.
+	// Increase it if a single producer can't cover all partitions within the probe interval.
+	Concurrency int `koanf:"concurrency"`
+
+	// Headers are added to every probe message. The consumer verifies that they arrive unchanged, because proxies
+	// or interceptors may strip or modify headers.
+	Headers []EndToEndHeaderConfig `koanf:"headers"`
+}
+
+// EndToEndHeaderConfig is a single record header that is added to every probe message
+type EndToEndHeaderConfig struct {
+	Key   string `koanf:"key"`
+	Value string `koanf:"value"`
 }
 
 func (c *EndToEndProducerConfig) SetDefaults() {
 	c.AckSla = 5 * time.Second
-	c.RequiredAcks = "all"
+	c.RequiredAcks = RequiredAcksAll
+	c.MessageSize = 0
+	c.PayloadMode = PayloadModeCompressible
+	c.RateLimit = 0
+	c.Jitter = 0
+	c.Transactional = false
+	c.TransactionalIDPrefix = "kminion-end-to-end"
+	c.Compression = CompressionNone
+	c.Partitions = nil
+	c.TargetEachBrokerOnce = false
+	c.PartitioningStrategy = PartitioningStrategyAll
+	c.StickyDuration = time.Minute
+	c.KeyCount = 100
+	c.Concurrency = 1
+	c.Headers = nil
 }
 
 func (c *EndToEndProducerConfig) Validate() error {
 
-	if c.RequiredAcks != "all" && c.RequiredAcks != "leader" {
-		return fmt.Errorf("producer.requiredAcks must be 'all' or 'leader")
+	switch c.RequiredAcks {
+	case RequiredAcksAll, RequiredAcksLeader, RequiredAcksNone:
+	default:
+		return fmt.Errorf("producer.requiredAcks must be 'all', 'leader' or 'none'")
 	}
 
 	if c.AckSla <= 0 {
 		return fmt.Errorf("producer.ackSla must be greater than zero")
 	}
 
+	if err := validateHistogramBuckets(c.AckSlaBuckets); err != nil {
+		return fmt.Errorf("producer.ackSlaBuckets is invalid: %w", err)
+	}
+
+	if c.MessageSize < 0 {
+		return fmt.Errorf("producer.messageSize must not be negative")
+	}
+
+	if c.RateLimit < 0 {
+		return fmt.Errorf("producer.rateLimit must not be negative")
+	}
+
+	// Higher rates result in a probe interval below 1ns, which would be truncated to 0
+	if c.RateLimit > float64(time.Second) {
+		return fmt.Errorf("producer.rateLimit must not be greater than %d messages per second", int64(time.Second))
+	}
+
+	if c.Jitter < 0 {
+		return fmt.Errorf("producer.jitter must not be negative")
+	}
+
+	if c.Transactional {
+		if c.RequiredAcks != RequiredAcksAll {
+			return fmt.Errorf("producer.requiredAcks must be 'all' if producer.transactional is enabled")
+		}
+		if c.TransactionalIDPrefix == "" {
+			return fmt.Errorf("producer.transactionalIdPrefix must be set if producer.transactional is enabled")
+		}
+	}
+
+	if c.Concurrency < 1 {
+		return fmt.Errorf("producer.concurrency must be at least 1")
+	}
+
+	if c.Transactional && c.Concurrency > 1 {
+		return fmt.Errorf("producer.concurrency must be 1 if producer.transactional is enabled")
+	}
+
+	headerKeys := make(map[string]struct{}, len(c.Headers))
+	for _, header := range c.Headers {
+		if header.Key == "" {
+			return fmt.Errorf("producer.headers must not contain headers without a key")
+		}
+		if _, exists := headerKeys[header.Key]; exists {
+			return fmt.Errorf("producer.headers contains the header '%v' more than once", header.Key)
+		}
+		headerKeys[header.Key] = struct{}{}
+	}
+
+	for _, partition := range c.Partitions {
+		if partition < 0 {
+			return fmt.Errorf("producer.partitions must not contain negative partition ids")
+		}
+	}
+
+	if len(c.Partitions) > 0 && c.TargetEachBrokerOnce {
+		return fmt.Errorf("producer.partitions and producer.targetEachBrokerOnce can not be used at the same time")
+	}
+
+	switch c.PartitioningStrategy {
+	case PartitioningStrategyAll, PartitioningStrategyRoundRobin:
+	case PartitioningStrategySticky:
+		if c.StickyDuration <= 0 {
+			return fmt.Errorf("producer.stickyDuration must be greater than zero")
+		}
+	case PartitioningStrategyKeyHash:
+		if c.KeyCount <= 0 {
+			return fmt.Errorf("producer.keyCount must be greater than zero")
+		}
+	default:
+		return fmt.Errorf("producer.partitioningStrategy '%v' is invalid. Valid values are all, round-robin, sticky or key-hash", c.PartitioningStrategy)
+	}
+
+	switch c.PayloadMode {
+	case PayloadModeCompressible, PayloadModeIncompressible:
+	default:
+		return fmt.Errorf("producer.payloadMode must be '%v' or '%v'", PayloadModeCompressible, PayloadModeIncompressible)
+	}
+
+	switch c.Compression {
+	case CompressionNone, CompressionGzip, CompressionSnappy, CompressionLz4, CompressionZstd:
+	default:
+		return fmt.Errorf("producer.compression '%v' is invalid. Valid values are none, gzip, snappy, lz4 or zstd", c.Compression)
+	}
+
 	return nil
 }
+
+// requiredAcks returns the kgo acks for the configured requiredAcks.
+func (c *EndToEndProducerConfig) requiredAcks() kgo.Acks {
+	switch c.RequiredAcks {
+	case RequiredAcksLeader:
+		return kgo.LeaderAck()
+	case RequiredAcksNone:
+		return kgo.NoAck()
+	default:
+		return kgo.AllISRAcks()
+	}
+}
+
+// compressionCodec returns the kgo compression codec for the configured compression.
+func (c *EndToEndProducerConfig) compressionCodec() kgo.CompressionCodec {
+	switch c.Compression {
+	case CompressionGzip:
+		return kgo.GzipCompression()
+	case CompressionSnappy:
+		return kgo.SnappyCompression()
+	case CompressionLz4:
+		return kgo.Lz4Compression()
+	case CompressionZstd:
+		return kgo.ZstdCompression()
+	default:
+		return kgo.NoCompression()
+	}
+}
+
+// ackSlaBuckets returns the histogram buckets for the produce latency.
+func (c *EndToEndProducerConfig) ackSlaBuckets() []float64 {
+	if len(c.AckSlaBuckets) > 0 {
+		return c.AckSlaBuckets
+	}
+	return createHistogramBuckets(c.AckSla)
+}
@@ -0,0 +1,31 @@
+package e2e
+
+import (
+	"fmt"
+)
+
+type EndToEndResultsTopicConfig struct {
+	// Enabled publishes a result record for each probe message to the results topic, so that the raw probe results
+	// can be analyzed outside of Prometheus.
+	Enabled bool `koanf:"enabled"`
+
+	// Name of the results topic. The topic must already exist, kminion does not create it.
+	Name string `koanf:"name"`
+}
+
+func (c *EndToEndResultsTopicConfig) SetDefaults() {
+	c.Enabled = false
+	c.Name = "kminion-end-to-end-results"
+}
+
+func (c *EndToEndResultsTopicConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+
+	if c.Name == "" {
+		return fmt.Errorf("name must be set if the results topic is enabled")
+	}
+
+	return nil
+}
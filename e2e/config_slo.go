@@ -0,0 +1,60 @@
+package e2e
+
+import (
+	"fmt"
+	"time"
+)
+
+type EndToEndSLOConfig struct {
+	// Enabled exports burn rates for the availability and latency SLOs, which are derived from the outcome of each
+	// probe message.
+	Enabled bool `koanf:"enabled"`
+
+	// AvailabilityObjective is the fraction of probe messages that must make the roundtrip within the roundtrip SLA.
+	AvailabilityObjective float64 `koanf:"availabilityObjective"`
+
+	// LatencyObjective is the fraction of received probe messages whose roundtrip latency must not exceed the
+	// LatencyThreshold.
+	LatencyObjective float64       `koanf:"latencyObjective"`
+	LatencyThreshold time.Duration `koanf:"latencyThreshold"`
+
+	// Windows are the durations over which the burn rates are calculated.
+	Windows []time.Duration `koanf:"windows"`
+}
+
+func (c *EndToEndSLOConfig) SetDefaults() {
+	c.Enabled = false
+	c.AvailabilityObjective = 0.999
+	c.LatencyObjective = 0.99
+	c.LatencyThreshold = 500 * time.Millisecond
+	c.Windows = []time.Duration{5 * time.Minute, time.Hour, 6 * time.Hour}
+}
+
+func (c *EndToEndSLOConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+
+	if c.AvailabilityObjective <= 0 || c.AvailabilityObjective >= 1 {
+		return fmt.Errorf("availabilityObjective must be between 0 and 1")
+	}
+
+	if c.LatencyObjective <= 0 || c.LatencyObjective >= 1 {
+		return fmt.Errorf("latencyObjective must be between 0 and 1")
+	}
+
+	if c.LatencyThreshold <= 0 {
+		return fmt.Errorf("latencyThreshold must be greater than zero")
+	}
+
+	if len(c.Windows) == 0 {
+		return fmt.Errorf("at least one window must be configured")
+	}
+	for _, window := range c.Windows {
+		if window < sloBucketResolution {
+			return fmt.Errorf("window '%v' is invalid, it must be at least %v", window, sloBucketResolution)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,65 @@
+package e2e
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderNameTemplatesStaticMembership(t *testing.T) {
+	t.Setenv("POD_NAME", "kminion-0")
+
+	cfg := Config{}
+	cfg.SetDefaults()
+	cfg.Consumer.StaticMembership = true
+	require.NoError(t, cfg.renderNameTemplates())
+	assert.Equal(t, "kminion-0", cfg.Consumer.InstanceID, "the instance id must default to the pod name")
+
+	cfg = Config{}
+	cfg.SetDefaults()
+	cfg.ClusterName = "prod-eu"
+	cfg.Consumer.StaticMembership = true
+	cfg.Consumer.InstanceID = "{{.ClusterName}}-{{.PodName}}"
+	require.NoError(t, cfg.renderNameTemplates())
+	assert.Equal(t, "prod-eu-kminion-0", cfg.Consumer.InstanceID)
+}
+
+func TestProducerRateLimitValidation(t *testing.T) {
+	cfg := EndToEndProducerConfig{}
+	cfg.SetDefaults()
+
+	cfg.RateLimit = 1e9
+	assert.NoError(t, cfg.Validate())
+
+	cfg.RateLimit = 2e9
+	assert.Error(t, cfg.Validate(), "rates that result in a probe interval below 1ns must be rejected")
+}
+
+func TestGroupMembershipMultipleTopics(t *testing.T) {
+	t.Setenv("POD_NAME", "kminion-0")
+
+	cfg := Config{}
+	cfg.SetDefaults()
+	cfg.Topics = []EndToEndTopicConfig{{Name: "e2e-a"}, {Name: "e2e-b"}}
+	cfg.Consumer.StaticMembership = true
+	require.NoError(t, cfg.renderNameTemplates())
+
+	// The consumers of different topics must neither share a group nor an instance id, otherwise they fence each other
+	var groupIDs, instanceIDs []string
+	for _, topicCfg := range cfg.TopicConfigs() {
+		topicConfig := cfg
+		topicConfig.TopicManagement = topicCfg
+		groupID, instanceID := topicConfig.groupMembership("minion-id")
+		groupIDs = append(groupIDs, groupID)
+		instanceIDs = append(instanceIDs, instanceID)
+	}
+	assert.Equal(t, []string{"kminion-end-to-end-kminion-0-e2e-a", "kminion-end-to-end-kminion-0-e2e-b"}, groupIDs)
+	assert.Equal(t, []string{"kminion-0-e2e-a", "kminion-0-e2e-b"}, instanceIDs)
+
+	// Without static membership each consumer uses its own random group
+	cfg.Consumer.StaticMembership = false
+	groupID, instanceID := cfg.groupMembership("minion-id")
+	assert.Equal(t, "kminion-end-to-end-minion-id", groupID)
+	assert.Empty(t, instanceID)
+}
@@ -11,6 +11,29 @@ type EndToEndTopicConfig struct {
 	ReplicationFactor      int           `koanf:"replicationFactor"`
 	PartitionsPerBroker    int           `koanf:"partitionsPerBroker"`
 	ReconciliationInterval time.Duration `koanf:"reconciliationInterval"`
+
+	// MinInsyncReplicas is the min.insync.replicas config that is set when the topic is created. If set to 0, it will
+	// be derived from the replication factor.
+	MinInsyncReplicas int `koanf:"minInsyncReplicas"`
+
+	// BrokerCheckInterval defines how often we check whether brokers have been added or removed. If the set of brokers
+	// changed, the topic will be reconciled immediately instead of waiting for the next reconciliation interval.
+	BrokerCheckInterval time.Duration `koanf:"brokerCheckInterval"`
+
+	// Configs are topic configs that are set when the topic is created, in addition to (or overriding) kminion's
+	// defaults for cleanup.policy, segment.ms, retention.ms and min.insync.replicas.
+	Configs []TopicConfigEntry `koanf:"configs"`
+
+	// ReconcileConfigs alters the topic configs of an existing topic if they differ from the desired configs, e.g.
+	// because they have been changed by other tooling.
+	ReconcileConfigs bool `koanf:"reconcileConfigs"`
+}
+
+// TopicConfigEntry is a single topic config, such as retention.ms. It's a list entry rather than a map, because
+// config names contain dots, which would be interpreted as nesting.
+type TopicConfigEntry struct {
+	Name  string `koanf:"name"`
+	Value string `koanf:"value"`
 }
 
 func (c *EndToEndTopicConfig) SetDefaults() {
@@ -19,6 +42,10 @@ func (c *EndToEndTopicConfig) SetDefaults() {
 	c.ReplicationFactor = 1
 	c.PartitionsPerBroker = 1
 	c.ReconciliationInterval = 10 * time.Minute
+	c.MinInsyncReplicas = 0
+	c.BrokerCheckInterval = 30 * time.Second
+	c.Configs = nil
+	c.ReconcileConfigs = false
 }
 
 func (c *EndToEndTopicConfig) Validate() error {
@@ -36,5 +63,46 @@ func (c *EndToEndTopicConfig) Validate() error {
 		return fmt.Errorf("failed to validate topic.ReconciliationInterval config, the duration can't be zero")
 	}
 
+	if c.BrokerCheckInterval <= 0 {
+		return fmt.Errorf("failed to validate topic.BrokerCheckInterval config, the duration must be greater than zero")
+	}
+
+	if c.MinInsyncReplicas < 0 || c.MinInsyncReplicas > c.ReplicationFactor {
+		return fmt.Errorf("failed to parse minInsyncReplicas, it must be between 0 and the replication factor, retrieved value %v", c.MinInsyncReplicas)
+	}
+
+	for _, entry := range c.Configs {
+		if entry.Name == "" {
+			return fmt.Errorf("failed to validate topic configs, all configs must have a name")
+		}
+	}
+
 	return nil
 }
+
+// inheritFrom returns a copy of the topic config where all unset properties are taken from the given base config.
+// Topic management can only be enabled or disabled for all topics at once, hence Enabled and ReconcileConfigs are
+// always inherited.
+func (c EndToEndTopicConfig) inheritFrom(base EndToEndTopicConfig) EndToEndTopicConfig {
+	c.Enabled = base.Enabled
+	c.ReconcileConfigs = base.ReconcileConfigs
+	if c.Configs == nil {
+		c.Configs = base.Configs
+	}
+	if c.ReplicationFactor == 0 {
+		c.ReplicationFactor = base.ReplicationFactor
+	}
+	if c.PartitionsPerBroker == 0 {
+		c.PartitionsPerBroker = base.PartitionsPerBroker
+	}
+	if c.ReconciliationInterval == 0 {
+		c.ReconciliationInterval = base.ReconciliationInterval
+	}
+	if c.MinInsyncReplicas == 0 {
+		c.MinInsyncReplicas = base.MinInsyncReplicas
+	}
+	if c.BrokerCheckInterval == 0 {
+		c.BrokerCheckInterval = base.BrokerCheckInterval
+	}
+	return c
+}
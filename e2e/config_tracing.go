@@ -0,0 +1,51 @@
+package e2e
+
+import (
+	"fmt"
+)
+
+type EndToEndTracingConfig struct {
+	// Enabled emits an OpenTelemetry trace for each probe message, which contains spans for producing, awaiting the
+	// broker's ack and consuming the message. Offset commits are traced as separate spans.
+	Enabled bool `koanf:"enabled"`
+
+	// Endpoint is the host and port of the OTLP/HTTP receiver the traces shall be sent to (e.g. "localhost:4318").
+	Endpoint string `koanf:"endpoint"`
+
+	// Insecure disables TLS for the connection to the OTLP receiver.
+	Insecure bool `koanf:"insecure"`
+
+	// ServiceName is reported as service.name resource attribute of all spans.
+	ServiceName string `koanf:"serviceName"`
+
+	// SampleRatio is the fraction of probe messages that shall be traced, between 0 and 1.
+	SampleRatio float64 `koanf:"sampleRatio"`
+}
+
+func (c *EndToEndTracingConfig) SetDefaults() {
+	c.Enabled = false
+	c.Endpoint = "localhost:4318"
+	c.Insecure = false
+	c.ServiceName = "kminion"
+	c.SampleRatio = 1
+}
+
+func (c *EndToEndTracingConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+
+	if c.Endpoint == "" {
+		return fmt.Errorf("endpoint must be set if tracing is enabled")
+	}
+
+	if c.ServiceName == "" {
+		return fmt.Errorf("serviceName must be set if tracing is enabled")
+	}
+
+	if c.SampleRatio < 0 || c.SampleRatio > 1 {
+		return fmt.Errorf("sampleRatio must be between 0 and 1")
+	}
+
+	return nil
+}
@@ -8,9 +8,15 @@ import (
 
 	"github.com/twmb/franz-go/pkg/kgo"
 	"github.com/twmb/franz-go/pkg/kmsg"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 )
 
+// timestampTypeLogAppendTime is the timestamp type of records whose timestamp has been set by the broker
+const timestampTypeLogAppendTime = 1
+
 func (s *Service) startConsumeMessages(ctx context.Context, initializedCh chan<- bool) {
 	client := s.client
 
@@ -37,6 +43,7 @@ func (s *Service) startConsumeMessages(ctx context.Context, initializedCh chan<-
 		}
 
 		fetches.EachRecord(s.processMessage)
+		fetches.EachPartition(s.updateConsumerLag)
 	}
 }
 
@@ -48,16 +55,24 @@ func (s *Service) commitOffsets(ctx context.Context) {
 	}
 
 	startCommitTimestamp := time.Now()
+	_, commitSpan := s.tracer.Start(ctx, "commit offsets", trace.WithAttributes(
+		attribute.String("messaging.consumer.group.name", s.groupId),
+	))
 
 	childCtx, cancel := context.WithTimeout(ctx, s.config.Consumer.CommitSla)
 	client.CommitOffsets(childCtx, uncommittedOffset, func(_ *kgo.Client, req *kmsg.OffsetCommitRequest, r *kmsg.OffsetCommitResponse, err error) {
 		cancel()
+		defer commitSpan.End()
 
 		coordinator := s.clientHooks.currentCoordinator.Load().(kgo.BrokerMetadata)
 		coordinatorID := strconv.Itoa(int(coordinator.NodeID))
+		commitSpan.SetAttributes(attribute.Int("kminion.coordinator_id", int(coordinator.NodeID)))
 
 		latency := time.Since(startCommitTimestamp)
-		s.offsetCommitLatency.WithLabelValues(coordinatorID).Observe(latency.Seconds())
+		observeLatency(s.offsetCommitLatency, s.offsetCommitLatencySummary, latency, nil, coordinatorID)
+		if latency > s.config.Consumer.CommitSla && !s.isWarmingUp(startCommitTimestamp) {
+			s.commitSlaViolations.WithLabelValues().Inc()
+		}
 		s.offsetCommitsTotal.WithLabelValues(coordinatorID).Inc()
 		// We do this to ensure that a series with that coordinator id is initialized
 		s.offsetCommitsTotal.WithLabelValues(coordinatorID).Add(0)
@@ -66,11 +81,35 @@ func (s *Service) commitOffsets(ctx context.Context) {
 		// reason as label.
 		if errCode := s.logCommitErrors(r, err); errCode != "" {
 			s.offsetCommitsFailedTotal.WithLabelValues(coordinatorID, errCode).Inc()
+			commitSpan.SetStatus(codes.Error, errCode)
 			return
 		}
 	})
 }
 
+// updateConsumerLag reports how far our consumer is behind the high watermark of the fetched partition, which tells
+// whether the roundtrip latencies are still meaningful.
+func (s *Service) updateConsumerLag(p kgo.FetchTopicPartition) {
+	if p.Err != nil {
+		return
+	}
+
+	// If the fetch contains no records of the partition, the consumer is still positioned after the last consumed record
+	nextOffset, consumed := s.clientHooks.consumedOffset(p.Partition)
+	if len(p.Records) > 0 {
+		nextOffset, consumed = p.Records[len(p.Records)-1].Offset+1, true
+	}
+	if !consumed {
+		return
+	}
+
+	lag := p.HighWatermark - nextOffset
+	if lag < 0 {
+		lag = 0
+	}
+	s.clientHooks.recordConsumerLag(p.Partition, nextOffset, lag)
+}
+
 // processMessage:
 // - deserializes the message
 // - checks if it is from us, or from another kminion process running somewhere else
@@ -93,5 +132,53 @@ func (s *Service) processMessage(record *kgo.Record) {
 
 	// restore partition, which is not serialized
 	msg.partition = int(record.Partition)
+	if isDuplicate := s.sequenceTracker.onMessageArrived(msg.Producer, msg.partition, msg.MessageID, msg.Sequence); isDuplicate {
+		s.logger.Debug("received duplicated message",
+			zap.Int("partition", msg.partition),
+			zap.String("message_id", msg.MessageID))
+		return
+	}
+	s.validateHeaders(record, &msg)
+	s.updateClockSkew(record, &msg)
 	s.messageTracker.onMessageArrived(&msg)
 }
+
+// validateHeaders checks whether the configured headers arrived unchanged. Headers that are missing or whose value
+// has been modified are counted as corrupted.
+func (s *Service) validateHeaders(record *kgo.Record, msg *EndToEndMessage) {
+	for _, expected := range s.config.Producer.Headers {
+		found := false
+		for _, header := range record.Headers {
+			if header.Key == expected.Key && string(header.Value) == expected.Value {
+				found = true
+				break
+			}
+		}
+		if found {
+			continue
+		}
+
+		s.headerCorruptions.WithLabelValues(expected.Key).Inc()
+		s.logger.Debug("received message with missing or modified header",
+			zap.Int("partition", msg.partition),
+			zap.String("message_id", msg.MessageID),
+			zap.String("header_key", expected.Key))
+	}
+}
+
+// updateClockSkew compares the time at which the partition leader appended the message with the time at which
+// kminion created it, which reveals brokers whose clocks are off. This is only possible if the topic is configured
+// with message.timestamp.type=LogAppendTime, otherwise the record's timestamp is the one set by our producer.
+// The reported skew includes the time it took to send the message to the broker.
+func (s *Service) updateClockSkew(record *kgo.Record, msg *EndToEndMessage) {
+	if record.Attrs.TimestampType() != timestampTypeLogAppendTime {
+		return
+	}
+
+	leaderID, exists := s.clientHooks.partitionLeader(record.Partition)
+	if !exists {
+		return
+	}
+	skew := record.Timestamp.Sub(msg.creationTime())
+	s.clockSkew.WithLabelValues(strconv.Itoa(int(leaderID))).Set(skew.Seconds())
+}
@@ -1,6 +1,11 @@
 package e2e
 
-import "time"
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel/trace"
+)
 
 const (
 	_ = iota
@@ -9,16 +14,30 @@ const (
 )
 
 type EndToEndMessage struct {
-	MinionID  string `json:"minionID"`     // unique for each running kminion instance
-	MessageID string `json:"messageID"`    // unique for each message
-	Timestamp int64  `json:"createdUtcNs"` // when the message was created, unix nanoseconds
+	MinionID  string `json:"minionID"`          // unique for each running kminion instance
+	MessageID string `json:"messageID"`         // unique for each message
+	Timestamp int64  `json:"createdUtcNs"`      // when the message was created, unix nanoseconds
+	Producer  int    `json:"producer"`          // index of the producer that sent the message, each has its own sequence
+	Sequence  int64  `json:"seq"`               // monotonically increasing per producer and partition, used to detect gaps
+	Payload   string `json:"payload,omitempty"` // padding to reach the configured message size
 
 	// The following properties are only used within the message tracker
 	partition      int
 	state          int
 	produceLatency float64
+	span           trace.Span // spans the message's entire roundtrip, no-op if tracing is disabled
 }
 
 func (m *EndToEndMessage) creationTime() time.Time {
 	return time.Unix(0, m.Timestamp)
 }
+
+// exemplar returns the labels that shall be attached as exemplar to latency observations of this message. If the
+// message is traced, the trace id is attached as well.
+func (m *EndToEndMessage) exemplar() prometheus.Labels {
+	labels := prometheus.Labels{"message_id": m.MessageID}
+	if m.span != nil && m.span.SpanContext().IsSampled() {
+		labels["trace_id"] = m.span.SpanContext().TraceID().String()
+	}
+	return labels
+}
@@ -5,6 +5,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/twmb/franz-go/pkg/kerr"
 	"github.com/twmb/franz-go/pkg/kgo"
 	"github.com/twmb/franz-go/pkg/kmsg"
@@ -27,15 +28,19 @@ type groupTracker struct {
 	client                 *kgo.Client          // kafka client
 	groupId                string               // our own groupId
 	potentiallyEmptyGroups map[string]time.Time // groupName -> utc timestamp when the group was first seen
+	isDeletionAuthorized   bool                 // set to false once a delete request failed due to missing permissions
+	deletedGroupsTotal     prometheus.Counter   // number of groups we have deleted
 }
 
-func newGroupTracker(cfg Config, logger *zap.Logger, client *kgo.Client, groupID string) *groupTracker {
+func newGroupTracker(cfg Config, logger *zap.Logger, client *kgo.Client, groupID string, deletedGroupsTotal prometheus.Counter) *groupTracker {
 	return &groupTracker{
 		cfg:                    cfg,
 		logger:                 logger.Named("group_tracker"),
 		client:                 client,
 		groupId:                groupID,
 		potentiallyEmptyGroups: make(map[string]time.Time),
+		isDeletionAuthorized:   true,
+		deletedGroupsTotal:     deletedGroupsTotal,
 	}
 }
 
@@ -55,6 +60,11 @@ func (g *groupTracker) start(ctx context.Context) {
 				g.logger.Error("failed to check for old consumer groups: %w", zap.Error(err))
 			}
 			cancel()
+
+			if !g.isDeletionAuthorized {
+				g.logger.Debug("stopping group tracker, not authorized to delete consumer groups")
+				return
+			}
 		}
 	}
 }
@@ -166,9 +176,11 @@ func (g *groupTracker) checkAndDeleteOldConsumerGroups(ctx context.Context) erro
 		}
 	}
 	g.logger.Info("deleted old consumer groups", zap.Strings("deleted_groups", deletedGroups))
+	g.deletedGroupsTotal.Add(float64(len(deletedGroups)))
 
 	if foundNotAuthorizedError {
 		g.logger.Info("disabling trying to delete old kminion consumer-groups since one of the last delete results had an 'GroupAuthorizationFailed' error")
+		g.isDeletionAuthorized = false
 	}
 
 	return nil
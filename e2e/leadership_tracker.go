@@ -0,0 +1,41 @@
+package e2e
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// leadershipTracker measures how long producing to a partition is disrupted, e.g. while its leadership moves to
+// another broker during a rolling restart. A disruption starts when the first failed or slow (exceeding the ack SLA)
+// produce has been sent and ends once the next successful produce has been acknowledged.
+type leadershipTracker struct {
+	mutex          sync.Mutex
+	disruptedSince map[int]time.Time // partition id -> start time of the first failed or slow produce
+
+	disruptionDuration prometheus.Histogram
+}
+
+func newLeadershipTracker(disruptionDuration prometheus.Histogram) *leadershipTracker {
+	return &leadershipTracker{
+		disruptedSince:     make(map[int]time.Time),
+		disruptionDuration: disruptionDuration,
+	}
+}
+
+// onAck must be called for every produce response with the time the produce has been started, isDisrupted indicates
+// whether producing failed or was slow.
+func (t *leadershipTracker) onAck(partition int, startTime time.Time, isDisrupted bool) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	since, wasDisrupted := t.disruptedSince[partition]
+	switch {
+	case isDisrupted && (!wasDisrupted || startTime.Before(since)):
+		t.disruptedSince[partition] = startTime
+	case !isDisrupted && wasDisrupted:
+		t.disruptionDuration.Observe(time.Since(since).Seconds())
+		delete(t.disruptedSince, partition)
+	}
+}
@@ -0,0 +1,26 @@
+package e2e
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLeadershipTrackerDisruption(t *testing.T) {
+	histogram := prometheus.NewHistogram(prometheus.HistogramOpts{Name: "produce_disruption_duration_seconds"})
+	tracker := newLeadershipTracker(histogram)
+
+	// The disruption starts when the first failed produce has been sent, not when its ack arrives
+	tracker.onAck(0, time.Now().Add(-10*time.Second), true)
+	tracker.onAck(0, time.Now().Add(-5*time.Second), true)
+	tracker.onAck(0, time.Now(), false)
+
+	metric := &dto.Metric{}
+	require.NoError(t, histogram.Write(metric))
+	assert.Equal(t, uint64(1), metric.GetHistogram().GetSampleCount())
+	assert.InDelta(t, 10, metric.GetHistogram().GetSampleSum(), 1)
+}
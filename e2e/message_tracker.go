@@ -1,11 +1,14 @@
 package e2e
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 	"time"
 
 	"github.com/jellydator/ttlcache/v2"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 
 	"go.uber.org/zap"
 )
@@ -14,9 +17,8 @@ import (
 //
 // When we successfully send a mesasge, it will be added to this tracker.
 // Later, when we receive the message back in the consumer, the message is marked as completed and removed from the tracker.
-// If the message does not arrive within the configured `consumer.roundtripSla`, it is counted as lost. Messages that
-// failed to be produced will not be
-// considered as lost message.
+// If the message does not arrive within the configured `consumer.roundtripSla`, it is no longer tracked. Whether such
+// a message arrived late or got lost is determined by the sequenceTracker.
 //
 // We use a dedicated counter to track messages that couldn't be  produced to Kafka.
 type messageTracker struct {
@@ -43,6 +45,7 @@ func newMessageTracker(svc *Service) *messageTracker {
 }
 
 func (t *messageTracker) addToTracker(msg *EndToEndMessage) {
+	t.svc.messagesInFlight.WithLabelValues(strconv.Itoa(msg.partition)).Inc()
 	t.cache.Set(msg.MessageID, msg)
 }
 
@@ -99,25 +102,46 @@ func (t *messageTracker) onMessageArrived(arrivedMessage *EndToEndMessage) {
 	latency := time.Now().Sub(msg.creationTime())
 
 	if !isExpired {
-		// Message arrived late, but was still in cache. We don't increment the lost counter here because eventually
-		// it will be evicted from the cache. This case should only pop up if the sla time is exceeded, but if the
-		// item has not been evicted from the cache yet.
-		t.logger.Info("message arrived late, will be marked as a lost message",
+		// Message arrived late, but was still in cache. This case should only pop up if the sla time is exceeded, but
+		// if the item has not been evicted from the cache yet.
+		t.logger.Info("message arrived late",
 			zap.Int64("delay_ms", latency.Milliseconds()),
 			zap.String("id", msg.MessageID))
+		msg.span.SetStatus(codes.Error, "message arrived after the roundtrip sla")
+		msg.span.End()
 		return
 	}
 
 	// message arrived early enough
+	_, consumeSpan := t.svc.tracer.Start(trace.ContextWithSpan(context.Background(), msg.span), "consume")
+	defer msg.span.End()
+	defer consumeSpan.End()
+
 	pID := strconv.Itoa(msg.partition)
 	t.svc.messagesReceived.WithLabelValues(pID).Inc()
-	t.svc.roundtripLatency.WithLabelValues(pID).Observe(latency.Seconds())
+	t.svc.roundtripSlaMet.WithLabelValues().Set(1)
+	t.svc.publishResult(msg, ResultStatusReceived, latency)
+	if t.svc.sloTracker != nil && !t.svc.isWarmingUp(msg.creationTime()) {
+		t.svc.sloTracker.onMessageReceived(latency)
+	}
+	observeLatency(t.svc.roundtripLatency, t.svc.roundtripLatencySummary, latency, msg.exemplar(), t.svc.partitionLabelValues(msg.partition)...)
+	if leaderID, exists := t.svc.clientHooks.partitionLeader(int32(msg.partition)); exists {
+		observeWithExemplar(t.svc.brokerRoundtripLatency.WithLabelValues(strconv.Itoa(int(leaderID))), latency, msg.exemplar())
+	}
+	if t.svc.replicaRoundtripLatency != nil {
+		if replicaType, exists := t.svc.clientHooks.replicaType(int32(msg.partition)); exists {
+			observeWithExemplar(t.svc.replicaRoundtripLatency.WithLabelValues(replicaType), latency, msg.exemplar())
+		}
+	}
 
 	// Remove message from cache, so that we don't track it any longer and won't mark it as lost when the entry expires.
 	t.cache.Remove(msg.MessageID)
 }
 
 func (t *messageTracker) onMessageExpired(_ string, reason ttlcache.EvictionReason, value interface{}) {
+	// Every eviction ends the message's flight, no matter whether it arrived, failed to be produced, was aborted or expired
+	t.svc.messagesInFlight.WithLabelValues(strconv.Itoa(value.(*EndToEndMessage).partition)).Dec()
+
 	if reason == ttlcache.Removed {
 		// We are not interested in messages that have been removed by us!
 		return
@@ -127,9 +151,20 @@ func (t *messageTracker) onMessageExpired(_ string, reason ttlcache.EvictionReas
 
 	created := msg.creationTime()
 	age := time.Since(created)
-	t.svc.lostMessages.WithLabelValues(strconv.Itoa(msg.partition)).Inc()
+	msg.span.SetStatus(codes.Error, "message did not arrive within the roundtrip sla")
+	msg.span.End()
+	t.svc.publishResult(msg, ResultStatusExpired, 0)
+
+	// Messages that have been sent while the consumer group was still joining are expected to be late
+	if !t.svc.isWarmingUp(created) {
+		t.svc.roundtripSlaViolations.WithLabelValues().Inc()
+		t.svc.roundtripSlaMet.WithLabelValues().Set(0)
+		if t.svc.sloTracker != nil {
+			t.svc.sloTracker.onMessageFailed()
+		}
+	}
 
-	t.logger.Debug("message expired/lost",
+	t.logger.Debug("message did not arrive within the roundtrip sla",
 		zap.Int64("age_ms", age.Milliseconds()),
 		zap.Int("partition", msg.partition),
 		zap.String("message_id", msg.MessageID),
@@ -0,0 +1,43 @@
+package e2e
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestMessageTrackerMessagesInFlight(t *testing.T) {
+	svc := &Service{
+		config:           Config{Consumer: EndToEndConsumerConfig{RoundtripSla: time.Minute}},
+		logger:           zap.NewNop(),
+		messagesInFlight: prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: "messages_in_flight"}, []string{"partition_id"}),
+	}
+	tracker := newMessageTracker(svc)
+
+	tracker.addToTracker(&EndToEndMessage{MessageID: "a", partition: 0})
+	tracker.addToTracker(&EndToEndMessage{MessageID: "b", partition: 0})
+	tracker.addToTracker(&EndToEndMessage{MessageID: "c", partition: 1})
+	assert.Equal(t, 2.0, testutil.ToFloat64(svc.messagesInFlight.WithLabelValues("0")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(svc.messagesInFlight.WithLabelValues("1")))
+
+	// Messages removed after an aborted transaction or a failed produce are no longer in flight
+	// The eviction callbacks are invoked asynchronously
+	require.NoError(t, tracker.removeFromTracker("a"))
+	require.NoError(t, tracker.removeFromTracker("c"))
+	assert.Eventually(t, func() bool {
+		return testutil.ToFloat64(svc.messagesInFlight.WithLabelValues("0")) == 1 &&
+			testutil.ToFloat64(svc.messagesInFlight.WithLabelValues("1")) == 0
+	}, time.Second, 10*time.Millisecond)
+
+	// Received messages are removed the same way
+	require.NoError(t, tracker.removeFromTracker("b"))
+	assert.Eventually(t, func() bool {
+		return testutil.ToFloat64(svc.messagesInFlight.WithLabelValues("0")) == 0
+	}, time.Second, 10*time.Millisecond)
+	require.NoError(t, tracker.cache.Close())
+}
@@ -0,0 +1,89 @@
+package e2e
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/twmb/franz-go/pkg/kgo"
+)
+
+// probeTarget is the partition (and optionally the key) a single probe message shall be produced to
+type probeTarget struct {
+	partition int
+	key       []byte
+}
+
+// probePartitioner decides which of the probed partitions receive a message in each interval, depending on the
+// configured partitioning strategy.
+type probePartitioner struct {
+	cfg EndToEndProducerConfig
+
+	mutex sync.Mutex
+
+	// round-robin
+	nextIndex int
+
+	// sticky
+	stickyIndex int
+	stickyUntil time.Time
+
+	// key-hash
+	nextKey   int
+	keyHasher kgo.TopicPartitioner
+}
+
+func newProbePartitioner(cfg EndToEndProducerConfig) *probePartitioner {
+	return &probePartitioner{
+		cfg:       cfg,
+		keyHasher: kgo.StickyKeyPartitioner(nil).ForTopic(""),
+	}
+}
+
+// nextTargets returns the targets for the next round of probe messages. The partition count is the number of all
+// partitions of the topic, including those that are not probed.
+func (p *probePartitioner) nextTargets(probePartitions []int, partitionCount int) []probeTarget {
+	if len(probePartitions) == 0 {
+		return nil
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	switch p.cfg.PartitioningStrategy {
+	case PartitioningStrategyRoundRobin:
+		index := p.nextIndex % len(probePartitions)
+		p.nextIndex = index + 1
+		return []probeTarget{{partition: probePartitions[index]}}
+	case PartitioningStrategySticky:
+		now := time.Now()
+		if now.After(p.stickyUntil) {
+			p.stickyIndex = rand.Intn(len(probePartitions))
+			p.stickyUntil = now.Add(p.cfg.StickyDuration)
+		}
+		return []probeTarget{{partition: probePartitions[p.stickyIndex%len(probePartitions)]}}
+	case PartitioningStrategyKeyHash:
+		// Keys are hashed over all partitions of the topic, the same way as by the Java client's default partitioner
+		// (murmur2). Keys that are hashed to a partition which is not probed are skipped.
+		if partitionCount <= 0 {
+			return nil
+		}
+		for i := 0; i < p.cfg.KeyCount; i++ {
+			key := []byte(fmt.Sprintf("kminion-key-%d", p.nextKey))
+			p.nextKey = (p.nextKey + 1) % p.cfg.KeyCount
+			partition := p.keyHasher.Partition(&kgo.Record{Key: key}, partitionCount)
+			if slices.Contains(probePartitions, partition) {
+				return []probeTarget{{partition: partition, key: key}}
+			}
+		}
+		return nil
+	default:
+		targets := make([]probeTarget, len(probePartitions))
+		for i, partition := range probePartitions {
+			targets[i] = probeTarget{partition: partition}
+		}
+		return targets
+	}
+}
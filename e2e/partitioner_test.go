@@ -0,0 +1,33 @@
+package e2e
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twmb/franz-go/pkg/kgo"
+)
+
+func TestProbePartitionerKeyHash(t *testing.T) {
+	cfg := EndToEndProducerConfig{}
+	cfg.SetDefaults()
+	cfg.PartitioningStrategy = PartitioningStrategyKeyHash
+	javaPartitioner := kgo.StickyKeyPartitioner(nil).ForTopic("")
+
+	// Keys are hashed over all partitions of the topic, like by the Java client
+	partitioner := newProbePartitioner(cfg)
+	for i := 0; i < 20; i++ {
+		targets := partitioner.nextTargets([]int{0, 1, 2, 3, 4, 5}, 6)
+		require.Len(t, targets, 1)
+		assert.Equal(t, javaPartitioner.Partition(&kgo.Record{Key: targets[0].key}, 6), targets[0].partition)
+	}
+
+	// Only the probed partitions receive messages, even though the keys are hashed over all partitions
+	partitioner = newProbePartitioner(cfg)
+	for i := 0; i < 20; i++ {
+		targets := partitioner.nextTargets([]int{4}, 6)
+		require.Len(t, targets, 1)
+		assert.Equal(t, 4, targets[0].partition)
+		assert.Equal(t, 4, javaPartitioner.Partition(&kgo.Record{Key: targets[0].key}, 6))
+	}
+}
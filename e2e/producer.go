@@ -3,27 +3,121 @@ package e2e
 import (
 	"context"
 	"encoding/json"
+	"math/rand"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/twmb/franz-go/pkg/kgo"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 )
 
-// produceMessagesToAllPartitions sends an EndToEndMessage to every partition on the given topic
-func (s *Service) produceMessagesToAllPartitions(ctx context.Context) {
-	for i := 0; i < s.partitionCount; i++ {
-		s.produceMessage(ctx, i)
+// produceProbeMessages sends an EndToEndMessage to the partitions that are probed by the given producer. By default
+// every partition receives a message, see selectProbePartitions, producerPartitions and probePartitioner.
+func (s *Service) produceProbeMessages(ctx context.Context, producer int, partitioner *probePartitioner) {
+	targets := partitioner.nextTargets(s.producerPartitions(producer), int(s.partitionCount.Load()))
+	if s.config.Producer.Transactional {
+		s.produceMessagesInTransaction(ctx, producer, targets)
+		return
+	}
+
+	for _, target := range targets {
+		s.produceMessage(ctx, producer, target)
+	}
+}
+
+// producerPartitions returns the share of the probed partitions that the given producer sends messages to. The
+// partitions are distributed evenly across all producers.
+func (s *Service) producerPartitions(producer int) []int {
+	probePartitions := s.getProbePartitions()
+	concurrency := s.config.Producer.Concurrency
+	if concurrency <= 1 {
+		return probePartitions
+	}
+
+	partitions := make([]int, 0, len(probePartitions)/concurrency+1)
+	for i, partition := range probePartitions {
+		if i%concurrency == producer {
+			partitions = append(partitions, partition)
+		}
+	}
+	return partitions
+}
+
+// produceMessagesInTransaction sends an EndToEndMessage to each of the given targets within a single
+// transaction. If the transaction can not be committed, it will be aborted and the produced messages are removed
+// from the message tracker, as they will never become visible to our read_committed consumer.
+func (s *Service) produceMessagesInTransaction(ctx context.Context, producer int, targets []probeTarget) {
+	beginStart := time.Now()
+	if err := s.client.BeginTransaction(); err != nil {
+		s.logger.Error("failed to begin transaction", zap.Error(err))
+		return
+	}
+	s.transactionBeginLatency.WithLabelValues().Observe(time.Since(beginStart).Seconds())
+
+	messages := make([]*EndToEndMessage, 0, len(targets))
+	for _, target := range targets {
+		messages = append(messages, s.produceMessage(ctx, producer, target))
+	}
+
+	childCtx, cancel := context.WithTimeout(ctx, s.config.Producer.AckSla)
+	defer cancel()
+
+	commit := kgo.TryCommit
+	if err := s.client.Flush(childCtx); err != nil {
+		s.logger.Warn("failed to flush transactional messages, aborting transaction", zap.Error(err))
+		commit = kgo.TryAbort
+		if err := s.client.AbortBufferedRecords(childCtx); err != nil {
+			s.logger.Error("failed to abort buffered records", zap.Error(err))
+		}
+	}
+
+	commitStart := time.Now()
+	err := s.client.EndTransaction(childCtx, commit)
+	if commit == kgo.TryCommit && err == nil {
+		s.transactionCommitLatency.WithLabelValues().Observe(time.Since(commitStart).Seconds())
+		s.transactionsCommitted.WithLabelValues().Inc()
+		return
+	}
+
+	if err != nil {
+		s.logger.Warn("failed to end transaction", zap.Error(err))
+	}
+	s.transactionsAborted.WithLabelValues().Inc()
+	for _, msg := range messages {
+		// Removed messages are not ended by the message tracker. Messages that are no longer tracked have already
+		// been ended, because they failed to be produced or expired.
+		if err := s.messageTracker.removeFromTracker(msg.MessageID); err == nil {
+			msg.span.SetStatus(codes.Error, "transaction aborted")
+			msg.span.End()
+		}
+		s.sequenceTracker.onProduceFailed(msg.Producer, msg.partition, msg.Sequence)
 	}
 }
 
-// produceMessage produces an end to end record to a single given partition. If it succeeds producing the record
+// produceMessage produces an end to end record to a single given partition (and with the target's key). If it succeeds producing the record
 // it will add it to the message tracker. If producing fails a message will be logged and the respective metrics
-// will be incremented.
-func (s *Service) produceMessage(ctx context.Context, partition int) {
+// will be incremented. The produced message is returned.
+func (s *Service) produceMessage(ctx context.Context, producer int, target probeTarget) *EndToEndMessage {
 	topicName := s.config.TopicManagement.Name
-	record, msg := createEndToEndRecord(s.minionID, topicName, partition)
+	partition := target.partition
+	seq := s.sequenceTracker.nextSequence(producer, partition)
+	record, msg := createEndToEndRecord(s.minionID, topicName, partition, producer, seq, s.config.Producer)
+	record.Key = target.key
+
+	// The roundtrip span is ended by the message tracker, once the message has been consumed or expired
+	spanCtx, roundtripSpan := s.tracer.Start(ctx, "end-to-end roundtrip", trace.WithAttributes(
+		attribute.String("messaging.destination.name", topicName),
+		attribute.Int("messaging.destination.partition.id", partition),
+		attribute.String("messaging.message.id", msg.MessageID),
+	))
+	msg.span = roundtripSpan
+	_, produceSpan := s.tracer.Start(spanCtx, "produce")
+	_, ackSpan := s.tracer.Start(spanCtx, "await broker ack")
 
 	startTime := time.Now()
 
@@ -36,7 +130,13 @@ func (s *Service) produceMessage(ctx context.Context, partition int) {
 	s.messageTracker.addToTracker(msg)
 	s.client.TryProduce(childCtx, record, func(r *kgo.Record, err error) {
 		defer cancel()
+		defer ackSpan.End()
 		ackDuration := time.Since(startTime)
+		isWarmingUp := s.isWarmingUp(startTime)
+		if ackDuration > s.config.Producer.AckSla && !isWarmingUp {
+			s.ackSlaViolations.WithLabelValues().Inc()
+		}
+		s.leadership.onAck(partition, startTime, err != nil || ackDuration > s.config.Producer.AckSla)
 		s.messagesProducedInFlight.WithLabelValues(pID).Dec()
 		s.messagesProducedTotal.WithLabelValues(pID).Inc()
 		// We add 0 in order to ensure that the "failed" metric series for that partition id are initialized as well.
@@ -45,11 +145,23 @@ func (s *Service) produceMessage(ctx context.Context, partition int) {
 
 		if err != nil {
 			s.messagesProducedFailed.WithLabelValues(pID).Inc()
+			s.clientHooks.recordProduceError(err)
+			if s.sloTracker != nil && !isWarmingUp {
+				s.sloTracker.onMessageFailed()
+			}
 			_ = s.messageTracker.removeFromTracker(msg.MessageID)
+			s.sequenceTracker.onProduceFailed(producer, partition, msg.Sequence)
+			msg.produceLatency = ackDuration.Seconds()
+			s.publishResult(msg, ResultStatusProduceFailed, 0)
+			ackSpan.RecordError(err)
+			ackSpan.SetStatus(codes.Error, "failed to produce message")
+			roundtripSpan.SetStatus(codes.Error, "failed to produce message")
+			roundtripSpan.End()
 
 			s.logger.Info("failed to produce message to end-to-end topic",
 				zap.String("topic_name", r.Topic),
 				zap.Int32("partition", r.Partition),
+				zap.String("error_code", produceErrorCode(err)),
 				zap.Error(err))
 			return
 		} else {
@@ -67,15 +179,23 @@ func (s *Service) produceMessage(ctx context.Context, partition int) {
 			// s.messageTracker.updateItemIfExists(msg)
 		}
 
-		s.produceLatency.WithLabelValues(pID).Observe(ackDuration.Seconds())
+		observeLatency(s.produceLatency, s.produceLatencySummary, ackDuration, msg.exemplar(), s.partitionLabelValues(partition)...)
+		if leaderID, exists := s.clientHooks.partitionLeader(r.Partition); exists {
+			observeWithExemplar(s.brokerProduceLatency.WithLabelValues(strconv.Itoa(int(leaderID))), ackDuration, msg.exemplar())
+		}
 	})
+	produceSpan.End()
+
+	return msg
 }
 
-func createEndToEndRecord(minionID string, topicName string, partition int) (*kgo.Record, *EndToEndMessage) {
+func createEndToEndRecord(minionID string, topicName string, partition int, producer int, seq int64, cfg EndToEndProducerConfig) (*kgo.Record, *EndToEndMessage) {
 	message := &EndToEndMessage{
 		MinionID:  minionID,
 		MessageID: uuid.NewString(),
 		Timestamp: time.Now().UnixNano(),
+		Producer:  producer,
+		Sequence:  seq,
 
 		partition: partition,
 		state:     EndToEndMessageStateCreated,
@@ -88,11 +208,39 @@ func createEndToEndRecord(minionID string, topicName string, partition int) (*kg
 		panic("cannot serialize EndToEndMessage")
 	}
 
+	// Pad the message so that the serialized value roughly matches the configured message size
+	paddingSize := cfg.MessageSize - len(mjson) - len(`,"payload":""`)
+	if paddingSize > 0 {
+		message.Payload = createPayload(paddingSize, cfg.PayloadMode)
+		mjson, err = json.Marshal(message)
+		if err != nil {
+			panic("cannot serialize EndToEndMessage")
+		}
+	}
+
 	record := &kgo.Record{
 		Topic:     topicName,
 		Value:     mjson,
 		Partition: int32(partition), // we set partition for producing so our customPartitioner can make use of it
 	}
+	for _, header := range cfg.Headers {
+		record.Headers = append(record.Headers, kgo.RecordHeader{Key: header.Key, Value: []byte(header.Value)})
+	}
 
 	return record, message
 }
+
+// createPayload returns a string of the given size. Compressible payloads consist of a single repeated character,
+// while incompressible payloads are made of random alphanumeric characters.
+func createPayload(size int, mode string) string {
+	if mode != PayloadModeIncompressible {
+		return strings.Repeat("x", size)
+	}
+
+	const alphabet = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+	payload := make([]byte, size)
+	for i := range payload {
+		payload[i] = alphabet[rand.Intn(len(alphabet))]
+	}
+	return string(payload)
+}
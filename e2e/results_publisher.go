@@ -0,0 +1,76 @@
+package e2e
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/twmb/franz-go/pkg/kgo"
+	"go.uber.org/zap"
+
+	"github.com/cloudhut/kminion/v2/kafka"
+)
+
+const (
+	ResultStatusReceived      = "received"
+	ResultStatusExpired       = "expired"
+	ResultStatusProduceFailed = "produce_failed"
+)
+
+// EndToEndResult is the record that is published to the results topic for each probe message
+type EndToEndResult struct {
+	MinionID  string `json:"minionID"`
+	MessageID string `json:"messageID"`
+	Topic     string `json:"topic"`
+	Partition int    `json:"partition"`
+	// BrokerID is the id of the broker that led the partition when the message was produced, -1 if unknown
+	BrokerID         int32   `json:"brokerID"`
+	ProducedUtcNs    int64   `json:"producedUtcNs"`
+	AckLatency       float64 `json:"ackLatencySeconds"`
+	RoundtripLatency float64 `json:"roundtripLatencySeconds,omitempty"`
+	Status           string  `json:"status"`
+}
+
+// resultsPublisher publishes the results of all probe messages to the results topic. It uses a dedicated client, so
+// that publishing results neither interferes with the probes (e.g. transactions) nor shows up in their metrics.
+type resultsPublisher struct {
+	logger *zap.Logger
+	client *kgo.Client
+	topic  string
+
+	publishFailed prometheus.Counter
+}
+
+func newResultsPublisher(ctx context.Context, cfg EndToEndResultsTopicConfig, kafkaSvc *kafka.Service, logger *zap.Logger, publishFailed prometheus.Counter) (*resultsPublisher, error) {
+	client, err := kafkaSvc.CreateAndTestClient(ctx, logger, []kgo.Opt{kgo.DefaultProduceTopic(cfg.Name)})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create kafka client for the results topic: %w", err)
+	}
+
+	return &resultsPublisher{
+		logger:        logger.Named("results_publisher"),
+		client:        client,
+		topic:         cfg.Name,
+		publishFailed: publishFailed,
+	}, nil
+}
+
+func (p *resultsPublisher) publish(ctx context.Context, result EndToEndResult) {
+	value, err := json.Marshal(result)
+	if err != nil {
+		p.logger.Error("failed to serialize end-to-end result", zap.Error(err))
+		return
+	}
+
+	p.client.TryProduce(ctx, &kgo.Record{Key: []byte(result.MessageID), Value: value}, func(_ *kgo.Record, err error) {
+		if err != nil {
+			p.publishFailed.Inc()
+			p.logger.Debug("failed to publish end-to-end result", zap.String("topic", p.topic), zap.Error(err))
+		}
+	})
+}
+
+func (p *resultsPublisher) close() {
+	p.client.Close()
+}
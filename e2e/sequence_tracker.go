@@ -0,0 +1,287 @@
+package e2e
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// maxTrackedSequenceGap is the maximum gap between two consecutive sequence numbers whose messages we track
+// individually. The messages of larger gaps are considered lost right away, without waiting for them to arrive late.
+const maxTrackedSequenceGap = 10000
+
+// lostCheckFrequency is the number of times per missing retention that missing messages are checked for being lost.
+const lostCheckFrequency = 10
+
+// duplicateDetectionWindow is the number of most recently received message ids per partition that are remembered in
+// order to detect duplicates.
+const duplicateDetectionWindow = 1000
+
+// sequenceTracker assigns monotonically increasing sequence numbers to the messages each producer sends to each
+// partition and detects gaps in the sequence numbers of the messages we consume. Additionally it remembers the ids of the most
+// recently received messages, so that we can detect duplicates.
+//
+// A gap is a message that has been produced successfully, but that we haven't received (yet), even though we have
+// already received a later message from the same producer and partition. Unlike the message tracker, this doesn't rely on the
+// roundtrip SLA and therefore distinguishes messages that are actually missing from messages that arrive late.
+type sequenceTracker struct {
+	mutex      sync.Mutex
+	partitions map[int]map[int]*partitionSequence // partition -> producer -> sequence state
+
+	// missingRetention is the duration after which missing messages are considered lost
+	missingRetention   time.Duration
+	messagesMissing    *prometheus.GaugeVec
+	messagesLost       *prometheus.CounterVec
+	messagesOutOfOrder *prometheus.CounterVec
+	messagesDuplicated *prometheus.CounterVec
+}
+
+type partitionSequence struct {
+	nextSeq     int64               // sequence number of the next message we produce
+	lastSeq     int64               // highest sequence number we received, -1 if we haven't received any message yet
+	missing     map[int64]time.Time // sequence number -> time when we detected that the message is missing
+	notProduced map[int64]struct{}  // sequence numbers of messages that failed to be produced
+	outOfOrder  int                 // number of messages that arrived after a message with a higher sequence number
+	duplicated  int                 // number of messages that have been received more than once
+
+	// receivedIDs contains the ids of the last received messages, receivedRing contains the same ids in the order
+	// they have been received, so that we can forget the oldest id once the window is full.
+	receivedIDs  map[string]struct{}
+	receivedRing []string
+	ringPos      int
+}
+
+func newSequenceTracker(missingRetention time.Duration, messagesMissing *prometheus.GaugeVec, messagesLost *prometheus.CounterVec, messagesOutOfOrder *prometheus.CounterVec, messagesDuplicated *prometheus.CounterVec) *sequenceTracker {
+	return &sequenceTracker{
+		partitions:         make(map[int]map[int]*partitionSequence),
+		missingRetention:   missingRetention,
+		messagesMissing:    messagesMissing,
+		messagesLost:       messagesLost,
+		messagesOutOfOrder: messagesOutOfOrder,
+		messagesDuplicated: messagesDuplicated,
+	}
+}
+
+// getPartition returns the sequence state of the given producer for the given partition. The caller must hold the
+// mutex.
+func (t *sequenceTracker) getPartition(producer int, partition int) *partitionSequence {
+	producers, exists := t.partitions[partition]
+	if !exists {
+		producers = make(map[int]*partitionSequence)
+		t.partitions[partition] = producers
+	}
+
+	p, exists := producers[producer]
+	if !exists {
+		p = &partitionSequence{
+			lastSeq:     -1,
+			missing:     make(map[int64]time.Time),
+			notProduced: make(map[int64]struct{}),
+			receivedIDs: make(map[string]struct{}, duplicateDetectionWindow),
+		}
+		producers[producer] = p
+	}
+	return p
+}
+
+// nextSequence returns the sequence number for the next message that the given producer will send to the given
+// partition.
+func (t *sequenceTracker) nextSequence(producer int, partition int) int64 {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	p := t.getPartition(producer, partition)
+	seq := p.nextSeq
+	p.nextSeq++
+	return seq
+}
+
+// onProduceFailed marks a sequence number as not produced, so that it won't be reported as missing.
+func (t *sequenceTracker) onProduceFailed(producer int, partition int, seq int64) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	p := t.getPartition(producer, partition)
+	if _, isMissing := p.missing[seq]; isMissing {
+		delete(p.missing, seq)
+	} else if seq > p.lastSeq {
+		p.notProduced[seq] = struct{}{}
+	}
+	t.reportMissing(partition)
+}
+
+// onMessageArrived checks the sequence number of a consumed message for gaps and ordering violations. It returns
+// true if the message is a duplicate of a message that has been received before, in which case it is not checked any
+// further.
+func (t *sequenceTracker) onMessageArrived(producer int, partition int, messageID string, seq int64) bool {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	p := t.getPartition(producer, partition)
+	now := time.Now()
+
+	if p.rememberID(messageID) {
+		p.duplicated++
+		if t.messagesDuplicated != nil {
+			t.messagesDuplicated.WithLabelValues(strconv.Itoa(partition)).Inc()
+		}
+		return true
+	}
+
+	switch {
+	case p.lastSeq == -1:
+		// This is the first message we receive, we can't know about any previous messages
+		p.lastSeq = seq
+	case seq > p.lastSeq:
+		if seq-p.lastSeq <= maxTrackedSequenceGap {
+			for missingSeq := p.lastSeq + 1; missingSeq < seq; missingSeq++ {
+				if _, failed := p.notProduced[missingSeq]; failed {
+					delete(p.notProduced, missingSeq)
+					continue
+				}
+				p.missing[missingSeq] = now
+			}
+		} else {
+			// Tracking each message of such a large gap is too expensive, so all of them that have been produced
+			// successfully are lost
+			lost := seq - p.lastSeq - 1
+			for failedSeq := range p.notProduced {
+				if failedSeq > p.lastSeq && failedSeq < seq {
+					delete(p.notProduced, failedSeq)
+					lost--
+				}
+			}
+			t.addLost(partition, lost)
+		}
+		p.lastSeq = seq
+	case seq < p.lastSeq:
+		// The message arrived after a later message, so it's no longer missing, but the partition's order has been
+		// violated. This may happen after an unclean leader election for instance.
+		delete(p.missing, seq)
+		p.outOfOrder++
+		if t.messagesOutOfOrder != nil {
+			t.messagesOutOfOrder.WithLabelValues(strconv.Itoa(partition)).Inc()
+		}
+	}
+
+	t.reportMissing(partition)
+	return false
+}
+
+// start periodically checks for messages that have been missing for too long, so that they are reported as lost even
+// if no further messages arrive on their partition.
+func (t *sequenceTracker) start(ctx context.Context) {
+	ticker := time.NewTicker(t.missingRetention / lostCheckFrequency)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			t.expireMissing(time.Now())
+		}
+	}
+}
+
+// expireMissing removes all messages that have been missing for longer than the missing retention and counts them as
+// lost.
+func (t *sequenceTracker) expireMissing(now time.Time) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	for partition, producers := range t.partitions {
+		lost := int64(0)
+		for _, p := range producers {
+			for missingSeq, detectedAt := range p.missing {
+				if now.Sub(detectedAt) > t.missingRetention {
+					delete(p.missing, missingSeq)
+					lost++
+				}
+			}
+		}
+		if lost > 0 {
+			t.addLost(partition, lost)
+			t.reportMissing(partition)
+		}
+	}
+}
+
+// addLost counts the given number of messages as lost. The caller must hold the mutex.
+func (t *sequenceTracker) addLost(partition int, lost int64) {
+	if lost <= 0 || t.messagesLost == nil {
+		return
+	}
+	t.messagesLost.WithLabelValues(strconv.Itoa(partition)).Add(float64(lost))
+}
+
+// rememberID adds the message id to the window of recently received ids. It returns true if the id is already part
+// of the window.
+func (p *partitionSequence) rememberID(messageID string) bool {
+	if _, exists := p.receivedIDs[messageID]; exists {
+		return true
+	}
+
+	if len(p.receivedRing) < duplicateDetectionWindow {
+		p.receivedRing = append(p.receivedRing, messageID)
+	} else {
+		delete(p.receivedIDs, p.receivedRing[p.ringPos])
+		p.receivedRing[p.ringPos] = messageID
+		p.ringPos = (p.ringPos + 1) % duplicateDetectionWindow
+	}
+	p.receivedIDs[messageID] = struct{}{}
+
+	return false
+}
+
+// reportMissing updates the messages missing gauge of the given partition. The caller must hold the mutex.
+func (t *sequenceTracker) reportMissing(partition int) {
+	if t.messagesMissing == nil {
+		return
+	}
+
+	missing := 0
+	for _, p := range t.partitions[partition] {
+		missing += len(p.missing)
+	}
+	t.messagesMissing.WithLabelValues(strconv.Itoa(partition)).Set(float64(missing))
+}
+
+// missingCount returns the number of messages that are currently missing for the given partition.
+func (t *sequenceTracker) missingCount(partition int) int {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	count := 0
+	for _, p := range t.partitions[partition] {
+		count += len(p.missing)
+	}
+	return count
+}
+
+// outOfOrderCount returns the number of messages that arrived out of order for the given partition.
+func (t *sequenceTracker) outOfOrderCount(partition int) int {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	count := 0
+	for _, p := range t.partitions[partition] {
+		count += p.outOfOrder
+	}
+	return count
+}
+
+// duplicatedCount returns the number of duplicated messages that have been received for the given partition.
+func (t *sequenceTracker) duplicatedCount(partition int) int {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	count := 0
+	for _, p := range t.partitions[partition] {
+		count += p.duplicated
+	}
+	return count
+}
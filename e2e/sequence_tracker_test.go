@@ -0,0 +1,120 @@
+package e2e
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSequenceTracker(t *testing.T) {
+	tt := []struct {
+		TestName           string
+		Produced           int
+		FailedProduces     []int64
+		Received           []int64
+		ExpectedMissing    int
+		ExpectedOutOfOrder int
+		ExpectedDuplicated int
+	}{
+		{
+			TestName:        "All messages received in order",
+			Produced:        5,
+			Received:        []int64{0, 1, 2, 3, 4},
+			ExpectedMissing: 0,
+		},
+		{
+			TestName:        "Gap in received messages",
+			Produced:        5,
+			Received:        []int64{0, 1, 4},
+			ExpectedMissing: 2,
+		},
+		{
+			TestName:           "Gap filled by late message",
+			Produced:           5,
+			Received:           []int64{0, 1, 4, 2},
+			ExpectedMissing:    1,
+			ExpectedOutOfOrder: 1,
+		},
+		{
+			TestName:        "Failed produces are not missing",
+			Produced:        5,
+			FailedProduces:  []int64{2, 3},
+			Received:        []int64{0, 1, 4},
+			ExpectedMissing: 0,
+		},
+		{
+			TestName:        "First received message starts the sequence",
+			Produced:        5,
+			Received:        []int64{3, 4},
+			ExpectedMissing: 0,
+		},
+		{
+			TestName:           "Duplicated message",
+			Produced:           5,
+			Received:           []int64{0, 1, 1, 2, 3, 4},
+			ExpectedMissing:    0,
+			ExpectedDuplicated: 1,
+		},
+	}
+
+	for _, test := range tt {
+		tracker := newSequenceTracker(time.Minute, nil, nil, nil, nil)
+		for i := 0; i < test.Produced; i++ {
+			tracker.nextSequence(0, 0)
+		}
+		for _, seq := range test.FailedProduces {
+			tracker.onProduceFailed(0, 0, seq)
+		}
+		for _, seq := range test.Received {
+			tracker.onMessageArrived(0, 0, strconv.FormatInt(seq, 10), seq)
+		}
+		assert.Equal(t, test.ExpectedMissing, tracker.missingCount(0), test.TestName)
+		assert.Equal(t, test.ExpectedOutOfOrder, tracker.outOfOrderCount(0), test.TestName)
+		assert.Equal(t, test.ExpectedDuplicated, tracker.duplicatedCount(0), test.TestName)
+	}
+}
+
+func TestSequenceTrackerProducers(t *testing.T) {
+	tracker := newSequenceTracker(time.Minute, nil, nil, nil, nil)
+
+	// Both producers send to the same partition, each with its own sequence space
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, int64(i), tracker.nextSequence(0, 0))
+		assert.Equal(t, int64(i), tracker.nextSequence(1, 0))
+	}
+
+	tracker.onMessageArrived(0, 0, "p0-0", 0)
+	tracker.onMessageArrived(1, 0, "p1-0", 0)
+	tracker.onMessageArrived(0, 0, "p0-1", 1)
+	tracker.onMessageArrived(1, 0, "p1-2", 2)
+	tracker.onMessageArrived(0, 0, "p0-2", 2)
+
+	assert.Equal(t, 1, tracker.missingCount(0), "only the message of the second producer is missing")
+	assert.Equal(t, 0, tracker.outOfOrderCount(0))
+}
+
+func TestSequenceTrackerLostMessages(t *testing.T) {
+	messagesLost := prometheus.NewCounterVec(prometheus.CounterOpts{Name: "messages_lost_total"}, []string{"partition_id"})
+	tracker := newSequenceTracker(time.Minute, nil, messagesLost, nil, nil)
+
+	tracker.onMessageArrived(0, 0, "0", 0)
+	tracker.onMessageArrived(0, 0, "3", 3)
+	assert.Equal(t, 2, tracker.missingCount(0))
+
+	// Missing messages are lost once the retention has passed, even though no further message arrives
+	tracker.expireMissing(time.Now())
+	assert.Equal(t, 2, tracker.missingCount(0))
+	tracker.expireMissing(time.Now().Add(2 * time.Minute))
+	assert.Equal(t, 0, tracker.missingCount(0))
+	assert.Equal(t, 2.0, testutil.ToFloat64(messagesLost.WithLabelValues("0")))
+
+	// Messages of gaps that are too large to be tracked are lost right away, unless they failed to be produced
+	tracker.onProduceFailed(0, 0, 5)
+	tracker.onMessageArrived(0, 0, "gap", 3+maxTrackedSequenceGap+2)
+	assert.Equal(t, 0, tracker.missingCount(0))
+	assert.Equal(t, float64(2+maxTrackedSequenceGap), testutil.ToFloat64(messagesLost.WithLabelValues("0")))
+}
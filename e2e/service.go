@@ -3,12 +3,17 @@ package e2e
 import (
 	"context"
 	"fmt"
+	"math/rand"
+	"sort"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/twmb/franz-go/pkg/kgo"
+	"github.com/twmb/franz-go/pkg/kmsg"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 
 	"github.com/cloudhut/kminion/v2/kafka"
@@ -23,31 +28,66 @@ type Service struct {
 	client   *kgo.Client
 
 	// Service
-	minionID       string          // unique identifier, reported in metrics, in case multiple instances run at the same time
-	groupId        string          // our own consumer group
-	groupTracker   *groupTracker   // tracks consumer groups starting with the kminion prefix and deletes them if they are unused for some time
-	messageTracker *messageTracker // tracks successfully produced messages,
-	clientHooks    *clientHooks    // logs broker events, tracks the coordinator (i.e. which broker last responded to our offset commit)
-	partitionCount int             // number of partitions of our test topic, used to send messages to all partitions
+	minionID        string             // unique identifier, reported in metrics, in case multiple instances run at the same time
+	groupId         string             // our own consumer group
+	groupTracker    *groupTracker      // tracks consumer groups starting with the kminion prefix and deletes them if they are unused for some time
+	messageTracker  *messageTracker    // tracks successfully produced messages,
+	sequenceTracker *sequenceTracker   // assigns sequence numbers to produced messages and detects gaps in consumed messages
+	clientHooks     *clientHooks       // logs broker events, tracks the coordinator (i.e. which broker last responded to our offset commit)
+	partitionCount  atomic.Int32       // number of partitions of our test topic, used to send messages to all partitions
+	probePartitions atomic.Value       // []int, partitions that we send probe messages to
+	sloTracker      *sloTracker        // calculates SLO burn rates from the probe results, nil if disabled
+	resultsPub      *resultsPublisher  // publishes the probe results to the results topic, nil if disabled
+	leadership      *leadershipTracker // measures how long producing is disrupted, e.g. by leader changes
+	knownBrokerIDs  map[int32]bool     // brokers that were part of the cluster during the last topic reconciliation
+	startedAt       time.Time          // when Start() has been called, used to determine the warm-up period
+	paused          atomic.Bool        // whether probing has been paused via the admin endpoint
+
+	// Tracing
+	tracer          trace.Tracer                    // creates the spans of the probe messages, no-op if tracing is disabled
+	shutdownTracing func(ctx context.Context) error // flushes and stops the span exporter
 
 	// Metrics
 	messagesProducedInFlight *prometheus.GaugeVec
+	messagesInFlight         *prometheus.GaugeVec
+	clockSkew                *prometheus.GaugeVec
 	messagesProducedTotal    *prometheus.CounterVec
 	messagesProducedFailed   *prometheus.CounterVec
 	messagesReceived         *prometheus.CounterVec
 	offsetCommitsTotal       *prometheus.CounterVec
 	offsetCommitsFailedTotal *prometheus.CounterVec
+	commitErrors             *prometheus.CounterVec
 	lostMessages             *prometheus.CounterVec
-
-	produceLatency      *prometheus.HistogramVec
-	roundtripLatency    *prometheus.HistogramVec
-	offsetCommitLatency *prometheus.HistogramVec
+	headerCorruptions        *prometheus.CounterVec
+	transactionsCommitted    *prometheus.CounterVec
+	transactionsAborted      *prometheus.CounterVec
+	roundtripSlaViolations   *prometheus.CounterVec
+	ackSlaViolations         *prometheus.CounterVec
+	commitSlaViolations      *prometheus.CounterVec
+	roundtripSlaMet          *prometheus.GaugeVec
+	pausedGauge              *prometheus.GaugeVec
+	topicConfigDrift         *prometheus.GaugeVec
+	topicConfigCorrections   *prometheus.CounterVec
+
+	produceLatency           *prometheus.HistogramVec
+	roundtripLatency         *prometheus.HistogramVec
+	offsetCommitLatency      *prometheus.HistogramVec
+	brokerProduceLatency     *prometheus.HistogramVec
+	brokerRoundtripLatency   *prometheus.HistogramVec
+	replicaRoundtripLatency  *prometheus.HistogramVec
+	transactionBeginLatency  *prometheus.HistogramVec
+	transactionCommitLatency *prometheus.HistogramVec
+
+	// Summaries, only set if enabled via latencyMetrics.type
+	produceLatencySummary      *prometheus.SummaryVec
+	roundtripLatencySummary    *prometheus.SummaryVec
+	offsetCommitLatencySummary *prometheus.SummaryVec
 }
 
 // NewService creates a new instance of the e2e moinitoring service (wow)
 func NewService(ctx context.Context, cfg Config, logger *zap.Logger, kafkaSvc *kafka.Service, promRegisterer prometheus.Registerer) (*Service, error) {
 	minionID := uuid.NewString()
-	groupID := fmt.Sprintf("%v-%v", cfg.Consumer.GroupIdPrefix, minionID)
+	groupID, instanceID := cfg.groupMembership(minionID)
 
 	// Producer options
 	kgoOpts := []kgo.Opt{
@@ -55,26 +95,55 @@ func NewService(ctx context.Context, cfg Config, logger *zap.Logger, kafkaSvc *k
 		kgo.RecordRetries(3),
 		// We use the manual partitioner so that the records' partition id will be used as target partitio
 		kgo.RecordPartitioner(kgo.ManualPartitioner()),
+		kgo.ProducerBatchCompression(cfg.Producer.compressionCodec()),
 	}
-	if cfg.Producer.RequiredAcks == "all" {
-		kgoOpts = append(kgoOpts, kgo.RequiredAcks(kgo.AllISRAcks()))
-	} else {
-		kgoOpts = append(kgoOpts, kgo.RequiredAcks(kgo.LeaderAck()))
+	kgoOpts = append(kgoOpts, kgo.RequiredAcks(cfg.Producer.requiredAcks()))
+	if cfg.Producer.RequiredAcks != RequiredAcksAll {
+		// Idempotent writes require acks from all in-sync replicas
 		kgoOpts = append(kgoOpts, kgo.DisableIdempotentWrite())
 	}
 
+	if cfg.Producer.Transactional {
+		kgoOpts = append(kgoOpts,
+			kgo.TransactionalID(fmt.Sprintf("%v-%v", cfg.Producer.TransactionalIDPrefix, minionID)),
+			kgo.FetchIsolationLevel(kgo.ReadCommitted()),
+		)
+	}
+
+	// Prepare hooks
+	hooks := newEndToEndClientHooks(cfg, logger, promRegisterer)
+	kgoOpts = append(kgoOpts, kgo.WithHooks(hooks,
+		kafka.NewThrottleMetricsHooks("", "end_to_end", promRegisterer),
+		kafka.NewMetadataMetricsHooks("", "end_to_end", promRegisterer),
+		kafka.NewSASLMetricsHooks("", "end_to_end", promRegisterer),
+		kafka.NewConnectionMetricsHooks("", "end_to_end", promRegisterer)))
+	if kafkaSvc.RequestMetricsEnabled() {
+		kgoOpts = append(kgoOpts, kgo.WithHooks(kafka.NewRequestMetricsHooks("", "end_to_end", kafkaSvc.RequestMetricsHistogramType(), promRegisterer)))
+	}
+
 	// Consumer configs
 	kgoOpts = append(kgoOpts,
 		kgo.ConsumerGroup(groupID),
 		kgo.ConsumeTopics(cfg.TopicManagement.Name),
-		kgo.Balancers(kgo.CooperativeStickyBalancer()),
+		kgo.Balancers(cfg.Consumer.groupBalancer()),
 		kgo.DisableAutoCommit(),
 		kgo.ConsumeResetOffset(kgo.NewOffset().AtEnd()),
+		kgo.OnPartitionsAssigned(hooks.onPartitionsAssigned),
+		kgo.OnPartitionsRevoked(hooks.onPartitionsRevoked),
+		kgo.OnPartitionsLost(hooks.onPartitionsRevoked),
 	)
 
-	// Prepare hooks
-	hooks := newEndToEndClientHooks(logger)
-	kgoOpts = append(kgoOpts, kgo.WithHooks(hooks))
+	if cfg.Consumer.Rack != "" {
+		kgoOpts = append(kgoOpts, kgo.Rack(cfg.Consumer.Rack))
+	}
+	if cfg.Consumer.StaticMembership {
+		kgoOpts = append(kgoOpts, kgo.InstanceID(instanceID))
+	}
+
+	tracerProvider, shutdownTracing, err := newTracerProvider(ctx, cfg.Tracing, cfg.TopicManagement.Name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to setup tracing: %w", err)
+	}
 
 	// Create kafka service and check if client can successfully connect to Kafka cluster
 	logger.Info("connecting to Kafka seed brokers, trying to fetch cluster metadata",
@@ -94,9 +163,11 @@ func NewService(ctx context.Context, cfg Config, logger *zap.Logger, kafkaSvc *k
 		minionID:    minionID,
 		groupId:     groupID,
 		clientHooks: hooks,
+
+		tracer:          tracerProvider.Tracer(tracerName),
+		shutdownTracing: shutdownTracing,
 	}
 
-	svc.groupTracker = newGroupTracker(cfg, logger, client, groupID)
 	svc.messageTracker = newMessageTracker(svc)
 
 	makeCounterVec := func(name string, labelNames []string, help string) *prometheus.CounterVec {
@@ -117,39 +188,140 @@ func NewService(ctx context.Context, cfg Config, logger *zap.Logger, kafkaSvc *k
 		promRegisterer.MustRegister(gv)
 		return gv
 	}
-	makeHistogramVec := func(name string, maxLatency time.Duration, labelNames []string, help string) *prometheus.HistogramVec {
-		hv := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+	makeHistogramVec := func(name string, buckets []float64, labelNames []string, help string) *prometheus.HistogramVec {
+		hv := prometheus.NewHistogramVec(kafka.WithHistogramType(prometheus.HistogramOpts{
 			Subsystem: "end_to_end",
 			Name:      name,
 			Help:      help,
-			Buckets:   createHistogramBuckets(maxLatency),
-		}, labelNames)
+			Buckets:   buckets,
+		}, cfg.LatencyMetrics.HistogramType), labelNames)
 		promRegisterer.MustRegister(hv)
 		return hv
 	}
+	makeSummaryVec := func(name string, labelNames []string, help string) *prometheus.SummaryVec {
+		sv := prometheus.NewSummaryVec(prometheus.SummaryOpts{
+			Subsystem:  "end_to_end",
+			Name:       name,
+			Help:       help,
+			Objectives: cfg.LatencyMetrics.summaryObjectives(),
+			MaxAge:     cfg.LatencyMetrics.SummaryMaxAge,
+		}, labelNames)
+		promRegisterer.MustRegister(sv)
+		return sv
+	}
 
 	// Low-level info
 	// Users can construct alerts like "can't produce messages" themselves from those
-	svc.messagesProducedInFlight = makeGaugeVec("messages_produced_in_flight", []string{"partition_id"}, "Number of messages that kminion's end-to-end test produced but has not received an answer for yet")
+	svc.messagesInFlight = makeGaugeVec("messages_in_flight", []string{"partition_id"}, "Number of messages that kminion's end-to-end test produced, but that have neither been received nor expired yet")
+	svc.messagesProducedInFlight = makeGaugeVec("messages_produced_in_flight", []string{"partition_id"}, "Number of messages that kminion's end-to-end test produced, but for which the broker has neither acknowledged the produce request nor returned an error yet")
 	svc.messagesProducedTotal = makeCounterVec("messages_produced_total", []string{"partition_id"}, "Number of all messages produced to Kafka. This counter will be incremented when we receive a response (failure/timeout or success) from Kafka")
 	svc.messagesProducedFailed = makeCounterVec("messages_produced_failed_total", []string{"partition_id"}, "Number of messages failed to produce to Kafka because of a timeout or failure")
 	svc.messagesReceived = makeCounterVec("messages_received_total", []string{"partition_id"}, "Number of *matching* messages kminion received. Every roundtrip message has a minionID (randomly generated on startup) and a timestamp. Kminion only considers a message a match if it it arrives within the configured roundtrip SLA (and it matches the minionID)")
 	svc.offsetCommitsTotal = makeCounterVec("offset_commits_total", []string{"coordinator_id"}, "Counts how many times kminions end-to-end test has committed offsets")
-	svc.offsetCommitsFailedTotal = makeCounterVec("offset_commits_failed_total", []string{"coordinator_id", "reason"}, "Number of offset commits that returned an error or timed out")
-	svc.lostMessages = makeCounterVec("messages_lost_total", []string{"partition_id"}, "Number of messages that have been produced successfully but not received within the configured SLA duration")
+	svc.offsetCommitsFailedTotal = makeCounterVec("offset_commits_failed_total", []string{"coordinator_id", "reason"}, "Number of offset commits that returned an error or timed out, by coordinator and reason. The reason is the Kafka error code of the last failed partition, or OFFSET_COMMIT_SLA_EXCEEDED / RESPONSE_ERROR if the whole request failed")
+	svc.commitErrors = makeCounterVec("commit_errors_total", []string{"error_code"}, "Number of errors that occurred when committing offsets, by Kafka error code (per partition) or OFFSET_COMMIT_SLA_EXCEEDED / RESPONSE_ERROR if the whole request failed")
+	svc.headerCorruptions = makeCounterVec("header_corruptions_total", []string{"header_key"}, "Number of received messages whose configured header was missing or had a modified value")
+	svc.lostMessages = makeCounterVec("messages_lost_total", []string{"partition_id"}, "Number of messages that have been produced successfully but have never been received, even though later messages of the same partition have been received")
+
+	messagesMissing := makeGaugeVec("messages_missing", []string{"partition_id"}, "Number of messages that have been produced successfully but not received yet, even though a later message of the same partition has already been received")
+	messagesOutOfOrder := makeCounterVec("messages_out_of_order_total", []string{"partition_id"}, "Number of messages that have been received after a message that was produced later to the same partition")
+	messagesDuplicated := makeCounterVec("messages_duplicated_total", []string{"partition_id"}, "Number of messages that have been received more than once")
+	svc.sequenceTracker = newSequenceTracker(10*cfg.Consumer.RoundtripSla, messagesMissing, svc.lostMessages, messagesOutOfOrder, messagesDuplicated)
+
+	// Clock skew, only reported if the topic uses LogAppendTime
+	svc.clockSkew = makeGaugeVec("clock_skew_seconds", []string{"broker_id"}, "Difference between the broker's append timestamp (LogAppendTime) and kminion's creation timestamp of the last received message, by the broker leading the partition")
+
+	// Pausing
+	svc.pausedGauge = makeGaugeVec("paused", []string{}, "Whether sending probe messages has been paused via the admin endpoint (1) or not (0)")
+	svc.pausedGauge.WithLabelValues().Set(0)
+
+	// SLAs
+	// Simple signals that can be used for alerting without having to work with the histograms
+	svc.roundtripSlaMet = makeGaugeVec("roundtrip_sla_met", []string{}, "Whether the last message has been received within the configured roundtrip SLA (1) or not (0)")
+	svc.roundtripSlaViolations = makeCounterVec("roundtrip_sla_violations_total", []string{}, "Number of messages that have not been received within the configured roundtrip SLA")
+	svc.ackSlaViolations = makeCounterVec("ack_sla_violations_total", []string{}, "Number of produced messages that have not been acknowledged within the configured ack SLA")
+	svc.commitSlaViolations = makeCounterVec("commit_sla_violations_total", []string{}, "Number of offset commits that have not been responded to within the configured commit SLA")
 
 	// Latency Histograms
 	// More detailed info about how long stuff took
 	// Since histograms also have an 'infinite' bucket, they can be used to detect small hickups "lost" messages
-	svc.produceLatency = makeHistogramVec("produce_latency_seconds", cfg.Producer.AckSla, []string{"partition_id"}, "Time until we received an ack for a produced message")
-	svc.roundtripLatency = makeHistogramVec("roundtrip_latency_seconds", cfg.Consumer.RoundtripSla, []string{"partition_id"}, "Time it took between sending (producing) and receiving (consuming) a message")
-	svc.offsetCommitLatency = makeHistogramVec("offset_commit_latency_seconds", cfg.Consumer.CommitSla, []string{"coordinator_id"}, "Time kafka took to respond to kminion's offset commit")
+	if cfg.LatencyMetrics.histogramsEnabled() {
+		svc.produceLatency = makeHistogramVec("produce_latency_seconds", cfg.Producer.ackSlaBuckets(), svc.partitionLabelNames(), "Time until we received an ack for a produced message")
+		svc.roundtripLatency = makeHistogramVec("roundtrip_latency_seconds", cfg.Consumer.roundtripSlaBuckets(), svc.partitionLabelNames(), "Time it took between sending (producing) and receiving (consuming) a message")
+		svc.offsetCommitLatency = makeHistogramVec("offset_commit_latency_seconds", cfg.Consumer.commitSlaBuckets(), []string{"coordinator_id"}, "Time kafka took to respond to kminion's offset commit")
+	}
+	svc.brokerProduceLatency = makeHistogramVec("broker_produce_latency_seconds", cfg.Producer.ackSlaBuckets(), []string{"broker_id"}, "Time until we received an ack for a produced message, by the broker leading the partition")
+	svc.brokerRoundtripLatency = makeHistogramVec("broker_roundtrip_latency_seconds", cfg.Consumer.roundtripSlaBuckets(), []string{"broker_id"}, "Time it took between sending (producing) and receiving (consuming) a message, by the broker leading the partition")
+	if cfg.Consumer.Rack != "" {
+		svc.replicaRoundtripLatency = makeHistogramVec("replica_roundtrip_latency_seconds", cfg.Consumer.roundtripSlaBuckets(), []string{"replica_type"}, "Time it took between sending (producing) and receiving (consuming) a message, by the type of the replica (leader or follower) the message has been fetched from")
+	}
+
+	// Latency Summaries
+	// Accurate quantiles, which can't be aggregated across instances though
+	if cfg.LatencyMetrics.summariesEnabled() {
+		svc.produceLatencySummary = makeSummaryVec("produce_latency_summary_seconds", svc.partitionLabelNames(), "Time until we received an ack for a produced message")
+		svc.roundtripLatencySummary = makeSummaryVec("roundtrip_latency_summary_seconds", svc.partitionLabelNames(), "Time it took between sending (producing) and receiving (consuming) a message")
+		svc.offsetCommitLatencySummary = makeSummaryVec("offset_commit_latency_summary_seconds", []string{"coordinator_id"}, "Time kafka took to respond to kminion's offset commit")
+	}
+
+	// Leadership changes
+	produceDisruption := prometheus.NewHistogram(kafka.WithHistogramType(prometheus.HistogramOpts{
+		Subsystem: "end_to_end",
+		Name:      "produce_disruption_duration_seconds",
+		Help:      "Time from the first failed or slow (exceeding the ack SLA) produce to a partition until the next successful one, e.g. during leader changes",
+		Buckets:   prometheus.ExponentialBuckets(0.1, 2, 12),
+	}, cfg.LatencyMetrics.HistogramType))
+	promRegisterer.MustRegister(produceDisruption)
+	svc.leadership = newLeadershipTracker(produceDisruption)
+
+	// Topic configs
+	svc.topicConfigDrift = makeGaugeVec("topic_config_drift_detected", []string{}, "Whether the configs of the end-to-end topic differ from the desired configs and could not be corrected (1) or not (0). Only reported if topicManagement.reconcileConfigs is enabled")
+	svc.topicConfigCorrections = makeCounterVec("topic_config_corrections_total", []string{}, "Number of end-to-end topic configs that have been altered because they drifted from the desired configs")
+
+	// Consumer group cleanup
+	staleGroupsDeleted := makeCounterVec("stale_consumer_groups_deleted_total", []string{}, "Number of stale kminion end-to-end consumer groups that have been deleted")
+	svc.groupTracker = newGroupTracker(cfg, logger, client, groupID, staleGroupsDeleted.WithLabelValues())
+
+	// Compression
+	compressionRatio := prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Subsystem:   "end_to_end",
+		Name:        "compression_ratio",
+		Help:        "Ratio of uncompressed to compressed bytes of the last batch produced by kminion's end-to-end test",
+		ConstLabels: prometheus.Labels{"codec": cfg.Producer.Compression},
+	}, hooks.lastCompressionRatio)
+	promRegisterer.MustRegister(compressionRatio)
+
+	// SLOs
+	if cfg.SLO.Enabled {
+		svc.sloTracker = newSLOTracker(cfg.SLO)
+		promRegisterer.MustRegister(svc.sloTracker.collectors()...)
+	}
+
+	// Results topic
+	if cfg.ResultsTopic.Enabled {
+		publishFailed := makeCounterVec("results_publish_failed_total", []string{}, "Number of probe results that could not be published to the results topic")
+		svc.resultsPub, err = newResultsPublisher(ctx, cfg.ResultsTopic, kafkaSvc, logger, publishFailed.WithLabelValues())
+		if err != nil {
+			client.Close()
+			return nil, err
+		}
+	}
+
+	// Transactions
+	if cfg.Producer.Transactional {
+		svc.transactionsCommitted = makeCounterVec("transactions_committed_total", []string{}, "Number of transactions that have been committed successfully")
+		svc.transactionsAborted = makeCounterVec("transactions_aborted_total", []string{}, "Number of transactions that have been aborted because producing or committing failed")
+		svc.transactionBeginLatency = makeHistogramVec("transaction_begin_latency_seconds", cfg.Producer.ackSlaBuckets(), []string{}, "Time it took to begin a transaction")
+		svc.transactionCommitLatency = makeHistogramVec("transaction_commit_latency_seconds", cfg.Producer.ackSlaBuckets(), []string{}, "Time it took to commit a transaction")
+	}
 
 	return svc, nil
 }
 
 // Start starts the service (wow)
 func (s *Service) Start(ctx context.Context) error {
+	s.startedAt = time.Now()
+
 	// Ensure topic exists and is configured correctly
 	if err := s.validateManagementTopic(ctx); err != nil {
 		return fmt.Errorf("could not validate end-to-end topic: %w", err)
@@ -160,11 +332,22 @@ func (s *Service) Start(ctx context.Context) error {
 	if err != nil {
 		return fmt.Errorf("could not get topic metadata after validation: %w", err)
 	}
-	partitions := len(topicMetadata.Topics[0].Partitions)
-	s.partitionCount = partitions
+	s.updateTopicState(topicMetadata)
 
 	// finally start everything else (producing, consuming, continuous validation, consumer group tracking)
 	go s.startReconciliation(ctx)
+	go func() {
+		<-ctx.Done()
+		// Use a fresh context so that the remaining spans can still be exported
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := s.shutdownTracing(shutdownCtx); err != nil {
+			s.logger.Warn("failed to shutdown tracing", zap.Error(err))
+		}
+		if s.resultsPub != nil {
+			s.resultsPub.close()
+		}
+	}()
 
 	// Start consumer and wait until we've received a response for the first poll
 	// which would indicate that the consumer is ready. Only if the consumer is
@@ -204,7 +387,10 @@ func (s *Service) Start(ctx context.Context) error {
 		}
 	}
 	go s.startOffsetCommits(ctx)
-	go s.startProducer(ctx)
+	go s.sequenceTracker.start(ctx)
+	for producer := 0; producer < s.config.Producer.Concurrency; producer++ {
+		go s.startProducer(ctx, producer)
+	}
 
 	// keep track of groups, delete old unused groups
 	if s.config.Consumer.DeleteStaleConsumerGroups {
@@ -215,15 +401,42 @@ func (s *Service) Start(ctx context.Context) error {
 }
 
 func (s *Service) sendInitMessage(ctx context.Context, client *kgo.Client, topicName string) {
+	if s.config.Producer.Transactional {
+		if err := client.BeginTransaction(); err != nil {
+			s.logger.Warn("failed to begin transaction for init messages", zap.Error(err))
+			return
+		}
+		defer func() {
+			commit := kgo.TryCommit
+			if err := client.Flush(ctx); err != nil {
+				s.logger.Warn("failed to flush init messages, aborting transaction", zap.Error(err))
+				commit = kgo.TryAbort
+				if err := client.AbortBufferedRecords(ctx); err != nil {
+					s.logger.Warn("failed to abort buffered init messages", zap.Error(err))
+				}
+			}
+			if err := client.EndTransaction(ctx, commit); err != nil {
+				s.logger.Warn("failed to end transaction for init messages", zap.Error(err))
+			}
+		}()
+	}
+
 	// Try to produce one record into each partition. This is important because
 	// one or more partitions may be offline, while others may still be writable.
-	for i := 0; i < s.partitionCount; i++ {
+	partitionCount := int(s.partitionCount.Load())
+	for i := 0; i < partitionCount; i++ {
 		client.TryProduce(ctx, &kgo.Record{
 			Key:       []byte("init-message"),
 			Value:     nil,
 			Topic:     topicName,
 			Partition: int32(i),
-		}, nil)
+		}, func(r *kgo.Record, err error) {
+			if err != nil {
+				s.logger.Debug("failed to produce init message",
+					zap.Int32("partition", r.Partition),
+					zap.Error(err))
+			}
+		})
 	}
 }
 
@@ -233,31 +446,179 @@ func (s *Service) startReconciliation(ctx context.Context) {
 	}
 
 	validateTopicTicker := time.NewTicker(s.config.TopicManagement.ReconciliationInterval)
+	brokerCheckTicker := time.NewTicker(s.config.TopicManagement.BrokerCheckInterval)
 	for {
 		select {
 		case <-ctx.Done():
 			return
 		case <-validateTopicTicker.C:
-			err := s.validateManagementTopic(ctx)
+			s.reconcileManagementTopic(ctx)
+		case <-brokerCheckTicker.C:
+			meta, err := s.getTopicMetadata(ctx)
 			if err != nil {
-				s.logger.Error("failed to validate end-to-end topic", zap.Error(err))
+				s.logger.Warn("failed to check for broker changes", zap.Error(err))
+				continue
+			}
+			if !s.haveBrokersChanged(meta) {
+				// Partition leaders may have moved, which matters if we only target one partition per broker
+				s.probePartitions.Store(selectProbePartitions(meta.Topics[0].Partitions, s.config.Producer))
+				continue
 			}
+			s.logger.Info("the set of brokers has changed, reconciling end-to-end topic",
+				zap.Int("previous_broker_count", len(s.knownBrokerIDs)),
+				zap.Int("broker_count", len(meta.Brokers)))
+			s.reconcileManagementTopic(ctx)
 		}
 	}
 }
 
-func (s *Service) startProducer(ctx context.Context) {
-	produceTicker := time.NewTicker(s.config.ProbeInterval)
+// reconcileManagementTopic validates the end-to-end topic (and fixes it if necessary) and afterwards refreshes the
+// partition count, so that we start producing to newly created partitions.
+func (s *Service) reconcileManagementTopic(ctx context.Context) {
+	err := s.validateManagementTopic(ctx)
+	if err != nil {
+		s.logger.Error("failed to validate end-to-end topic", zap.Error(err))
+		return
+	}
+
+	meta, err := s.getTopicMetadata(ctx)
+	if err != nil {
+		s.logger.Error("failed to get topic metadata after validation", zap.Error(err))
+		return
+	}
+	s.updateTopicState(meta)
+}
+
+// updateTopicState stores the partition count and the current set of brokers from the given metadata response.
+func (s *Service) updateTopicState(meta *kmsg.MetadataResponse) {
+	s.partitionCount.Store(int32(len(meta.Topics[0].Partitions)))
+	s.probePartitions.Store(selectProbePartitions(meta.Topics[0].Partitions, s.config.Producer))
+
+	brokerIDs := make(map[int32]bool, len(meta.Brokers))
+	for _, broker := range meta.Brokers {
+		brokerIDs[broker.NodeID] = true
+	}
+	s.knownBrokerIDs = brokerIDs
+}
+
+// haveBrokersChanged returns true if brokers have been added or removed since the last reconciliation.
+func (s *Service) haveBrokersChanged(meta *kmsg.MetadataResponse) bool {
+	if len(meta.Brokers) != len(s.knownBrokerIDs) {
+		return true
+	}
+	for _, broker := range meta.Brokers {
+		if !s.knownBrokerIDs[broker.NodeID] {
+			return true
+		}
+	}
+	return false
+}
+
+// selectProbePartitions returns the ids of the partitions that shall receive probe messages, depending on the
+// producer config.
+func selectProbePartitions(partitions []kmsg.MetadataResponseTopicPartition, cfg EndToEndProducerConfig) []int {
+	probePartitions := make([]int, 0, len(partitions))
+
+	switch {
+	case len(cfg.Partitions) > 0:
+		existing := make(map[int32]bool, len(partitions))
+		for _, partition := range partitions {
+			existing[partition.Partition] = true
+		}
+		for _, partitionID := range cfg.Partitions {
+			if existing[partitionID] {
+				probePartitions = append(probePartitions, int(partitionID))
+			}
+		}
+	case cfg.TargetEachBrokerOnce:
+		// Pick the partition with the lowest id for each leader, so that the selection is stable
+		partitionByLeader := make(map[int32]int32)
+		for _, partition := range partitions {
+			if partition.Leader < 0 {
+				continue
+			}
+			if current, exists := partitionByLeader[partition.Leader]; !exists || partition.Partition < current {
+				partitionByLeader[partition.Leader] = partition.Partition
+			}
+		}
+		for _, partitionID := range partitionByLeader {
+			probePartitions = append(probePartitions, int(partitionID))
+		}
+		sort.Ints(probePartitions)
+	default:
+		for i := range partitions {
+			probePartitions = append(probePartitions, i)
+		}
+	}
+
+	return probePartitions
+}
+
+// getProbePartitions returns the partitions that shall receive probe messages in the next interval.
+func (s *Service) getProbePartitions() []int {
+	partitions, _ := s.probePartitions.Load().([]int)
+	return partitions
+}
+
+// startProducer periodically sends probe messages to the given producer's share of the probed partitions. Each
+// producer picks its targets with its own partitioner.
+func (s *Service) startProducer(ctx context.Context, producer int) {
+	partitioner := newProbePartitioner(s.config.Producer)
+	produceTimer := time.NewTimer(s.nextProbeDelay())
 	for {
 		select {
 		case <-ctx.Done():
+			produceTimer.Stop()
 			return
-		case <-produceTicker.C:
-			s.produceMessagesToAllPartitions(ctx)
+		case <-produceTimer.C:
+			if !s.paused.Load() {
+				s.produceProbeMessages(ctx, producer, partitioner)
+			}
+			produceTimer.Reset(s.nextProbeDelay())
 		}
 	}
 }
 
+// publishResult publishes the result of a probe message to the results topic, if enabled.
+func (s *Service) publishResult(msg *EndToEndMessage, status string, roundtripLatency time.Duration) {
+	if s.resultsPub == nil {
+		return
+	}
+
+	brokerID, _ := s.clientHooks.partitionLeader(int32(msg.partition))
+	s.resultsPub.publish(context.Background(), EndToEndResult{
+		MinionID:         msg.MinionID,
+		MessageID:        msg.MessageID,
+		Topic:            s.config.TopicManagement.Name,
+		Partition:        msg.partition,
+		BrokerID:         brokerID,
+		ProducedUtcNs:    msg.Timestamp,
+		AckLatency:       msg.produceLatency,
+		RoundtripLatency: roundtripLatency.Seconds(),
+		Status:           status,
+	})
+}
+
+// isWarmingUp returns true if the given time is within the configured warm-up period after the start. SLA
+// violations that happen during the warm-up period are not reported, as they are most likely caused by the consumer
+// group still joining.
+func (s *Service) isWarmingUp(t time.Time) bool {
+	return t.Before(s.startedAt.Add(s.config.WarmupDuration))
+}
+
+// nextProbeDelay returns the duration to wait until the next round of probe messages shall be sent. It's derived
+// from the configured rate limit (or probe interval) plus a random jitter.
+func (s *Service) nextProbeDelay() time.Duration {
+	interval := s.config.ProbeInterval
+	if s.config.Producer.RateLimit > 0 {
+		interval = time.Duration(float64(time.Second) / s.config.Producer.RateLimit)
+	}
+	if s.config.Producer.Jitter > 0 {
+		interval += time.Duration(rand.Int63n(int64(s.config.Producer.Jitter)))
+	}
+	return interval
+}
+
 func (s *Service) startOffsetCommits(ctx context.Context) {
 	commitTicker := time.NewTicker(5 * time.Second)
 	for {
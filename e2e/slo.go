@@ -0,0 +1,132 @@
+package e2e
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// sloBucketResolution is the time span covered by each bucket of an sloWindowCounter
+const sloBucketResolution = 10 * time.Second
+
+// sloTracker records the outcome of probe messages and calculates the burn rates of the availability and latency
+// SLOs over multiple windows. A burn rate of 1 means that the error budget is consumed exactly at the rate that is
+// allowed by the objective, higher values mean that the error budget will be exhausted early.
+type sloTracker struct {
+	cfg EndToEndSLOConfig
+
+	availability *sloWindowCounter
+	latency      *sloWindowCounter
+}
+
+func newSLOTracker(cfg EndToEndSLOConfig) *sloTracker {
+	maxWindow := time.Duration(0)
+	for _, window := range cfg.Windows {
+		if window > maxWindow {
+			maxWindow = window
+		}
+	}
+
+	return &sloTracker{
+		cfg:          cfg,
+		availability: newSLOWindowCounter(maxWindow),
+		latency:      newSLOWindowCounter(maxWindow),
+	}
+}
+
+// onMessageReceived records a message that made the roundtrip within the roundtrip SLA.
+func (t *sloTracker) onMessageReceived(latency time.Duration) {
+	now := time.Now()
+	t.availability.add(now, false)
+	t.latency.add(now, latency > t.cfg.LatencyThreshold)
+}
+
+// onMessageFailed records a message that either failed to be produced or didn't arrive within the roundtrip SLA.
+func (t *sloTracker) onMessageFailed() {
+	t.availability.add(time.Now(), true)
+}
+
+// collectors returns a gauge for each SLO and window, which calculates the burn rate when being scraped.
+func (t *sloTracker) collectors() []prometheus.Collector {
+	collectors := make([]prometheus.Collector, 0, 2*len(t.cfg.Windows))
+	for _, window := range t.cfg.Windows {
+		window := window
+		collectors = append(collectors,
+			prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+				Subsystem:   "end_to_end",
+				Name:        "slo_availability_burn_rate",
+				Help:        "Rate at which the error budget of the availability SLO is consumed within the window",
+				ConstLabels: prometheus.Labels{"window": window.String()},
+			}, func() float64 {
+				return t.availability.errorRatio(time.Now(), window) / (1 - t.cfg.AvailabilityObjective)
+			}),
+			prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+				Subsystem:   "end_to_end",
+				Name:        "slo_latency_burn_rate",
+				Help:        "Rate at which the error budget of the latency SLO is consumed within the window",
+				ConstLabels: prometheus.Labels{"window": window.String()},
+			}, func() float64 {
+				return t.latency.errorRatio(time.Now(), window) / (1 - t.cfg.LatencyObjective)
+			}),
+		)
+	}
+	return collectors
+}
+
+// sloWindowCounter counts events and failed events in fixed size time buckets, so that the error ratio can be
+// calculated for any window up to the configured maximum.
+type sloWindowCounter struct {
+	mutex   sync.Mutex
+	buckets []sloBucket
+}
+
+type sloBucket struct {
+	index  int64 // unix time divided by the bucket resolution, identifies the time span covered by this bucket
+	total  int64
+	failed int64
+}
+
+func newSLOWindowCounter(maxWindow time.Duration) *sloWindowCounter {
+	bucketCount := int(maxWindow/sloBucketResolution) + 1
+	return &sloWindowCounter{buckets: make([]sloBucket, bucketCount)}
+}
+
+func (c *sloWindowCounter) add(now time.Time, failed bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	index := now.UnixNano() / int64(sloBucketResolution)
+	bucket := &c.buckets[index%int64(len(c.buckets))]
+	if bucket.index != index {
+		// The bucket contains events from an older time span, which are outside all windows by now
+		*bucket = sloBucket{index: index}
+	}
+	bucket.total++
+	if failed {
+		bucket.failed++
+	}
+}
+
+// errorRatio returns the fraction of failed events within the window, or 0 if there were no events at all.
+func (c *sloWindowCounter) errorRatio(now time.Time, window time.Duration) float64 {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	currentIndex := now.UnixNano() / int64(sloBucketResolution)
+	oldestIndex := currentIndex - int64(window/sloBucketResolution)
+
+	var total, failed int64
+	for _, bucket := range c.buckets {
+		if bucket.index <= oldestIndex || bucket.index > currentIndex {
+			continue
+		}
+		total += bucket.total
+		failed += bucket.failed
+	}
+
+	if total == 0 {
+		return 0
+	}
+	return float64(failed) / float64(total)
+}
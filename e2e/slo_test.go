@@ -0,0 +1,26 @@
+package e2e
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSLOWindowCounter(t *testing.T) {
+	counter := newSLOWindowCounter(time.Hour)
+	now := time.Now()
+
+	// Events older than the window must not be considered
+	for i := 0; i < 10; i++ {
+		counter.add(now.Add(-30*time.Minute), true)
+	}
+	for i := 0; i < 9; i++ {
+		counter.add(now, false)
+	}
+	counter.add(now, true)
+
+	assert.InDelta(t, 0.1, counter.errorRatio(now, 5*time.Minute), 0.0001)
+	assert.InDelta(t, 0.55, counter.errorRatio(now, time.Hour), 0.0001)
+	assert.Equal(t, 0.0, counter.errorRatio(now.Add(2*time.Hour), time.Hour))
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"strconv"
 	"time"
 
 	"github.com/twmb/franz-go/pkg/kerr"
@@ -68,6 +69,12 @@ func (s *Service) validateManagementTopic(ctx context.Context) error {
 		return fmt.Errorf("failed to create partitions: %w", err)
 	}
 
+	if s.config.TopicManagement.Enabled && s.config.TopicManagement.ReconcileConfigs {
+		if err = s.reconcileTopicConfigs(ctx); err != nil {
+			return fmt.Errorf("failed to reconcile topic configs: %w", err)
+		}
+	}
+
 	return nil
 }
 
@@ -130,6 +137,9 @@ func (s *Service) calculatePartitionReassignments(meta *kmsg.MetadataResponse) (
 	// We want to ensure that each brokerID leads at least one partition permanently. Hence let's iterate over brokers.
 	preferredLeaderPartitionsBrokerID := make(map[int32][]kmsg.MetadataResponseTopicPartition)
 	for _, broker := range brokerByID {
+		// Initialize an entry for every broker, so that brokers which don't lead any partition yet (e.g. because they
+		// have just been added to the cluster) will get a partition assigned further down.
+		preferredLeaderPartitionsBrokerID[broker.NodeID] = make([]kmsg.MetadataResponseTopicPartition, 0)
 		for _, partition := range topicMeta.Partitions {
 			// PreferredLeader = BrokerID of the brokerID that is the desired leader. Regardless who the current leader is
 			preferredLeader := partition.Replicas[0]
@@ -181,6 +191,7 @@ func (s *Service) calculatePartitionReassignments(meta *kmsg.MetadataResponse) (
 			partitionReassignments = append(partitionReassignments, req)
 
 			reassignablePartitions = reassignablePartitions[1:]
+			continue
 		}
 
 		// Create a new partition for this broker
@@ -339,15 +350,21 @@ func (s *Service) getTopicsConfigs(ctx context.Context, configNames []string) (*
 }
 
 func createTopicConfig(cfgTopic EndToEndTopicConfig) []kmsg.CreateTopicsRequestTopicConfig {
+	desiredConfigs := desiredTopicConfigs(cfgTopic)
 
-	topicConfig := func(name string, value interface{}) kmsg.CreateTopicsRequestTopicConfig {
+	topicConfigs := make([]kmsg.CreateTopicsRequestTopicConfig, 0, len(desiredConfigs))
+	for _, name := range sortedKeys(desiredConfigs) {
 		prop := kmsg.NewCreateTopicsRequestTopicConfig()
 		prop.Name = name
-		valStr := fmt.Sprintf("%v", value)
-		prop.Value = &valStr
-		return prop
+		prop.Value = kmsg.StringPtr(desiredConfigs[name])
+		topicConfigs = append(topicConfigs, prop)
 	}
+	return topicConfigs
+}
 
+// desiredTopicConfigs returns the topic configs the end-to-end topic should have, which are kminion's defaults
+// merged with the user provided configs.
+func desiredTopicConfigs(cfgTopic EndToEndTopicConfig) map[string]string {
 	minISR := 1
 	if cfgTopic.ReplicationFactor >= 3 {
 		// Only with 3+ replicas does it make sense to require acks from 2 brokers
@@ -355,14 +372,86 @@ func createTopicConfig(cfgTopic EndToEndTopicConfig) []kmsg.CreateTopicsRequestT
 		//       we probably don't even need this configured on the topic directly...
 		minISR = 2
 	}
+	if cfgTopic.MinInsyncReplicas > 0 {
+		minISR = cfgTopic.MinInsyncReplicas
+	}
 
 	// Even though kminion's end-to-end feature actually does not require any
 	// real persistence beyond a few minutes; it might be good too keep messages
 	// around a bit for debugging.
-	return []kmsg.CreateTopicsRequestTopicConfig{
-		topicConfig("cleanup.policy", "delete"),
-		topicConfig("segment.ms", (time.Hour * 12).Milliseconds()),   // new segment every 12h
-		topicConfig("retention.ms", (time.Hour * 24).Milliseconds()), // discard segments older than 24h
-		topicConfig("min.insync.replicas", minISR),
+	configs := map[string]string{
+		"cleanup.policy":      "delete",
+		"segment.ms":          strconv.FormatInt((time.Hour * 12).Milliseconds(), 10), // new segment every 12h
+		"retention.ms":        strconv.FormatInt((time.Hour * 24).Milliseconds(), 10), // discard segments older than 24h
+		"min.insync.replicas": strconv.Itoa(minISR),
+	}
+	for _, entry := range cfgTopic.Configs {
+		configs[entry.Name] = entry.Value
+	}
+	return configs
+}
+
+// reconcileTopicConfigs compares the configs of the existing end-to-end topic with the desired configs and alters
+// all configs that have drifted.
+func (s *Service) reconcileTopicConfigs(ctx context.Context) error {
+	desiredConfigs := desiredTopicConfigs(s.config.TopicManagement)
+	configNames := sortedKeys(desiredConfigs)
+
+	res, err := s.getTopicsConfigs(ctx, configNames)
+	if err != nil {
+		return fmt.Errorf("failed to describe topic configs: %w", err)
+	}
+	if len(res.Resources) != 1 {
+		return fmt.Errorf("expected one resource in describe configs response, but got %v", len(res.Resources))
+	}
+	if err := kerr.ErrorForCode(res.Resources[0].ErrorCode); err != nil {
+		return fmt.Errorf("failed to describe topic configs: %w", err)
+	}
+
+	actualConfigs := make(map[string]string)
+	for _, entry := range res.Resources[0].Configs {
+		actualConfigs[entry.Name] = pointerStrToStr(entry.Value)
+	}
+
+	resource := kmsg.NewIncrementalAlterConfigsRequestResource()
+	resource.ResourceType = kmsg.ConfigResourceTypeTopic
+	resource.ResourceName = s.config.TopicManagement.Name
+	for _, name := range configNames {
+		if actualConfigs[name] == desiredConfigs[name] {
+			continue
+		}
+		s.logger.Info("e2e topic config has drifted, altering it",
+			zap.String("topic_name", s.config.TopicManagement.Name),
+			zap.String("config_name", name),
+			zap.String("actual_value", actualConfigs[name]),
+			zap.String("desired_value", desiredConfigs[name]))
+
+		config := kmsg.NewIncrementalAlterConfigsRequestResourceConfig()
+		config.Name = name
+		config.Op = kmsg.IncrementalAlterConfigOpSet
+		config.Value = kmsg.StringPtr(desiredConfigs[name])
+		resource.Configs = append(resource.Configs, config)
+	}
+
+	if len(resource.Configs) == 0 {
+		s.topicConfigDrift.WithLabelValues().Set(0)
+		return nil
 	}
+	s.topicConfigDrift.WithLabelValues().Set(1)
+
+	req := kmsg.NewIncrementalAlterConfigsRequest()
+	req.Resources = []kmsg.IncrementalAlterConfigsRequestResource{resource}
+	alterRes, err := req.RequestWith(ctx, s.client)
+	if err != nil {
+		return fmt.Errorf("failed to alter topic configs: %w", err)
+	}
+	for _, r := range alterRes.Resources {
+		if err := kerr.ErrorForCode(r.ErrorCode); err != nil {
+			return fmt.Errorf("failed to alter topic configs: %w", err)
+		}
+	}
+
+	s.topicConfigCorrections.WithLabelValues().Add(float64(len(resource.Configs)))
+	s.topicConfigDrift.WithLabelValues().Set(0)
+	return nil
 }
@@ -2,11 +2,16 @@ package e2e
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"math"
+	"sort"
+	"strconv"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/twmb/franz-go/pkg/kerr"
+	"github.com/twmb/franz-go/pkg/kgo"
 	"github.com/twmb/franz-go/pkg/kmsg"
 	"go.uber.org/zap"
 )
@@ -25,6 +30,58 @@ func createHistogramBuckets(maxLatency time.Duration) []float64 {
 	return bucket
 }
 
+// validateHistogramBuckets checks that user provided bucket boundaries are positive and sorted in increasing order.
+// An empty list is valid, in which case the buckets are derived from the SLA.
+func validateHistogramBuckets(buckets []float64) error {
+	for i, bucket := range buckets {
+		if bucket <= 0 {
+			return fmt.Errorf("bucket boundary '%v' must be greater than zero", bucket)
+		}
+		if i > 0 && bucket <= buckets[i-1] {
+			return fmt.Errorf("bucket boundaries must be sorted in increasing order, but '%v' follows '%v'", bucket, buckets[i-1])
+		}
+	}
+	return nil
+}
+
+// partitionLabelNames returns the label names for the latency histograms, which depend on whether partition
+// granularity is enabled.
+func (s *Service) partitionLabelNames() []string {
+	if !s.config.PartitionGranularity {
+		return []string{}
+	}
+	return []string{"partition_id"}
+}
+
+// partitionLabelValues returns the label values matching partitionLabelNames for the given partition.
+func (s *Service) partitionLabelValues(partition int) []string {
+	if !s.config.PartitionGranularity {
+		return []string{}
+	}
+	return []string{strconv.Itoa(partition)}
+}
+
+// observeLatency records the latency in the given histogram and summary. Either of them may be nil if the
+// respective metric type has been disabled. The exemplar is only attached to the histogram, as summaries don't
+// support exemplars.
+func observeLatency(hv *prometheus.HistogramVec, sv *prometheus.SummaryVec, latency time.Duration, exemplar prometheus.Labels, labelValues ...string) {
+	if hv != nil {
+		observeWithExemplar(hv.WithLabelValues(labelValues...), latency, exemplar)
+	}
+	if sv != nil {
+		sv.WithLabelValues(labelValues...).Observe(latency.Seconds())
+	}
+}
+
+// observeWithExemplar observes the latency and attaches the exemplar, if there is one and the observer supports it.
+func observeWithExemplar(observer prometheus.Observer, latency time.Duration, exemplar prometheus.Labels) {
+	if exemplarObserver, ok := observer.(prometheus.ExemplarObserver); ok && len(exemplar) > 0 {
+		exemplarObserver.ObserveWithExemplar(latency.Seconds(), exemplar)
+		return
+	}
+	observer.Observe(latency.Seconds())
+}
+
 func containsStr(ar []string, x string) (bool, int) {
 	for i, item := range ar {
 		if item == x {
@@ -34,15 +91,18 @@ func containsStr(ar []string, x string) (bool, int) {
 	return false, -1
 }
 
-// logCommitErrors logs all errors in commit response and returns a well formatted error code if there was one
+// logCommitErrors logs all errors in commit response, counts them by error code and returns a well formatted error
+// code if there was one
 func (s *Service) logCommitErrors(r *kmsg.OffsetCommitResponse, err error) string {
 	if err != nil {
 		if err == context.DeadlineExceeded {
 			s.logger.Warn("offset commit failed because SLA has been exceeded")
+			s.commitErrors.WithLabelValues("OFFSET_COMMIT_SLA_EXCEEDED").Inc()
 			return "OFFSET_COMMIT_SLA_EXCEEDED"
 		}
 
 		s.logger.Warn("offset commit failed", zap.Error(err))
+		s.commitErrors.WithLabelValues("RESPONSE_ERROR").Inc()
 		return "RESPONSE_ERROR"
 	}
 
@@ -59,6 +119,7 @@ func (s *Service) logCommitErrors(r *kmsg.OffsetCommitResponse, err error) strin
 				zap.Int32("partition_id", p.Partition),
 				zap.Error(typedErr),
 			)
+			s.commitErrors.WithLabelValues(typedErr.Message).Inc()
 			lastErrCode = typedErr.Message
 		}
 	}
@@ -66,6 +127,25 @@ func (s *Service) logCommitErrors(r *kmsg.OffsetCommitResponse, err error) strin
 	return lastErrCode
 }
 
+// produceErrorCode returns the Kafka error code of a failed produce, or a descriptive code for client side errors.
+func produceErrorCode(err error) string {
+	var kafkaErr *kerr.Error
+	switch {
+	case errors.As(err, &kafkaErr):
+		return kafkaErr.Message
+	case errors.Is(err, context.DeadlineExceeded):
+		return "PRODUCE_SLA_EXCEEDED"
+	case errors.Is(err, kgo.ErrRecordTimeout):
+		return "RECORD_TIMEOUT"
+	case errors.Is(err, kgo.ErrRecordRetries):
+		return "RECORD_RETRIES_EXCEEDED"
+	case errors.Is(err, kgo.ErrAborting):
+		return "ABORTING"
+	default:
+		return "UNKNOWN"
+	}
+}
+
 // brokerMetadataByBrokerID returns a map of all broker metadata keyed by their BrokerID
 func brokerMetadataByBrokerID(meta []kmsg.MetadataResponseBroker) map[int32]kmsg.MetadataResponseBroker {
 	res := make(map[int32]kmsg.MetadataResponseBroker)
@@ -88,6 +168,16 @@ func brokerMetadataByRackID(meta []kmsg.MetadataResponseBroker) map[string][]kms
 	return res
 }
 
+// sortedKeys returns the keys of the map in increasing order
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
 func pointerStrToStr(str *string) string {
 	if str == nil {
 		return ""
@@ -110,3 +200,12 @@ func isInArray(num int16, arr []int16) bool {
 	}
 	return false
 }
+
+// countPartitions returns the total number of partitions in a map of topic names to partition ids
+func countPartitions(partitionsByTopic map[string][]int32) int {
+	count := 0
+	for _, partitions := range partitionsByTopic {
+		count += len(partitions)
+	}
+	return count
+}
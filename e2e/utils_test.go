@@ -0,0 +1,39 @@
+package e2e
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/twmb/franz-go/pkg/kerr"
+	"github.com/twmb/franz-go/pkg/kmsg"
+	"go.uber.org/zap"
+)
+
+func TestLogCommitErrors(t *testing.T) {
+	svc := &Service{
+		logger:       zap.NewNop(),
+		commitErrors: prometheus.NewCounterVec(prometheus.CounterOpts{Name: "commit_errors_total"}, []string{"error_code"}),
+	}
+
+	res := kmsg.NewPtrOffsetCommitResponse()
+	topic := kmsg.NewOffsetCommitResponseTopic()
+	for _, code := range []int16{0, kerr.CoordinatorNotAvailable.Code, kerr.RebalanceInProgress.Code, kerr.RebalanceInProgress.Code} {
+		partition := kmsg.NewOffsetCommitResponseTopicPartition()
+		partition.ErrorCode = code
+		topic.Partitions = append(topic.Partitions, partition)
+	}
+	res.Topics = append(res.Topics, topic)
+
+	assert.Equal(t, "REBALANCE_IN_PROGRESS", svc.logCommitErrors(res, nil))
+	assert.Equal(t, "OFFSET_COMMIT_SLA_EXCEEDED", svc.logCommitErrors(nil, context.DeadlineExceeded))
+	assert.Equal(t, "RESPONSE_ERROR", svc.logCommitErrors(nil, context.Canceled))
+
+	// Every failed partition is counted, failed requests are counted once
+	assert.Equal(t, 1.0, testutil.ToFloat64(svc.commitErrors.WithLabelValues("COORDINATOR_NOT_AVAILABLE")))
+	assert.Equal(t, 2.0, testutil.ToFloat64(svc.commitErrors.WithLabelValues("REBALANCE_IN_PROGRESS")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(svc.commitErrors.WithLabelValues("OFFSET_COMMIT_SLA_EXCEEDED")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(svc.commitErrors.WithLabelValues("RESPONSE_ERROR")))
+}
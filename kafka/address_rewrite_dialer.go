@@ -0,0 +1,33 @@
+package kafka
+
+import (
+	"context"
+	"net"
+)
+
+// newAddressRewriteDialFunc returns a dial function that connects to the rewritten address if a rewrite applies to
+// the dialed address. Rewrites that match the exact host:port take precedence over rewrites that match the host only.
+// The TLS server name is still derived from the advertised address, because the TLS dial func wraps this one.
+func newAddressRewriteDialFunc(dial dialFunc, rewrites []AddressRewriteConfig) dialFunc {
+	return func(ctx context.Context, network, host string) (net.Conn, error) {
+		return dial(ctx, network, rewriteAddress(rewrites, host))
+	}
+}
+
+// rewriteAddress returns the address to connect to for the given advertised address.
+func rewriteAddress(rewrites []AddressRewriteConfig, address string) string {
+	for _, rewrite := range rewrites {
+		if rewrite.From != address {
+			continue
+		}
+		if rewritten, ok := rewrite.rewrite(address); ok {
+			return rewritten
+		}
+	}
+	for _, rewrite := range rewrites {
+		if rewritten, ok := rewrite.rewrite(address); ok {
+			return rewritten
+		}
+	}
+	return address
+}
@@ -0,0 +1,152 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+)
+
+// awsSecretsResolver reads secret references from AWS Secrets Manager and SSM Parameter Store.
+type awsSecretsResolver struct {
+	secretsManager *secretsmanager.Client
+	ssm            *ssm.Client
+}
+
+func newAWSSecretsResolver(ctx context.Context, region string) (*awsSecretsResolver, error) {
+	var opts []func(*awsconfig.LoadOptions) error
+	if region != "" {
+		opts = append(opts, awsconfig.WithRegion(region))
+	}
+	awsCfg, err := awsconfig.LoadDefaultConfig(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load default AWS config: %w", err)
+	}
+
+	return &awsSecretsResolver{
+		secretsManager: secretsmanager.NewFromConfig(awsCfg),
+		ssm:            ssm.NewFromConfig(awsCfg),
+	}, nil
+}
+
+// resolve returns the value of the given secret reference. Secrets Manager references may select a key of a JSON
+// secret with a "#<json key>" suffix. SSM parameters are decrypted if they are of the SecureString type.
+func (r *awsSecretsResolver) resolve(ctx context.Context, ref string) (string, error) {
+	switch {
+	case strings.HasPrefix(ref, awsSecretRefPrefixSecretsManager):
+		secretID := strings.TrimPrefix(ref, awsSecretRefPrefixSecretsManager)
+		jsonKey := ""
+		if i := strings.LastIndex(secretID, "#"); i >= 0 {
+			secretID, jsonKey = secretID[:i], secretID[i+1:]
+		}
+
+		res, err := r.secretsManager.GetSecretValue(ctx, &secretsmanager.GetSecretValueInput{SecretId: aws.String(secretID)})
+		if err != nil {
+			return "", fmt.Errorf("failed to get secret '%v': %w", secretID, err)
+		}
+		value := aws.ToString(res.SecretString)
+		if value == "" && len(res.SecretBinary) > 0 {
+			value = string(res.SecretBinary)
+		}
+		if jsonKey == "" {
+			return value, nil
+		}
+
+		var values map[string]interface{}
+		if err := json.Unmarshal([]byte(value), &values); err != nil {
+			return "", fmt.Errorf("failed to parse secret '%v' as JSON: %w", secretID, err)
+		}
+		keyValue, ok := values[jsonKey].(string)
+		if !ok {
+			return "", fmt.Errorf("secret '%v' does not contain the string key '%v'", secretID, jsonKey)
+		}
+		return keyValue, nil
+	case strings.HasPrefix(ref, awsSecretRefPrefixSSM):
+		name := strings.TrimPrefix(ref, awsSecretRefPrefixSSM)
+		res, err := r.ssm.GetParameter(ctx, &ssm.GetParameterInput{Name: aws.String(name), WithDecryption: aws.Bool(true)})
+		if err != nil {
+			return "", fmt.Errorf("failed to get parameter '%v': %w", name, err)
+		}
+		return aws.ToString(res.Parameter.Value), nil
+	default:
+		return "", fmt.Errorf("unsupported aws secret reference '%v'", ref)
+	}
+}
+
+// resolveAll resolves all given non-empty secret references.
+func (r *awsSecretsResolver) resolveAll(ctx context.Context, refs ...string) ([]string, error) {
+	values := make([]string, len(refs))
+	for i, ref := range refs {
+		if ref == "" {
+			continue
+		}
+		value, err := r.resolve(ctx, ref)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = value
+	}
+	return values, nil
+}
+
+// awsSecretsCredentialsProvider reads the SASL username and password from AWS. The credentials are cached for the
+// configured refresh interval, so that AWS is not called for every connection that is authenticated.
+type awsSecretsCredentialsProvider struct {
+	cfg      SASLAWSSecretsConfig
+	username string
+	resolver *awsSecretsResolver
+
+	mutex          sync.Mutex
+	cachedUsername string
+	cachedPassword string
+	refreshAt      time.Time
+}
+
+func newAWSSecretsCredentialsProvider(cfg SASLAWSSecretsConfig, username string) (*awsSecretsCredentialsProvider, error) {
+	resolver, err := newAWSSecretsResolver(context.Background(), cfg.Region)
+	if err != nil {
+		return nil, err
+	}
+	return &awsSecretsCredentialsProvider{
+		cfg:      cfg,
+		username: username,
+		resolver: resolver,
+	}, nil
+}
+
+// Credentials returns the cached username and password or reads them from AWS if they are due for a refresh. If the
+// refresh fails, the previously read credentials are returned as long as there are any and the refresh is retried
+// later (see refreshRetryAt).
+func (p *awsSecretsCredentialsProvider) Credentials(ctx context.Context) (string, string, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.cachedPassword != "" && time.Now().Before(p.refreshAt) {
+		return p.cachedUsername, p.cachedPassword, nil
+	}
+
+	values, err := p.resolver.resolveAll(ctx, p.cfg.Username, p.cfg.Password)
+	if err != nil {
+		if p.cachedPassword != "" {
+			p.refreshAt = refreshRetryAt(p.cfg.RefreshInterval)
+			return p.cachedUsername, p.cachedPassword, nil
+		}
+		return "", "", fmt.Errorf("failed to read sasl credentials from aws: %w", err)
+	}
+	username := values[0]
+	if p.cfg.Username == "" {
+		username = p.username
+	}
+	p.cachedUsername = username
+	p.cachedPassword = values[1]
+	p.refreshAt = time.Now().Add(p.cfg.RefreshInterval)
+
+	return p.cachedUsername, p.cachedPassword, nil
+}
@@ -2,17 +2,14 @@ package kafka
 
 import (
 	"context"
-	"crypto/tls"
-	"crypto/x509"
-	"encoding/pem"
 	"fmt"
-	"io/ioutil"
 	"net"
 	"time"
 
 	"github.com/jcmturner/gokrb5/v8/client"
 	"github.com/jcmturner/gokrb5/v8/keytab"
 	"github.com/twmb/franz-go/pkg/kgo"
+	"github.com/twmb/franz-go/pkg/kmsg"
 	"github.com/twmb/franz-go/pkg/kversion"
 	"github.com/twmb/franz-go/pkg/sasl"
 	"github.com/twmb/franz-go/pkg/sasl/kerberos"
@@ -28,16 +25,47 @@ import (
 // If TLS certificates can't be read an error will be returned.
 // logger is only used to print warnings about TLS.
 func NewKgoConfig(cfg Config, logger *zap.Logger) ([]kgo.Opt, error) {
+	saslCredentials, err := newSASLCredentialsFunc(cfg.SASL)
+	if err != nil {
+		return nil, err
+	}
+	return newKgoConfig(cfg, logger, saslCredentials)
+}
+
+// maxVersionsAboveV2_7_0 are requests that did not exist in Kafka 2.7 or whose newer versions are required, and
+// which are therefore allowed up to the highest version that franz-go supports.
+var maxVersionsAboveV2_7_0 = []kmsg.Request{
+	kmsg.NewPtrDescribeQuorumRequest(),
+	kmsg.NewPtrListTransactionsRequest(),
+	kmsg.NewPtrDescribeTransactionsRequest(),
+	kmsg.NewPtrDescribeProducersRequest(),
+	kmsg.NewPtrDescribeLogDirsRequest(), // v4 reports the capacity of the log dirs
+	kmsg.NewPtrListOffsetsRequest(),     // v7 supports the max timestamp spec (-3), v8 the earliest local spec (-4)
+}
+
+// maxVersions returns the max request versions of all clients. Requests are capped at Kafka 2.7, except for the
+// requests in maxVersionsAboveV2_7_0. The brokers further downgrade the versions to the ones they support.
+func maxVersions() *kversion.Versions {
+	versions := kversion.V2_7_0()
+	for _, req := range maxVersionsAboveV2_7_0 {
+		versions.SetMaxKeyVersion(req.Key(), req.MaxVersion())
+	}
+	return versions
+}
+
+// newKgoConfig creates a new Config for the Kafka Client, which retrieves the PLAIN and SCRAM credentials from the
+// given credentials func.
+func newKgoConfig(cfg Config, logger *zap.Logger, saslCredentials saslCredentialsFunc) ([]kgo.Opt, error) {
 	opts := []kgo.Opt{
 		kgo.SeedBrokers(cfg.Brokers...),
-		kgo.MaxVersions(kversion.V2_7_0()),
+		kgo.MaxVersions(maxVersions()),
 		kgo.ClientID(cfg.ClientID),
 		kgo.FetchMaxBytes(5 * 1000 * 1000), // 5MB
 		kgo.MaxConcurrentFetches(10),
 	}
 
 	// Create Logger
-	kgoLogger := KgoZapLogger{
+	kgoLogger := &KgoZapLogger{
 		logger: logger.Sugar(),
 	}
 	opts = append(opts, kgo.WithLogger(kgoLogger))
@@ -51,25 +79,31 @@ func NewKgoConfig(cfg Config, logger *zap.Logger) ([]kgo.Opt, error) {
 	if cfg.SASL.Enabled {
 		// SASL Plain
 		if cfg.SASL.Mechanism == "PLAIN" {
-			mechanism := plain.Auth{
-				User: cfg.SASL.Username,
-				Pass: cfg.SASL.Password,
-			}.AsMechanism()
+			mechanism := plain.Plain(func(ctx context.Context) (plain.Auth, error) {
+				username, password, err := saslCredentials(ctx)
+				return plain.Auth{
+					User: username,
+					Pass: password,
+				}, err
+			})
 			opts = append(opts, kgo.SASL(mechanism))
 		}
 
 		// SASL SCRAM
 		if cfg.SASL.Mechanism == "SCRAM-SHA-256" || cfg.SASL.Mechanism == "SCRAM-SHA-512" {
 			var mechanism sasl.Mechanism
-			scramAuth := scram.Auth{
-				User: cfg.SASL.Username,
-				Pass: cfg.SASL.Password,
+			scramAuth := func(ctx context.Context) (scram.Auth, error) {
+				username, password, err := saslCredentials(ctx)
+				return scram.Auth{
+					User: username,
+					Pass: password,
+				}, err
 			}
 			if cfg.SASL.Mechanism == "SCRAM-SHA-256" {
-				mechanism = scramAuth.AsSha256Mechanism()
+				mechanism = scram.Sha256(scramAuth)
 			}
 			if cfg.SASL.Mechanism == "SCRAM-SHA-512" {
-				mechanism = scramAuth.AsSha512Mechanism()
+				mechanism = scram.Sha512(scramAuth)
 			}
 			opts = append(opts, kgo.SASL(mechanism))
 		}
@@ -84,14 +118,14 @@ func NewKgoConfig(cfg Config, logger *zap.Logger) ([]kgo.Opt, error) {
 			}
 
 			switch cfg.SASL.GSSAPI.AuthType {
-			case "USER_AUTH:":
+			case GSSAPIAuthTypeUser:
 				krbClient = client.NewWithPassword(
 					cfg.SASL.GSSAPI.Username,
 					cfg.SASL.GSSAPI.Realm,
 					cfg.SASL.GSSAPI.Password,
 					kerbCfg,
 					client.DisablePAFXFAST(!cfg.SASL.GSSAPI.EnableFast))
-			case "KEYTAB_AUTH":
+			case GSSAPIAuthTypeKeytab:
 				ktb, err := keytab.Load(cfg.SASL.GSSAPI.KeyTabPath)
 				if err != nil {
 					return nil, fmt.Errorf("failed to load keytab: %w", err)
@@ -113,8 +147,9 @@ func NewKgoConfig(cfg Config, logger *zap.Logger) ([]kgo.Opt, error) {
 
 		// OAuthBearer
 		if cfg.SASL.Mechanism == "OAUTHBEARER" {
+			tokenSource := newOAuthTokenSource(cfg.SASL.OAuthBearer)
 			mechanism := oauth.Oauth(func(ctx context.Context) (oauth.Auth, error) {
-				token, err := cfg.SASL.OAuthBearer.getToken(ctx)
+				token, err := tokenSource.Token(ctx)
 				return oauth.Auth{
 					Zid:   cfg.SASL.OAuthBearer.ClientID,
 					Token: token,
@@ -122,82 +157,42 @@ func NewKgoConfig(cfg Config, logger *zap.Logger) ([]kgo.Opt, error) {
 			})
 			opts = append(opts, kgo.SASL(mechanism))
 		}
-	}
 
-	// Configure TLS
-	var caCertPool *x509.CertPool
-	if cfg.TLS.Enabled {
-		// Root CA
-		if cfg.TLS.CaFilepath != "" || len(cfg.TLS.Ca) > 0 {
-			ca := []byte(cfg.TLS.Ca)
-			if cfg.TLS.CaFilepath != "" {
-				caBytes, err := ioutil.ReadFile(cfg.TLS.CaFilepath)
-				if err != nil {
-					return nil, fmt.Errorf("failed to load ca cert: %w", err)
-				}
-				ca = caBytes
-			}
-			caCertPool = x509.NewCertPool()
-			isSuccessful := caCertPool.AppendCertsFromPEM(ca)
-			if !isSuccessful {
-				logger.Warn("failed to append ca file to cert pool, is this a valid PEM format?")
+		// AWS MSK IAM
+		if cfg.SASL.Mechanism == "AWS_MSK_IAM" {
+			mechanism, err := cfg.SASL.AWSMskIam.mechanism(context.Background())
+			if err != nil {
+				return nil, fmt.Errorf("failed to create AWS MSK IAM sasl mechanism: %w", err)
 			}
+			opts = append(opts, kgo.SASL(mechanism))
 		}
+	}
 
-		// If configured load TLS cert & key - Mutual TLS
-		var certificates []tls.Certificate
-		hasCertFile := cfg.TLS.CertFilepath != "" || len(cfg.TLS.Cert) > 0
-		hasKeyFile := cfg.TLS.KeyFilepath != "" || len(cfg.TLS.Key) > 0
-		if hasCertFile || hasKeyFile {
-			cert := []byte(cfg.TLS.Cert)
-			privateKey := []byte(cfg.TLS.Key)
-			// 1. Read certificates
-			if cfg.TLS.CertFilepath != "" {
-				certBytes, err := ioutil.ReadFile(cfg.TLS.CertFilepath)
-				if err != nil {
-					return nil, fmt.Errorf("failed to TLS certificate: %w", err)
-				}
-				cert = certBytes
-			}
-
-			if cfg.TLS.KeyFilepath != "" {
-				keyBytes, err := ioutil.ReadFile(cfg.TLS.KeyFilepath)
-				if err != nil {
-					return nil, fmt.Errorf("failed to read TLS key: %w", err)
-				}
-				privateKey = keyBytes
-			}
-
-			// 2. Check if private key needs to be decrypted. Decrypt it if passphrase is given, otherwise return error
-			pemBlock, _ := pem.Decode(privateKey)
-			if pemBlock == nil {
-				return nil, fmt.Errorf("no valid private key found")
-			}
-
-			if x509.IsEncryptedPEMBlock(pemBlock) {
-				decryptedKey, err := x509.DecryptPEMBlock(pemBlock, []byte(cfg.TLS.Passphrase))
-				if err != nil {
-					return nil, fmt.Errorf("private key is encrypted, but could not decrypt it: %s", err)
-				}
-				// If private key was encrypted we can overwrite the original contents now with the decrypted version
-				privateKey = pem.EncodeToMemory(&pem.Block{Type: pemBlock.Type, Bytes: decryptedKey})
-			}
-			tlsCert, err := tls.X509KeyPair(cert, privateKey)
-			if err != nil {
-				return nil, fmt.Errorf("cannot parse pem: %s", err)
-			}
-			certificates = []tls.Certificate{tlsCert}
+	// Configure Proxy
+	netDialer := &net.Dialer{Timeout: 10 * time.Second}
+	dial := netDialer.DialContext
+	if cfg.Proxy.Enabled {
+		proxyDial, err := newProxyDialFunc(cfg.Proxy, netDialer)
+		if err != nil {
+			return nil, err
 		}
+		dial = proxyDial
+	}
+
+	// Configure address rewrites, so that the rewritten address is dialed directly or through the proxy
+	if len(cfg.AddressRewrites) > 0 {
+		dial = newAddressRewriteDialFunc(dial, cfg.AddressRewrites)
+	}
 
-		tlsDialer := &tls.Dialer{
-			NetDialer: &net.Dialer{Timeout: 10 * time.Second},
-			Config: &tls.Config{
-				InsecureSkipVerify: cfg.TLS.InsecureSkipTLSVerify,
-				Certificates:       certificates,
-				RootCAs:            caCertPool,
-			},
+	// Configure TLS
+	if cfg.TLS.Enabled {
+		tlsLoader, err := newTLSConfigLoader(cfg.TLS, logger)
+		if err != nil {
+			return nil, err
 		}
-		opts = append(opts, kgo.Dialer(tlsDialer.DialContext))
+		opts = append(opts, kgo.Dialer(newTLSDialFunc(dial, tlsLoader.Get)))
+	} else if cfg.Proxy.Enabled || len(cfg.AddressRewrites) > 0 {
+		opts = append(opts, kgo.Dialer(dial))
 	}
 
 	return opts, nil
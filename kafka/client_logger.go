@@ -1,22 +1,35 @@
 package kafka
 
 import (
+	"sync"
+
 	"github.com/twmb/franz-go/pkg/kgo"
 	"go.uber.org/zap"
 )
 
 type KgoZapLogger struct {
 	logger *zap.SugaredLogger
+
+	// observers receive all log messages of the client, see logObserver
+	observersLock sync.RWMutex
+	observers     []logObserver
+}
+
+// logObserver is implemented by hooks that derive metrics from the client's log messages, because franz-go exposes
+// some events (e.g. SASL re-authentications) only by logging them. Hooks register themselves as observer in
+// OnNewClient, see observeClientLogs.
+type logObserver interface {
+	observeLog(level kgo.LogLevel, msg string, keyvals ...interface{})
 }
 
 // Level Implements kgo.Logger interface. It returns the log level to log at.
 // We pin this to debug as the zap logger decides what to actually send to the output stream.
-func (k KgoZapLogger) Level() kgo.LogLevel {
+func (k *KgoZapLogger) Level() kgo.LogLevel {
 	return kgo.LogLevelDebug
 }
 
 // Log implements kgo.Logger interface
-func (k KgoZapLogger) Log(level kgo.LogLevel, msg string, keyvals ...interface{}) {
+func (k *KgoZapLogger) Log(level kgo.LogLevel, msg string, keyvals ...interface{}) {
 	switch level {
 	case kgo.LogLevelDebug:
 		k.logger.Debugw(msg, keyvals...)
@@ -27,4 +40,27 @@ func (k KgoZapLogger) Log(level kgo.LogLevel, msg string, keyvals ...interface{}
 	case kgo.LogLevelError:
 		k.logger.Errorw(msg, keyvals...)
 	}
+
+	k.observersLock.RLock()
+	defer k.observersLock.RUnlock()
+	for _, observer := range k.observers {
+		observer.observeLog(level, msg, keyvals...)
+	}
+}
+
+func (k *KgoZapLogger) addObserver(observer logObserver) {
+	k.observersLock.Lock()
+	defer k.observersLock.Unlock()
+	k.observers = append(k.observers, observer)
+}
+
+// observeClientLogs registers the observer with the client's logger. It returns false if the client does not use a
+// KgoZapLogger.
+func observeClientLogs(client *kgo.Client, observer logObserver) bool {
+	logger, ok := client.OptValue(kgo.WithLogger).(*KgoZapLogger)
+	if !ok {
+		return false
+	}
+	logger.addObserver(observer)
+	return true
 }
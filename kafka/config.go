@@ -1,32 +1,92 @@
 package kafka
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+)
 
 type Config struct {
 	// General
-	Brokers  []string `koanf:"brokers"`
-	ClientID string   `koanf:"clientId"`
-	RackID   string   `koanf:"rackId"`
+	Brokers []string `koanf:"brokers"`
+
+	// ClientID of the minion and end-to-end clients. It may contain the template variables {{.ClusterName}},
+	// {{.Hostname}} and {{.PodName}}, so that brokers can distinguish kminion instances in request logs and quotas.
+	ClientID string `koanf:"clientId"`
+
+	// ClusterName of the monitored cluster, which can be referenced as {{.ClusterName}} in the client id
+	ClusterName string `koanf:"clusterName"`
+
+	// FallbackBrokers are further sets of seed brokers, e.g. of another listener, which are tried in order if the
+	// connection can not be established with the seed brokers. Running clients are switched to the next working set
+	// of seed brokers if the active set fails the periodic health check.
+	FallbackBrokers [][]string `koanf:"fallbackBrokers"`
+
+	// FallbackCheckInterval is the interval in which the active set of seed brokers is health checked if fallback
+	// brokers are configured. Zero disables the health check, so that the seed brokers are only failed over when a
+	// client is created.
+	FallbackCheckInterval time.Duration `koanf:"fallbackCheckInterval"`
+
+	// RackID is the client.rack of all clients. If set, consumers fetch from the closest replica (KIP-392), which
+	// requires the brokers to have a replica.selector.class configured.
+	RackID string `koanf:"rackId"`
 
 	TLS  TLSConfig  `koanf:"tls"`
 	SASL SASLConfig `koanf:"sasl"`
 
+	// Proxy through which all connections to the brokers are established
+	Proxy ProxyConfig `koanf:"proxy"`
+
+	// AddressRewrites rewrite the addresses advertised by the brokers to addresses that are reachable by kminion
+	AddressRewrites []AddressRewriteConfig `koanf:"addressRewrites"`
+
 	RetryInitConnection bool `koanf:"retryInitConnection"`
+
+	// RequestMetrics exports the number of requests, failed requests and request latencies of kminion's clients by
+	// broker and API key.
+	RequestMetrics bool `koanf:"requestMetrics"`
+
+	// RequestMetricsHistogramType is the type of the request latency histograms: classic, native or both
+	RequestMetricsHistogramType string `koanf:"requestMetricsHistogramType"`
 }
 
 func (c *Config) SetDefaults() {
 	c.ClientID = "kminion"
+	c.FallbackCheckInterval = 30 * time.Second
+	c.RequestMetricsHistogramType = HistogramTypeClassic
 
 	c.TLS.SetDefaults()
 	c.SASL.SetDefaults()
+	c.Proxy.SetDefaults()
 }
 
 func (c *Config) Validate() error {
 	if len(c.Brokers) == 0 {
 		return fmt.Errorf("no seed brokers specified, at least one must be configured")
 	}
+	for i, brokers := range c.FallbackBrokers {
+		if len(brokers) == 0 {
+			return fmt.Errorf("fallback brokers at index '%v' must contain at least one seed broker", i)
+		}
+	}
+	if c.FallbackCheckInterval < 0 {
+		return fmt.Errorf("fallback check interval must not be negative")
+	}
+
+	nameTemplateData, err := NewNameTemplateData(c.ClusterName)
+	if err != nil {
+		return err
+	}
+	c.ClientID, err = RenderNameTemplate(c.ClientID, nameTemplateData)
+	if err != nil {
+		return fmt.Errorf("failed to render client id: %w", err)
+	}
+
+	err = ValidateHistogramType(c.RequestMetricsHistogramType)
+	if err != nil {
+		return fmt.Errorf("failed to validate requestMetricsHistogramType: %w", err)
+	}
 
-	err := c.TLS.Validate()
+	err = c.TLS.Validate()
 	if err != nil {
 		return fmt.Errorf("failed to validate TLS config: %w", err)
 	}
@@ -36,5 +96,17 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("failed to validate SASL config: %w", err)
 	}
 
+	err = c.Proxy.Validate()
+	if err != nil {
+		return fmt.Errorf("failed to validate proxy config: %w", err)
+	}
+
+	for i, rewrite := range c.AddressRewrites {
+		err = rewrite.Validate()
+		if err != nil {
+			return fmt.Errorf("failed to validate address rewrite at index '%v': %w", i, err)
+		}
+	}
+
 	return nil
 }
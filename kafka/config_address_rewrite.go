@@ -0,0 +1,45 @@
+package kafka
+
+import (
+	"fmt"
+	"net"
+)
+
+// AddressRewriteConfig rewrites a broker address, e.g. an internal hostname that the brokers advertise, to an address
+// that is reachable by kminion, such as a NodePort or LoadBalancer address.
+type AddressRewriteConfig struct {
+	// From is either host:port or just the host of the advertised address. If only the host is given, the rewrite
+	// applies to all ports of that host.
+	From string `koanf:"from"`
+
+	// To is either host:port or just the host to connect to instead. If only the host is given, the port of the
+	// advertised address is kept.
+	To string `koanf:"to"`
+}
+
+func (c *AddressRewriteConfig) Validate() error {
+	if c.From == "" {
+		return fmt.Errorf("from must be specified")
+	}
+	if c.To == "" {
+		return fmt.Errorf("to must be specified")
+	}
+
+	return nil
+}
+
+// rewrite returns the address to connect to for the given host:port and whether the rewrite applies to it.
+func (c *AddressRewriteConfig) rewrite(address string) (string, bool) {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return "", false
+	}
+	if c.From != address && c.From != host {
+		return "", false
+	}
+
+	if _, _, err := net.SplitHostPort(c.To); err == nil {
+		return c.To, true
+	}
+	return net.JoinHostPort(c.To, port), true
+}
@@ -0,0 +1,99 @@
+package kafka
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	awsSecretRefPrefixSecretsManager = "secretsmanager:"
+	awsSecretRefPrefixSSM            = "ssm:"
+)
+
+// SASLAWSSecretsConfig configures reading the SASL username and password for the PLAIN and SCRAM mechanisms from AWS
+// Secrets Manager or SSM Parameter Store. The AWS credentials are sourced from the default AWS credential chain.
+type SASLAWSSecretsConfig struct {
+	Enabled bool `koanf:"enabled"`
+
+	// Region of the secrets, defaults to the region of the default AWS config (e.g. AWS_REGION).
+	Region string `koanf:"region"`
+
+	// Username and Password are secret references, either "secretsmanager:<secret id or arn>[#<json key>]" or
+	// "ssm:<parameter name>". If the username is empty, the username of the SASL config is used.
+	Username string `koanf:"username"`
+	Password string `koanf:"password"`
+
+	// RefreshInterval is the time after which the secrets are read again, so that rotated secrets are picked up.
+	RefreshInterval time.Duration `koanf:"refreshInterval"`
+}
+
+func (c *SASLAWSSecretsConfig) SetDefaults() {
+	c.Enabled = false
+	c.RefreshInterval = 5 * time.Minute
+}
+
+func (c *SASLAWSSecretsConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+	if c.Password == "" {
+		return fmt.Errorf("aws secrets password reference must be specified")
+	}
+	for _, ref := range []string{c.Username, c.Password} {
+		if err := validateAWSSecretRef(ref); err != nil {
+			return err
+		}
+	}
+	if c.RefreshInterval <= 0 {
+		return fmt.Errorf("aws secrets refresh interval must be positive, but got '%v'", c.RefreshInterval)
+	}
+	return nil
+}
+
+// TLSAWSSecretsConfig configures reading the PEM encoded CA, certificate and key from AWS Secrets Manager or SSM
+// Parameter Store. The AWS credentials are sourced from the default AWS credential chain.
+type TLSAWSSecretsConfig struct {
+	Enabled bool `koanf:"enabled"`
+
+	// Region of the secrets, defaults to the region of the default AWS config (e.g. AWS_REGION).
+	Region string `koanf:"region"`
+
+	// Ca, Cert and Key are secret references, either "secretsmanager:<secret id or arn>[#<json key>]" or
+	// "ssm:<parameter name>". Empty references are not read.
+	Ca   string `koanf:"ca"`
+	Cert string `koanf:"cert"`
+	Key  string `koanf:"key"`
+
+	// RefreshInterval is the time after which the secrets are read again, so that rotated certificates are picked up.
+	RefreshInterval time.Duration `koanf:"refreshInterval"`
+}
+
+func (c *TLSAWSSecretsConfig) SetDefaults() {
+	c.Enabled = false
+	c.RefreshInterval = 5 * time.Minute
+}
+
+func (c *TLSAWSSecretsConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+	for _, ref := range []string{c.Ca, c.Cert, c.Key} {
+		if err := validateAWSSecretRef(ref); err != nil {
+			return err
+		}
+	}
+	if c.RefreshInterval <= 0 {
+		return fmt.Errorf("aws secrets refresh interval must be positive, but got '%v'", c.RefreshInterval)
+	}
+	return nil
+}
+
+// validateAWSSecretRef checks whether the given secret reference is either empty or has a supported prefix.
+func validateAWSSecretRef(ref string) error {
+	if ref == "" || strings.HasPrefix(ref, awsSecretRefPrefixSecretsManager) || strings.HasPrefix(ref, awsSecretRefPrefixSSM) {
+		return nil
+	}
+	return fmt.Errorf("aws secret reference '%v' must start with '%v' or '%v'",
+		ref, awsSecretRefPrefixSecretsManager, awsSecretRefPrefixSSM)
+}
@@ -0,0 +1,45 @@
+package kafka
+
+import "fmt"
+
+const (
+	ProxyTypeSOCKS5 = "socks5"
+	ProxyTypeHTTP   = "http"
+)
+
+// ProxyConfig to connect to Kafka through a SOCKS5 or HTTP (CONNECT) proxy
+type ProxyConfig struct {
+	Enabled bool `koanf:"enabled"`
+
+	// Type is either socks5 or http
+	Type string `koanf:"type"`
+
+	// Address is the host:port of the proxy
+	Address string `koanf:"address"`
+
+	// Username and password are optional credentials for the proxy
+	Username string `koanf:"username"`
+	Password string `koanf:"password"`
+}
+
+func (c *ProxyConfig) SetDefaults() {
+	c.Enabled = false
+	c.Type = ProxyTypeSOCKS5
+}
+
+func (c *ProxyConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+
+	switch c.Type {
+	case ProxyTypeSOCKS5, ProxyTypeHTTP:
+	default:
+		return fmt.Errorf("given proxy type '%v' is invalid", c.Type)
+	}
+	if c.Address == "" {
+		return fmt.Errorf("proxy address must be specified")
+	}
+
+	return nil
+}
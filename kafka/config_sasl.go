@@ -1,6 +1,12 @@
 package kafka
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"time"
+)
 
 const (
 	SASLMechanismPlain       = "PLAIN"
@@ -8,6 +14,7 @@ const (
 	SASLMechanismScramSHA512 = "SCRAM-SHA-512"
 	SASLMechanismGSSAPI      = "GSSAPI"
 	SASLMechanismOAuthBearer = "OAUTHBEARER"
+	SASLMechanismAWSMskIam   = "AWS_MSK_IAM"
 )
 
 // SASLConfig for Kafka Client
@@ -17,9 +24,21 @@ type SASLConfig struct {
 	Password  string `koanf:"password"`
 	Mechanism string `koanf:"mechanism"`
 
+	// PasswordFilepath is the path to a file that contains the password for the PLAIN or SCRAM mechanism. The file is
+	// read upon each authentication, so that rotated passwords are used without restarting kminion.
+	PasswordFilepath string `koanf:"passwordFilepath"`
+
 	// SASL Mechanisms that require more configuration than username & password
-	GSSAPI      SASLGSSAPIConfig  `koanf:"gssapi"`
-	OAuthBearer OAuthBearerConfig `koanf:"oauth"`
+	GSSAPI      SASLGSSAPIConfig    `koanf:"gssapi"`
+	OAuthBearer OAuthBearerConfig   `koanf:"oauth"`
+	AWSMskIam   SASLAWSMskIamConfig `koanf:"awsMskIam"`
+
+	// Vault reads the username and password for the PLAIN or SCRAM mechanism from a HashiCorp Vault secret
+	Vault SASLVaultConfig `koanf:"vault"`
+
+	// AWSSecrets reads the username and password for the PLAIN or SCRAM mechanism from AWS Secrets Manager or SSM
+	// Parameter Store
+	AWSSecrets SASLAWSSecretsConfig `koanf:"awsSecrets"`
 }
 
 // SetDefaults for SASL Config
@@ -27,6 +46,9 @@ func (c *SASLConfig) SetDefaults() {
 	c.Enabled = false
 	c.Mechanism = SASLMechanismPlain
 	c.GSSAPI.SetDefaults()
+	c.AWSMskIam.SetDefaults()
+	c.Vault.SetDefaults()
+	c.AWSSecrets.SetDefaults()
 }
 
 // Validate SASL config input
@@ -35,14 +57,95 @@ func (c *SASLConfig) Validate() error {
 		return nil
 	}
 
+	if c.Password != "" && c.PasswordFilepath != "" {
+		return fmt.Errorf("config keys 'password' and 'passwordFilepath' are both set. only one can be used at the same time")
+	}
+
+	err := c.Vault.Validate()
+	if err != nil {
+		return err
+	}
+	err = c.AWSSecrets.Validate()
+	if err != nil {
+		return err
+	}
+	if c.Vault.Enabled && c.AWSSecrets.Enabled {
+		return fmt.Errorf("vault and aws secrets can not be enabled at the same time")
+	}
+	if c.Vault.Enabled || c.AWSSecrets.Enabled {
+		switch c.Mechanism {
+		case SASLMechanismPlain, SASLMechanismScramSHA256, SASLMechanismScramSHA512:
+		default:
+			return fmt.Errorf("vault and aws secrets credentials are only supported for the PLAIN and SCRAM mechanisms")
+		}
+		if c.Password != "" || c.PasswordFilepath != "" {
+			return fmt.Errorf("the password must not be configured if vault or aws secrets are enabled")
+		}
+	}
+
 	switch c.Mechanism {
-	case SASLMechanismPlain, SASLMechanismScramSHA256, SASLMechanismScramSHA512, SASLMechanismGSSAPI:
+	case SASLMechanismPlain, SASLMechanismScramSHA256, SASLMechanismScramSHA512:
 		// Valid and supported
+	case SASLMechanismGSSAPI:
+		return c.GSSAPI.Validate()
 	case SASLMechanismOAuthBearer:
 		return c.OAuthBearer.Validate()
+	case SASLMechanismAWSMskIam:
+		return c.AWSMskIam.Validate()
 	default:
 		return fmt.Errorf("given sasl mechanism '%v' is invalid", c.Mechanism)
 	}
 
 	return nil
 }
+
+// credentialsRefreshRetryInterval is the time after which a failed refresh of previously read credentials is retried.
+const credentialsRefreshRetryInterval = 30 * time.Second
+
+// refreshRetryAt returns when a failed refresh of previously read credentials shall be retried, so that the credential
+// source is not called again for each connection that is authenticated in the meantime.
+func refreshRetryAt(refreshInterval time.Duration) time.Time {
+	if refreshInterval < credentialsRefreshRetryInterval {
+		return time.Now().Add(refreshInterval)
+	}
+	return time.Now().Add(credentialsRefreshRetryInterval)
+}
+
+// saslCredentialsFunc returns the username and password for the PLAIN and SCRAM mechanisms.
+type saslCredentialsFunc func(ctx context.Context) (string, string, error)
+
+// newSASLCredentialsFunc returns the credentials func for the configured credential source, which is either Vault,
+// AWS, the password file or the password in the config.
+func newSASLCredentialsFunc(c SASLConfig) (saslCredentialsFunc, error) {
+	if c.Vault.Enabled {
+		provider, err := newVaultCredentialsProvider(c.Vault, c.Username)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create vault credentials provider: %w", err)
+		}
+		return provider.Credentials, nil
+	}
+	if c.AWSSecrets.Enabled {
+		provider, err := newAWSSecretsCredentialsProvider(c.AWSSecrets, c.Username)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create aws secrets credentials provider: %w", err)
+		}
+		return provider.Credentials, nil
+	}
+
+	return func(context.Context) (string, string, error) {
+		password, err := c.getPassword()
+		return c.Username, password, err
+	}, nil
+}
+
+// getPassword returns the configured password or reads it from the password file.
+func (c *SASLConfig) getPassword() (string, error) {
+	if c.PasswordFilepath == "" {
+		return c.Password, nil
+	}
+	password, err := ioutil.ReadFile(c.PasswordFilepath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read sasl password file: %w", err)
+	}
+	return strings.TrimRight(string(password), "\r\n"), nil
+}
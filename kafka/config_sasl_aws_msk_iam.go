@@ -0,0 +1,74 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/twmb/franz-go/pkg/sasl"
+	awssasl "github.com/twmb/franz-go/pkg/sasl/aws"
+)
+
+// SASLAWSMskIamConfig represents the config for the AWS_MSK_IAM mechanism. If no access key is specified, the
+// credentials are sourced from the default AWS credential chain, which includes environment variables, shared
+// config files, web identity tokens (IRSA), ECS task roles and EC2 instance profiles.
+type SASLAWSMskIamConfig struct {
+	AccessKey    string `koanf:"accessKey"`
+	SecretKey    string `koanf:"secretKey"`
+	SessionToken string `koanf:"sessionToken"`
+
+	// UserAgent is sent to the brokers along with the credentials, it shows up in the broker logs.
+	UserAgent string `koanf:"userAgent"`
+}
+
+func (c *SASLAWSMskIamConfig) SetDefaults() {
+	c.UserAgent = "kminion"
+}
+
+func (c *SASLAWSMskIamConfig) Validate() error {
+	if (c.AccessKey == "") != (c.SecretKey == "") {
+		return fmt.Errorf("AWS MSK IAM access key and secret key must either both be specified or both be empty")
+	}
+	if c.SessionToken != "" && c.AccessKey == "" {
+		return fmt.Errorf("AWS MSK IAM session token must not be specified without an access key")
+	}
+	return nil
+}
+
+// credentialsProvider returns the static credentials if configured, otherwise the default AWS credential chain.
+// The returned provider caches the credentials until they expire.
+func (c *SASLAWSMskIamConfig) credentialsProvider(ctx context.Context) (aws.CredentialsProvider, error) {
+	if c.AccessKey != "" {
+		return credentials.NewStaticCredentialsProvider(c.AccessKey, c.SecretKey, c.SessionToken), nil
+	}
+
+	awsCfg, err := awsconfig.LoadDefaultConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load default AWS config: %w", err)
+	}
+	return awsCfg.Credentials, nil
+}
+
+// mechanism returns the AWS_MSK_IAM SASL mechanism. The credentials are retrieved upon each authentication so that
+// temporary credentials are refreshed before they expire.
+func (c *SASLAWSMskIamConfig) mechanism(ctx context.Context) (sasl.Mechanism, error) {
+	provider, err := c.credentialsProvider(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return awssasl.ManagedStreamingIAM(func(ctx context.Context) (awssasl.Auth, error) {
+		creds, err := provider.Retrieve(ctx)
+		if err != nil {
+			return awssasl.Auth{}, fmt.Errorf("failed to retrieve AWS credentials: %w", err)
+		}
+		return awssasl.Auth{
+			AccessKey:    creds.AccessKeyID,
+			SecretKey:    creds.SecretAccessKey,
+			SessionToken: creds.SessionToken,
+			UserAgent:    c.UserAgent,
+		}, nil
+	}), nil
+}
@@ -1,14 +1,26 @@
 package kafka
 
+import "fmt"
+
+const (
+	GSSAPIAuthTypeUser   = "USER_AUTH"
+	GSSAPIAuthTypeKeytab = "KEYTAB_AUTH"
+)
+
 // SASLGSSAPIConfig represents the Kafka Kerberos config
 type SASLGSSAPIConfig struct {
+	// AuthType is either USER_AUTH (username & password) or KEYTAB_AUTH (username & keytab)
 	AuthType           string `koanf:"authType"`
 	KeyTabPath         string `koanf:"keyTabPath"`
 	KerberosConfigPath string `koanf:"kerberosConfigPath"`
-	ServiceName        string `koanf:"serviceName"`
-	Username           string `koanf:"username"`
-	Password           string `koanf:"password"`
-	Realm              string `koanf:"realm"`
+
+	// ServiceName is the primary of the brokers' Kerberos principal
+	ServiceName string `koanf:"serviceName"`
+
+	// Username is the primary (and instance) of kminion's Kerberos principal, the realm is configured separately.
+	Username string `koanf:"username"`
+	Password string `koanf:"password"`
+	Realm    string `koanf:"realm"`
 
 	// EnableFAST enables FAST, which is a pre-authentication framework for Kerberos.
 	// It includes a mechanism for tunneling pre-authentication exchanges using armoured KDC messages.
@@ -17,5 +29,36 @@ type SASLGSSAPIConfig struct {
 }
 
 func (s *SASLGSSAPIConfig) SetDefaults() {
+	s.AuthType = GSSAPIAuthTypeKeytab
+	s.KerberosConfigPath = "/etc/krb5.conf"
+	s.ServiceName = "kafka"
 	s.EnableFast = true
 }
+
+func (s *SASLGSSAPIConfig) Validate() error {
+	switch s.AuthType {
+	case GSSAPIAuthTypeUser:
+		if s.Password == "" {
+			return fmt.Errorf("GSSAPI password must be specified for auth type '%v'", s.AuthType)
+		}
+	case GSSAPIAuthTypeKeytab:
+		if s.KeyTabPath == "" {
+			return fmt.Errorf("GSSAPI keytab path must be specified for auth type '%v'", s.AuthType)
+		}
+	default:
+		return fmt.Errorf("given GSSAPI auth type '%v' is invalid, valid values are '%v' and '%v'",
+			s.AuthType, GSSAPIAuthTypeUser, GSSAPIAuthTypeKeytab)
+	}
+
+	if s.KerberosConfigPath == "" {
+		return fmt.Errorf("GSSAPI kerberos config path must be specified")
+	}
+	if s.ServiceName == "" {
+		return fmt.Errorf("GSSAPI service name must be specified")
+	}
+	if s.Username == "" || s.Realm == "" {
+		return fmt.Errorf("GSSAPI username and realm must be specified")
+	}
+
+	return nil
+}
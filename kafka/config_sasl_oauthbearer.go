@@ -8,13 +8,17 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"sync"
+	"time"
 )
 
 type OAuthBearerConfig struct {
 	TokenEndpoint string `koanf:"tokenEndpoint"`
 	ClientID      string `koanf:"clientId"`
 	ClientSecret  string `koanf:"clientSecret"`
-	Scope         string `koanf:"scope"`
+
+	// Scope is a space separated list of scopes that are requested for the access token.
+	Scope string `koanf:"scope"`
 }
 
 func (c *OAuthBearerConfig) Validate() error {
@@ -28,17 +32,21 @@ func (c *OAuthBearerConfig) Validate() error {
 }
 
 // same as AcquireToken in Console https://github.com/redpanda-data/console/blob/master/backend/pkg/config/kafka_sasl_oauth.go#L56
-func (c *OAuthBearerConfig) getToken(ctx context.Context) (string, error) {
+// The returned duration is the lifetime of the token as reported by the token endpoint, it is zero if the endpoint
+// didn't report a lifetime.
+func (c *OAuthBearerConfig) getToken(ctx context.Context) (string, time.Duration, error) {
 	authHeaderValue := base64.StdEncoding.EncodeToString([]byte(c.ClientID + ":" + c.ClientSecret))
 
 	queryParams := url.Values{
 		"grant_type": []string{"client_credentials"},
-		"scope":      []string{c.Scope},
+	}
+	if c.Scope != "" {
+		queryParams.Set("scope", c.Scope)
 	}
 
 	req, err := http.NewRequestWithContext(ctx, "POST", c.TokenEndpoint, strings.NewReader(queryParams.Encode()))
 	if err != nil {
-		return "", fmt.Errorf("failed to create HTTP request: %w", err)
+		return "", 0, fmt.Errorf("failed to create HTTP request: %w", err)
 	}
 
 	req.URL.RawQuery = queryParams.Encode()
@@ -50,24 +58,65 @@ func (c *OAuthBearerConfig) getToken(ctx context.Context) (string, error) {
 
 	resp, err := client.Do(req)
 	if err != nil {
-		return "", fmt.Errorf("HTTP request failed: %w", err)
+		return "", 0, fmt.Errorf("HTTP request failed: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("token request failed with status code %d", resp.StatusCode)
+		return "", 0, fmt.Errorf("token request failed with status code %d", resp.StatusCode)
 	}
 
 	var tokenResponse map[string]interface{}
 	decoder := json.NewDecoder(resp.Body)
 	if err := decoder.Decode(&tokenResponse); err != nil {
-		return "", fmt.Errorf("failed to parse token response: %w", err)
+		return "", 0, fmt.Errorf("failed to parse token response: %w", err)
 	}
 
 	accessToken, ok := tokenResponse["access_token"].(string)
 	if !ok {
-		return "", fmt.Errorf("access_token not found in token response")
+		return "", 0, fmt.Errorf("access_token not found in token response")
+	}
+
+	var expiresIn time.Duration
+	if expiresInSeconds, ok := tokenResponse["expires_in"].(float64); ok && expiresInSeconds > 0 {
+		expiresIn = time.Duration(expiresInSeconds * float64(time.Second))
+	}
+
+	return accessToken, expiresIn, nil
+}
+
+// oauthTokenSource caches the access token, so that the token endpoint is not called for every connection that is
+// authenticated. The token is refreshed once 80% of its lifetime have passed, so that connections never authenticate
+// with a token that is about to expire. Brokers that enforce a max re-authentication time (connections.max.reauth.ms)
+// make the client re-authenticate existing connections, which picks up the refreshed token.
+type oauthTokenSource struct {
+	cfg OAuthBearerConfig
+
+	mutex     sync.Mutex
+	token     string
+	refreshAt time.Time
+}
+
+func newOAuthTokenSource(cfg OAuthBearerConfig) *oauthTokenSource {
+	return &oauthTokenSource{cfg: cfg}
+}
+
+// Token returns the cached access token or requests a new one if the cached token is due for a refresh. Tokens
+// without a reported lifetime are not cached.
+func (s *oauthTokenSource) Token(ctx context.Context) (string, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.token != "" && time.Now().Before(s.refreshAt) {
+		return s.token, nil
+	}
+
+	token, expiresIn, err := s.cfg.getToken(ctx)
+	if err != nil {
+		return "", err
 	}
+	s.token = token
+	s.refreshAt = time.Now().Add(expiresIn * 8 / 10)
 
-	return accessToken, nil
+	return token, nil
 }
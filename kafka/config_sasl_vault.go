@@ -0,0 +1,89 @@
+package kafka
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	VaultAuthMethodToken      = "token"
+	VaultAuthMethodKubernetes = "kubernetes"
+)
+
+// SASLVaultConfig configures fetching the SASL username and password for the PLAIN and SCRAM mechanisms from a
+// HashiCorp Vault secret, so that they don't have to be stored in the config or on disk.
+type SASLVaultConfig struct {
+	Enabled bool `koanf:"enabled"`
+
+	// Address of the Vault server, e.g. https://vault.example.com:8200
+	Address string `koanf:"address"`
+
+	// Namespace is the Vault Enterprise namespace, it is omitted if empty.
+	Namespace string `koanf:"namespace"`
+
+	// CaFilepath is the path to the CA certificate used to verify the Vault server. The system's CAs are used if empty.
+	CaFilepath string `koanf:"caFilepath"`
+
+	// AuthMethod is either token or kubernetes
+	AuthMethod string `koanf:"authMethod"`
+
+	// Token is used for the token auth method. If empty, the VAULT_TOKEN env variable is used. The token is not
+	// renewed by kminion, use a long-lived token or a Vault agent if it expires.
+	Token string `koanf:"token"`
+
+	// Kubernetes auth method: kminion logs in with its service account token and logs in again once the Vault token
+	// has expired.
+	KubernetesRole          string `koanf:"kubernetesRole"`
+	KubernetesMountPath     string `koanf:"kubernetesMountPath"`
+	KubernetesTokenFilepath string `koanf:"kubernetesTokenFilepath"`
+
+	// SecretPath is the API path of the secret, e.g. secret/data/kminion for the KV v2 engine mounted at secret/.
+	// Secrets of both the KV v1 and v2 engines are supported.
+	SecretPath string `koanf:"secretPath"`
+	// UsernameKey is the key of the username in the secret. If the secret doesn't contain it, sasl.username is used.
+	UsernameKey string `koanf:"usernameKey"`
+	PasswordKey string `koanf:"passwordKey"`
+
+	// RefreshInterval is the time after which the secret is read again, so that rotated credentials are picked up.
+	RefreshInterval time.Duration `koanf:"refreshInterval"`
+}
+
+func (c *SASLVaultConfig) SetDefaults() {
+	c.Enabled = false
+	c.AuthMethod = VaultAuthMethodToken
+	c.KubernetesMountPath = "kubernetes"
+	c.KubernetesTokenFilepath = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+	c.UsernameKey = "username"
+	c.PasswordKey = "password"
+	c.RefreshInterval = 5 * time.Minute
+}
+
+func (c *SASLVaultConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+
+	if c.Address == "" {
+		return fmt.Errorf("vault address must be specified")
+	}
+	switch c.AuthMethod {
+	case VaultAuthMethodToken:
+	case VaultAuthMethodKubernetes:
+		if c.KubernetesRole == "" {
+			return fmt.Errorf("vault kubernetes role must be specified for auth method '%v'", c.AuthMethod)
+		}
+	default:
+		return fmt.Errorf("given vault auth method '%v' is invalid", c.AuthMethod)
+	}
+	if c.SecretPath == "" {
+		return fmt.Errorf("vault secret path must be specified")
+	}
+	if c.UsernameKey == "" || c.PasswordKey == "" {
+		return fmt.Errorf("vault username and password keys must be specified")
+	}
+	if c.RefreshInterval <= 0 {
+		return fmt.Errorf("vault refresh interval must be positive, but got '%v'", c.RefreshInterval)
+	}
+
+	return nil
+}
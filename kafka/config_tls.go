@@ -13,10 +13,14 @@ type TLSConfig struct {
 	Key                   string `koanf:"key"`
 	Passphrase            string `koanf:"passphrase"`
 	InsecureSkipTLSVerify bool   `koanf:"insecureSkipTlsVerify"`
+
+	// AWSSecrets reads the CA, certificate and key from AWS Secrets Manager or SSM Parameter Store
+	AWSSecrets TLSAWSSecretsConfig `koanf:"awsSecrets"`
 }
 
 func (c *TLSConfig) SetDefaults() {
 	c.Enabled = false
+	c.AWSSecrets.SetDefaults()
 }
 
 func (c *TLSConfig) Validate() error {
@@ -30,5 +34,21 @@ func (c *TLSConfig) Validate() error {
 	if len(c.KeyFilepath) > 0 && len(c.Key) > 0 {
 		return fmt.Errorf("config keys 'keyFilepath' and 'key' are both set. only one can be used at the same time")
 	}
+
+	err := c.AWSSecrets.Validate()
+	if err != nil {
+		return err
+	}
+	if c.AWSSecrets.Enabled {
+		if c.AWSSecrets.Ca != "" && (len(c.CaFilepath) > 0 || len(c.Ca) > 0) {
+			return fmt.Errorf("the ca must not be configured if it is read from aws secrets")
+		}
+		if c.AWSSecrets.Cert != "" && (len(c.CertFilepath) > 0 || len(c.Cert) > 0) {
+			return fmt.Errorf("the cert must not be configured if it is read from aws secrets")
+		}
+		if c.AWSSecrets.Key != "" && (len(c.KeyFilepath) > 0 || len(c.Key) > 0) {
+			return fmt.Errorf("the key must not be configured if it is read from aws secrets")
+		}
+	}
 	return nil
 }
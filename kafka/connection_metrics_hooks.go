@@ -0,0 +1,53 @@
+package kafka
+
+import (
+	"net"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/twmb/franz-go/pkg/kgo"
+)
+
+// ConnectionMetricsHooks implements the kgo.HookBrokerConnect and kgo.HookBrokerDisconnect interfaces and exports how
+// many connections a client opened and closed, by broker. A high connection churn towards specific brokers is often an
+// early sign of network issues.
+type ConnectionMetricsHooks struct {
+	connectionsOpened *prometheus.CounterVec
+	connectionsClosed *prometheus.CounterVec
+}
+
+// NewConnectionMetricsHooks creates the connection metrics with the given namespace and subsystem and registers them
+// with the given registerer.
+func NewConnectionMetricsHooks(namespace string, subsystem string, registerer prometheus.Registerer) *ConnectionMetricsHooks {
+	connectionsOpened := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      "client_connections_opened_total",
+		Help:      "Number of connections that have been opened to the broker",
+	}, []string{"broker_id"})
+	connectionsClosed := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      "client_connections_closed_total",
+		Help:      "Number of connections to the broker that have been closed",
+	}, []string{"broker_id"})
+	registerer.MustRegister(connectionsOpened, connectionsClosed)
+
+	return &ConnectionMetricsHooks{
+		connectionsOpened: connectionsOpened,
+		connectionsClosed: connectionsClosed,
+	}
+}
+
+// OnBrokerConnect is called after a connection to a broker has been opened or failed to be opened.
+func (h *ConnectionMetricsHooks) OnBrokerConnect(meta kgo.BrokerMetadata, _ time.Duration, _ net.Conn, err error) {
+	if err != nil {
+		return
+	}
+	h.connectionsOpened.WithLabelValues(brokerIDLabel(meta)).Inc()
+}
+
+// OnBrokerDisconnect is called after a connection to a broker has been closed.
+func (h *ConnectionMetricsHooks) OnBrokerDisconnect(meta kgo.BrokerMetadata, _ net.Conn) {
+	h.connectionsClosed.WithLabelValues(brokerIDLabel(meta)).Inc()
+}
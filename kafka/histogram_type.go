@@ -0,0 +1,45 @@
+package kafka
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// HistogramTypeClassic exports histograms with the configured buckets only
+	HistogramTypeClassic = "classic"
+	// HistogramTypeNative exports native (sparse, exponential) histograms only, which require Prometheus to be started
+	// with the native-histograms feature flag and to scrape the protobuf exposition format
+	HistogramTypeNative = "native"
+	// HistogramTypeBoth exports both, so that dashboards can be migrated
+	HistogramTypeBoth = "both"
+)
+
+// ValidateHistogramType returns an error if the given histogram type is none of classic, native or both.
+func ValidateHistogramType(histogramType string) error {
+	switch histogramType {
+	case HistogramTypeClassic, HistogramTypeNative, HistogramTypeBoth:
+		return nil
+	default:
+		return fmt.Errorf("histogram type '%v' is invalid. Valid values are classic, native or both", histogramType)
+	}
+}
+
+// WithHistogramType returns the histogram opts for the given histogram type. Native histograms use a bucket factor
+// of 1.1, which bounds the relative error of quantiles to roughly 5%, and at most 160 buckets per series.
+func WithHistogramType(opts prometheus.HistogramOpts, histogramType string) prometheus.HistogramOpts {
+	if histogramType == HistogramTypeClassic || histogramType == "" {
+		return opts
+	}
+
+	opts.NativeHistogramBucketFactor = 1.1
+	opts.NativeHistogramMaxBucketNumber = 160
+	opts.NativeHistogramMinResetDuration = time.Hour
+	if histogramType == HistogramTypeNative {
+		// Histograms without classic buckets are exported as native histograms only
+		opts.Buckets = nil
+	}
+	return opts
+}
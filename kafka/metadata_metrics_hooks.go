@@ -0,0 +1,78 @@
+package kafka
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/twmb/franz-go/pkg/kgo"
+	"github.com/twmb/franz-go/pkg/kmsg"
+)
+
+var metadataRequestKey = new(kmsg.MetadataRequest).Key()
+
+// MetadataMetricsHooks implements the kgo.HookBrokerE2E interface and exports how old the metadata of a client is, how
+// often it has been refreshed and how long the refreshes took. All metadata requests of a client are considered
+// refreshes, regardless of whether the client refreshed its cached metadata or kminion requested the metadata.
+type MetadataMetricsHooks struct {
+	refreshes       prometheus.Counter
+	refreshErrors   prometheus.Counter
+	refreshDuration prometheus.Histogram
+
+	// lastRefresh is the unix timestamp in nanoseconds of the last successful refresh. It is initialized with the
+	// creation time, so that the age grows if the metadata can never be fetched.
+	lastRefresh atomic.Int64
+}
+
+// NewMetadataMetricsHooks creates the metadata metrics with the given namespace and subsystem and registers them with
+// the given registerer.
+func NewMetadataMetricsHooks(namespace string, subsystem string, registerer prometheus.Registerer) *MetadataMetricsHooks {
+	h := &MetadataMetricsHooks{}
+	h.lastRefresh.Store(time.Now().UnixNano())
+
+	h.refreshes = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      "client_metadata_refreshes_total",
+		Help:      "Number of metadata requests that have been sent to the cluster",
+	})
+	h.refreshErrors = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      "client_metadata_refresh_errors_total",
+		Help:      "Number of metadata requests that failed to be written or whose response failed to be read",
+	})
+	h.refreshDuration = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      "client_metadata_refresh_duration_seconds",
+		Help:      "Time from writing a metadata request until its response has been read",
+		Buckets:   prometheus.ExponentialBuckets(0.001, 2, 15),
+	})
+	age := prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      "client_metadata_age_seconds",
+		Help:      "Time since the metadata has last been refreshed successfully",
+	}, func() float64 {
+		return time.Since(time.Unix(0, h.lastRefresh.Load())).Seconds()
+	})
+	registerer.MustRegister(h.refreshes, h.refreshErrors, h.refreshDuration, age)
+
+	return h
+}
+
+// OnBrokerE2E is called after a request has been written and its response has been read (or an error occurred).
+func (h *MetadataMetricsHooks) OnBrokerE2E(_ kgo.BrokerMetadata, key int16, e2e kgo.BrokerE2E) {
+	if key != metadataRequestKey {
+		return
+	}
+
+	h.refreshes.Inc()
+	if e2e.Err() != nil {
+		h.refreshErrors.Inc()
+		return
+	}
+	h.refreshDuration.Observe(e2e.DurationE2E().Seconds())
+	h.lastRefresh.Store(time.Now().UnixNano())
+}
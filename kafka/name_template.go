@@ -0,0 +1,56 @@
+package kafka
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/template"
+)
+
+// NameTemplateData contains the values that can be referenced in templated names, such as the client id or the
+// end-to-end topic names, e.g. "kminion-{{.ClusterName}}-{{.PodName}}".
+type NameTemplateData struct {
+	// ClusterName is the configured clusterName
+	ClusterName string
+	// Hostname is the hostname reported by the operating system
+	Hostname string
+	// PodName is the value of the POD_NAME environment variable, or the hostname if it is not set
+	PodName string
+}
+
+// NewNameTemplateData returns the template data of this kminion instance for the given cluster name.
+func NewNameTemplateData(clusterName string) (NameTemplateData, error) {
+	hostname, err := os.Hostname()
+	if err != nil {
+		return NameTemplateData{}, fmt.Errorf("failed to get hostname: %w", err)
+	}
+
+	podName := os.Getenv("POD_NAME")
+	if podName == "" {
+		podName = hostname
+	}
+
+	return NameTemplateData{
+		ClusterName: clusterName,
+		Hostname:    hostname,
+		PodName:     podName,
+	}, nil
+}
+
+// RenderNameTemplate renders the given name. Names that do not contain a template action are returned unchanged.
+func RenderNameTemplate(name string, data NameTemplateData) (string, error) {
+	if !strings.Contains(name, "{{") {
+		return name, nil
+	}
+
+	tmpl, err := template.New("name").Option("missingkey=error").Parse(name)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse name template '%v': %w", name, err)
+	}
+
+	var sb strings.Builder
+	if err := tmpl.Execute(&sb, data); err != nil {
+		return "", fmt.Errorf("failed to render name template '%v': %w", name, err)
+	}
+	return sb.String(), nil
+}
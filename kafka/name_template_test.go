@@ -0,0 +1,30 @@
+package kafka
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderNameTemplate(t *testing.T) {
+	data := NameTemplateData{ClusterName: "prod-eu", Hostname: "node-1", PodName: "kminion-0"}
+
+	name, err := RenderNameTemplate("kminion-end-to-end", data)
+	require.NoError(t, err)
+	assert.Equal(t, "kminion-end-to-end", name)
+
+	name, err = RenderNameTemplate("kminion-{{.ClusterName}}-{{.PodName}}", data)
+	require.NoError(t, err)
+	assert.Equal(t, "kminion-prod-eu-kminion-0", name)
+
+	name, err = RenderNameTemplate("kminion-{{.Hostname}}", data)
+	require.NoError(t, err)
+	assert.Equal(t, "kminion-node-1", name)
+
+	_, err = RenderNameTemplate("kminion-{{.Namespace}}", data)
+	assert.Error(t, err, "unknown fields must be rejected")
+
+	_, err = RenderNameTemplate("kminion-{{.ClusterName", data)
+	assert.Error(t, err, "invalid templates must be rejected")
+}
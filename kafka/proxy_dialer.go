@@ -0,0 +1,97 @@
+package kafka
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"golang.org/x/net/proxy"
+)
+
+type dialFunc func(ctx context.Context, network, host string) (net.Conn, error)
+
+// newProxyDialFunc returns a dial function that establishes connections to the brokers through the configured proxy.
+// The connection to the proxy itself is established with the given net dialer.
+func newProxyDialFunc(cfg ProxyConfig, netDialer *net.Dialer) (dialFunc, error) {
+	switch cfg.Type {
+	case ProxyTypeSOCKS5:
+		var auth *proxy.Auth
+		if cfg.Username != "" {
+			auth = &proxy.Auth{User: cfg.Username, Password: cfg.Password}
+		}
+		socksDialer, err := proxy.SOCKS5("tcp", cfg.Address, auth, netDialer)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create socks5 proxy dialer: %w", err)
+		}
+		contextDialer, ok := socksDialer.(proxy.ContextDialer)
+		if !ok {
+			return nil, fmt.Errorf("socks5 proxy dialer does not support contexts")
+		}
+		return contextDialer.DialContext, nil
+	case ProxyTypeHTTP:
+		return newHTTPConnectDialFunc(cfg, netDialer), nil
+	default:
+		return nil, fmt.Errorf("given proxy type '%v' is invalid", cfg.Type)
+	}
+}
+
+// newHTTPConnectDialFunc returns a dial function that tunnels connections through an HTTP proxy using the CONNECT
+// method.
+func newHTTPConnectDialFunc(cfg ProxyConfig, netDialer *net.Dialer) dialFunc {
+	return func(ctx context.Context, network, host string) (net.Conn, error) {
+		conn, err := netDialer.DialContext(ctx, network, cfg.Address)
+		if err != nil {
+			return nil, fmt.Errorf("failed to connect to http proxy: %w", err)
+		}
+
+		// Abort the CONNECT handshake if the context is cancelled
+		if deadline, ok := ctx.Deadline(); ok {
+			conn.SetDeadline(deadline)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodConnect, "http://"+host, nil)
+		if err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("failed to create http proxy connect request: %w", err)
+		}
+		req.Host = host
+		if cfg.Username != "" {
+			credentials := base64.StdEncoding.EncodeToString([]byte(cfg.Username + ":" + cfg.Password))
+			req.Header.Set("Proxy-Authorization", "Basic "+credentials)
+		}
+		if err := req.Write(conn); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("failed to send http proxy connect request: %w", err)
+		}
+
+		reader := bufio.NewReader(conn)
+		res, err := http.ReadResponse(reader, req)
+		if err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("failed to read http proxy connect response: %w", err)
+		}
+		res.Body.Close()
+		if res.StatusCode != http.StatusOK {
+			conn.Close()
+			return nil, fmt.Errorf("http proxy connect request failed with status '%v'", res.Status)
+		}
+		conn.SetDeadline(time.Time{})
+
+		return &bufferedConn{Conn: conn, reader: reader}, nil
+	}
+}
+
+// bufferedConn reads from the buffered reader that has been used to read the proxy's response, so that bytes which
+// have been buffered beyond the response are not lost.
+type bufferedConn struct {
+	net.Conn
+	reader *bufio.Reader
+}
+
+func (c *bufferedConn) Read(b []byte) (int, error) {
+	return c.reader.Read(b)
+}
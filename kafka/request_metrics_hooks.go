@@ -0,0 +1,70 @@
+package kafka
+
+import (
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/twmb/franz-go/pkg/kgo"
+	"github.com/twmb/franz-go/pkg/kmsg"
+)
+
+// RequestMetricsHooks implements the kgo.HookBrokerE2E interface and exports the number of requests, the number of
+// failed requests and the request latencies of a client, by broker and API key (e.g. Produce, Fetch, Metadata).
+type RequestMetricsHooks struct {
+	requests       *prometheus.CounterVec
+	requestErrors  *prometheus.CounterVec
+	requestLatency *prometheus.HistogramVec
+}
+
+// NewRequestMetricsHooks creates the request metrics with the given namespace and subsystem and registers them with
+// the given registerer. The request latencies are exported as the given histogram type (see WithHistogramType).
+func NewRequestMetricsHooks(namespace string, subsystem string, histogramType string, registerer prometheus.Registerer) *RequestMetricsHooks {
+	requests := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      "client_requests_total",
+		Help:      "Number of requests that have been sent to the broker, by API key",
+	}, []string{"broker_id", "api_key"})
+	requestErrors := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      "client_request_errors_total",
+		Help:      "Number of requests to the broker that failed to be written or whose response failed to be read, by API key",
+	}, []string{"broker_id", "api_key"})
+	requestLatency := prometheus.NewHistogramVec(WithHistogramType(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      "client_request_latency_seconds",
+		Help:      "Time from writing a request to the broker until its response has been read, by API key",
+		Buckets:   prometheus.ExponentialBuckets(0.001, 2, 15),
+	}, histogramType), []string{"broker_id", "api_key"})
+	registerer.MustRegister(requests, requestErrors, requestLatency)
+
+	return &RequestMetricsHooks{
+		requests:       requests,
+		requestErrors:  requestErrors,
+		requestLatency: requestLatency,
+	}
+}
+
+// OnBrokerE2E is called after a request has been written and its response has been read (or an error occurred).
+func (h *RequestMetricsHooks) OnBrokerE2E(meta kgo.BrokerMetadata, key int16, e2e kgo.BrokerE2E) {
+	brokerID := brokerIDLabel(meta)
+	apiKey := kmsg.NameForKey(key)
+
+	h.requests.WithLabelValues(brokerID, apiKey).Inc()
+	if e2e.Err() != nil {
+		h.requestErrors.WithLabelValues(brokerID, apiKey).Inc()
+		return
+	}
+	h.requestLatency.WithLabelValues(brokerID, apiKey).Observe(e2e.DurationE2E().Seconds())
+}
+
+// brokerIDLabel returns the broker id as label value. Seed brokers, whose ids are not known yet, are reported as
+// "seed".
+func brokerIDLabel(meta kgo.BrokerMetadata) string {
+	if meta.NodeID < 0 {
+		return "seed"
+	}
+	return strconv.Itoa(int(meta.NodeID))
+}
@@ -0,0 +1,117 @@
+package kafka
+
+import (
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/twmb/franz-go/pkg/kgo"
+)
+
+// Log messages of franz-go from which the SASL metrics are derived
+const (
+	saslLogAuthenticationBegin   = "beginning sasl authentication"
+	saslLogAuthenticationFailed  = "unable to initialize sasl"
+	saslLogSessionLifetime       = "sasl has a limited lifetime"
+	saslLogReauthenticationBegin = "sasl expiry limit reached, reauthenticating"
+	saslLogReauthenticationRetry = "sasl reauth failed, retrying once on new connection"
+)
+
+// SASLMetricsHooks implements the kgo.HookNewClient interface and exports SASL authentications, re-authentications
+// (KIP-368) and the session lifetimes returned by each broker. franz-go exposes these events only via its log
+// messages, hence the hooks observe the client's logger, which must be a KgoZapLogger.
+type SASLMetricsHooks struct {
+	authentications          *prometheus.CounterVec
+	authenticationFailures   *prometheus.CounterVec
+	reauthentications        *prometheus.CounterVec
+	reauthenticationFailures *prometheus.CounterVec
+	sessionLifetime          *prometheus.GaugeVec
+}
+
+// NewSASLMetricsHooks creates the SASL metrics with the given namespace and subsystem and registers them with the
+// given registerer.
+func NewSASLMetricsHooks(namespace string, subsystem string, registerer prometheus.Registerer) *SASLMetricsHooks {
+	authentications := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      "client_sasl_authentications_total",
+		Help:      "Number of SASL authentications with the broker, including re-authentications",
+	}, []string{"broker_id"})
+	authenticationFailures := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      "client_sasl_authentication_failures_total",
+		Help:      "Number of new connections to the broker that failed to authenticate",
+	}, []string{"broker_id"})
+	reauthentications := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      "client_sasl_reauthentications_total",
+		Help:      "Number of re-authentications on existing connections, because the SASL session lifetime has been reached",
+	}, []string{"broker_id"})
+	reauthenticationFailures := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      "client_sasl_reauthentication_failures_total",
+		Help:      "Number of re-authentications that were rejected by the broker, after which the connection has been closed",
+	}, []string{"broker_id"})
+	sessionLifetime := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      "client_sasl_session_lifetime_seconds",
+		Help:      "SASL session lifetime returned by the broker in the last authentication (connections.max.reauth.ms)",
+	}, []string{"broker_id"})
+	registerer.MustRegister(authentications, authenticationFailures, reauthentications, reauthenticationFailures,
+		sessionLifetime)
+
+	return &SASLMetricsHooks{
+		authentications:          authentications,
+		authenticationFailures:   authenticationFailures,
+		reauthentications:        reauthentications,
+		reauthenticationFailures: reauthenticationFailures,
+		sessionLifetime:          sessionLifetime,
+	}
+}
+
+// OnNewClient registers the hooks as observer of the client's log messages.
+func (h *SASLMetricsHooks) OnNewClient(client *kgo.Client) {
+	observeClientLogs(client, h)
+}
+
+func (h *SASLMetricsHooks) observeLog(_ kgo.LogLevel, msg string, keyvals ...interface{}) {
+	switch msg {
+	case saslLogAuthenticationBegin:
+		h.authentications.WithLabelValues(saslLogBrokerID(keyvals)).Inc()
+	case saslLogAuthenticationFailed:
+		h.authenticationFailures.WithLabelValues(saslLogBrokerID(keyvals)).Inc()
+	case saslLogReauthenticationBegin:
+		h.reauthentications.WithLabelValues(saslLogBrokerID(keyvals)).Inc()
+	case saslLogReauthenticationRetry:
+		h.reauthenticationFailures.WithLabelValues(saslLogBrokerID(keyvals)).Inc()
+	case saslLogSessionLifetime:
+		lifetime, ok := logValue(keyvals, "session_lifetime").(time.Duration)
+		if ok {
+			h.sessionLifetime.WithLabelValues(saslLogBrokerID(keyvals)).Set(lifetime.Seconds())
+		}
+	}
+}
+
+// saslLogBrokerID returns the broker id of a log message as label value. franz-go logs seed brokers as "seed_<n>",
+// which are reported as "seed" like in brokerIDLabel.
+func saslLogBrokerID(keyvals []interface{}) string {
+	brokerID, _ := logValue(keyvals, "broker").(string)
+	if strings.HasPrefix(brokerID, "seed") {
+		return "seed"
+	}
+	return brokerID
+}
+
+// logValue returns the value of the given key of a log message's key value pairs, or nil if the key does not exist.
+func logValue(keyvals []interface{}, key string) interface{} {
+	for i := 0; i+1 < len(keyvals); i += 2 {
+		if keyvals[i] == key {
+			return keyvals[i+1]
+		}
+	}
+	return nil
+}
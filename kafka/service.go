@@ -3,6 +3,9 @@ package kafka
 import (
 	"context"
 	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/twmb/franz-go/pkg/kerr"
@@ -15,24 +18,199 @@ import (
 type Service struct {
 	cfg    Config
 	logger *zap.Logger
+
+	// saslCredentials is shared by all clients, so that credentials fetched from Vault are cached across clients
+	saslCredentialsOnce sync.Once
+	saslCredentials     saslCredentialsFunc
+	saslCredentialsErr  error
+
+	// activeSeedBrokersIndex is the index of the seed broker set (see seedBrokerSets) that the last connection test
+	// succeeded with
+	activeSeedBrokersIndex atomic.Int32
+
+	// clients are the running clients created by CreateAndTestClient. They are switched to another set of seed
+	// brokers if the active set fails the health check.
+	clientsMutex sync.Mutex
+	clients      map[*kgo.Client]struct{}
+
+	// testSeedBrokers tests the connectivity to the given seed brokers, it's replaced in tests
+	testSeedBrokers func(ctx context.Context, seedBrokers []string) error
 }
 
 func NewService(cfg Config, logger *zap.Logger) *Service {
-	return &Service{
-		cfg:    cfg,
-		logger: logger.Named("kafka_service"),
+	s := &Service{
+		cfg:     cfg,
+		logger:  logger.Named("kafka_service"),
+		clients: make(map[*kgo.Client]struct{}),
+	}
+	s.testSeedBrokers = s.testSeedBrokersConnection
+	return s
+}
+
+// Start health checks the active set of seed brokers in the background, if fallback brokers are configured.
+func (s *Service) Start(ctx context.Context) {
+	if len(s.cfg.FallbackBrokers) == 0 || s.cfg.FallbackCheckInterval == 0 {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(s.cfg.FallbackCheckInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.checkSeedBrokers(ctx)
+			}
+		}
+	}()
+}
+
+// checkSeedBrokers tests the connectivity to the active set of seed brokers. If it fails, the remaining sets are
+// tested in order and all running clients are switched to the first one that succeeds.
+func (s *Service) checkSeedBrokers(ctx context.Context) {
+	seedBrokerSets := s.seedBrokerSets()
+	activeIndex := int(s.activeSeedBrokersIndex.Load())
+	err := s.testSeedBrokers(ctx, seedBrokerSets[activeIndex])
+	if err == nil {
+		return
+	}
+	s.logger.Warn("health check of the active seed brokers failed, trying the other seed brokers",
+		zap.String("seed_brokers", strings.Join(seedBrokerSets[activeIndex], ",")),
+		zap.Error(err))
+
+	for i := 1; i < len(seedBrokerSets); i++ {
+		index := (activeIndex + i) % len(seedBrokerSets)
+		seedBrokers := seedBrokerSets[index]
+		if err := s.testSeedBrokers(ctx, seedBrokers); err != nil {
+			s.logger.Warn("health check of the seed brokers failed",
+				zap.String("seed_brokers", strings.Join(seedBrokers, ",")),
+				zap.Error(err))
+			continue
+		}
+
+		s.activeSeedBrokersIndex.Store(int32(index))
+		s.switchClientsSeedBrokers(seedBrokers)
+		s.logger.Warn("failed over to another set of seed brokers",
+			zap.String("seed_brokers", strings.Join(seedBrokers, ",")))
+		return
+	}
+	s.logger.Warn("health check of all seed brokers failed, keeping the active seed brokers")
+}
+
+// switchClientsSeedBrokers replaces the seed brokers of all running clients and refreshes their metadata. The
+// metadata is then fetched from the new seed brokers, which advertise the addresses of their listener, so that all
+// further requests are sent via the new listener.
+func (s *Service) switchClientsSeedBrokers(seedBrokers []string) {
+	s.clientsMutex.Lock()
+	defer s.clientsMutex.Unlock()
+
+	for client := range s.clients {
+		if err := client.UpdateSeedBrokers(seedBrokers...); err != nil {
+			s.logger.Warn("failed to update the seed brokers of client", zap.Error(err))
+			continue
+		}
+		client.ForceMetadataRefresh()
+	}
+}
+
+// testSeedBrokersConnection creates a short-lived client with the given seed brokers and tests its connectivity.
+func (s *Service) testSeedBrokersConnection(ctx context.Context, seedBrokers []string) error {
+	client, err := s.CreateClient(s.logger.Named("kgo_client"), []kgo.Opt{kgo.SeedBrokers(seedBrokers...)})
+	if err != nil {
+		return err
 	}
+	defer client.Close()
+
+	return s.testConnection(client, ctx)
 }
 
 // CreateAndTestClient creates a client with the services default settings
+// If the connection can't be established, the fallback seed brokers are tried in order. Once the client is running,
+// it's switched to another set of seed brokers whenever the active set fails the health check (see Start).
 // logger: will be used to log connections, errors, warnings about tls config, ...
 func (s *Service) CreateAndTestClient(ctx context.Context, l *zap.Logger, opts []kgo.Opt) (*kgo.Client, error) {
 	logger := l.Named("kgo_client")
+	seedBrokerSets := s.seedBrokerSets()
+	opts = append(opts[:len(opts):len(opts)], kgo.WithHooks(&seedBrokersClientHooks{svc: s}))
+
+	// Test connection, failing over to the next set of seed brokers if the connection can't be established
+	for {
+		var err error
+		activeIndex := int(s.activeSeedBrokersIndex.Load())
+		for i := range seedBrokerSets {
+			index := (activeIndex + i) % len(seedBrokerSets)
+			seedBrokers := seedBrokerSets[index]
+
+			clientOpts := append([]kgo.Opt{kgo.SeedBrokers(seedBrokers...)}, opts...)
+			client, createErr := s.CreateClient(logger, clientOpts)
+			if createErr != nil {
+				return nil, createErr
+			}
+			err = s.testConnection(client, ctx)
+			if err == nil {
+				if index != activeIndex {
+					logger.Warn("failed over to another set of seed brokers",
+						zap.String("seed_brokers", strings.Join(seedBrokers, ",")))
+					s.activeSeedBrokersIndex.Store(int32(index))
+				}
+				s.clientsMutex.Lock()
+				s.clients[client] = struct{}{}
+				s.clientsMutex.Unlock()
+				return client, nil
+			}
+			client.Close()
+
+			if len(seedBrokerSets) > 1 {
+				logger.Warn("failed to test connectivity to Kafka cluster with seed brokers",
+					zap.String("seed_brokers", strings.Join(seedBrokers, ",")),
+					zap.Error(err))
+			}
+		}
+
+		if !s.cfg.RetryInitConnection {
+			return nil, fmt.Errorf("failed to test connectivity to Kafka cluster %w", err)
+		}
+
+		logger.Warn("failed to test connectivity to Kafka cluster, retrying in 5 seconds", zap.Error(err))
+		time.Sleep(time.Second * 5)
+	}
+}
+
+// seedBrokersClientHooks stops switching the seed brokers of a client created by CreateAndTestClient once it's closed.
+type seedBrokersClientHooks struct {
+	svc *Service
+}
+
+func (h *seedBrokersClientHooks) OnClientClosed(client *kgo.Client) {
+	h.svc.clientsMutex.Lock()
+	defer h.svc.clientsMutex.Unlock()
+	delete(h.svc.clients, client)
+}
+
+// seedBrokerSets returns the seed brokers followed by the fallback seed brokers.
+func (s *Service) seedBrokerSets() [][]string {
+	return append([][]string{s.cfg.Brokers}, s.cfg.FallbackBrokers...)
+}
+
+// CreateClient creates a client with the services default settings, without testing the connectivity.
+// logger: will be used to log connections, errors, warnings about tls config, ...
+func (s *Service) CreateClient(logger *zap.Logger, opts []kgo.Opt) (*kgo.Client, error) {
 	// Config with default options
-	kgoOpts, err := NewKgoConfig(s.cfg, logger)
+	s.saslCredentialsOnce.Do(func() {
+		s.saslCredentials, s.saslCredentialsErr = newSASLCredentialsFunc(s.cfg.SASL)
+	})
+	if s.saslCredentialsErr != nil {
+		return nil, s.saslCredentialsErr
+	}
+	kgoOpts, err := newKgoConfig(s.cfg, logger, s.saslCredentials)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create a valid kafka Client config: %w", err)
 	}
+	// Use the seed brokers that the connection could be established with
+	kgoOpts = append(kgoOpts, kgo.SeedBrokers(s.Brokers()...))
 	// Append user (the service calling this method) provided options
 	kgoOpts = append(kgoOpts, opts...)
 
@@ -42,27 +220,23 @@ func (s *Service) CreateAndTestClient(ctx context.Context, l *zap.Logger, opts [
 		return nil, fmt.Errorf("failed to create kafka Client: %w", err)
 	}
 
-	// Test connection
-	for {
-		err = s.testConnection(client, ctx)
-		if err == nil {
-			break
-		}
-
-		if !s.cfg.RetryInitConnection {
-			return nil, fmt.Errorf("failed to test connectivity to Kafka cluster %w", err)
-		}
+	return client, nil
+}
 
-		logger.Warn("failed to test connectivity to Kafka cluster, retrying in 5 seconds", zap.Error(err))
-		time.Sleep(time.Second * 5)
-	}
+// RequestMetricsEnabled returns whether the clients shall export request metrics by broker and API key.
+func (s *Service) RequestMetricsEnabled() bool {
+	return s.cfg.RequestMetrics
+}
 
-	return client, nil
+// RequestMetricsHistogramType returns the histogram type (classic, native or both) of the request latencies.
+func (s *Service) RequestMetricsHistogramType() string {
+	return s.cfg.RequestMetricsHistogramType
 }
 
-// Brokers returns list of brokers this service is connecting to
+// Brokers returns list of brokers this service is connecting to. After a failover these are the fallback brokers
+// that the connection could be established with.
 func (s *Service) Brokers() []string {
-	return s.cfg.Brokers
+	return s.seedBrokerSets()[s.activeSeedBrokersIndex.Load()]
 }
 
 // testConnection tries to fetch Broker metadata and prints some information if connection succeeds. An error will be
@@ -0,0 +1,79 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twmb/franz-go/pkg/kgo"
+	"go.uber.org/zap"
+)
+
+func TestCheckSeedBrokers(t *testing.T) {
+	// The client of the fallback listener dials it after the failover
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+	dialed := make(chan struct{}, 1)
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			conn.Close()
+			select {
+			case dialed <- struct{}{}:
+			default:
+			}
+		}
+	}()
+
+	cfg := Config{}
+	cfg.SetDefaults()
+	cfg.Brokers = []string{"127.0.0.1:1"}
+	cfg.FallbackBrokers = [][]string{{"127.0.0.1:2"}, {listener.Addr().String()}}
+	svc := NewService(cfg, zap.NewNop())
+	healthy := map[string]bool{listener.Addr().String(): true}
+	svc.testSeedBrokers = func(_ context.Context, seedBrokers []string) error {
+		if !healthy[seedBrokers[0]] {
+			return errors.New("unreachable")
+		}
+		return nil
+	}
+
+	client, err := kgo.NewClient(kgo.SeedBrokers(cfg.Brokers...), kgo.WithHooks(&seedBrokersClientHooks{svc: svc}))
+	require.NoError(t, err)
+	svc.clients[client] = struct{}{}
+	t.Cleanup(client.Close)
+
+	// The healthy active set is kept
+	healthy[cfg.Brokers[0]] = true
+	svc.checkSeedBrokers(context.Background())
+	assert.Equal(t, cfg.Brokers, svc.Brokers())
+
+	// The first working fallback set is used once the active set fails
+	healthy[cfg.Brokers[0]] = false
+	svc.checkSeedBrokers(context.Background())
+	assert.Equal(t, cfg.FallbackBrokers[1], svc.Brokers())
+	select {
+	case <-dialed:
+	case <-time.After(10 * time.Second):
+		t.Fatal("the running client has not been switched to the fallback brokers")
+	}
+
+	// The active set is kept if all sets fail
+	healthy[listener.Addr().String()] = false
+	svc.checkSeedBrokers(context.Background())
+	assert.Equal(t, cfg.FallbackBrokers[1], svc.Brokers())
+
+	// Closed clients are no longer switched
+	client.Close()
+	svc.clientsMutex.Lock()
+	assert.Empty(t, svc.clients)
+	svc.clientsMutex.Unlock()
+}
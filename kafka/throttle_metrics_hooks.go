@@ -0,0 +1,47 @@
+package kafka
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/twmb/franz-go/pkg/kgo"
+)
+
+// ThrottleMetricsHooks implements the kgo.HookBrokerThrottle interface and exports how often and how long a client has
+// been throttled by each broker. Brokers throttle clients that exceed their quotas, which also skews all latencies
+// measured by that client.
+type ThrottleMetricsHooks struct {
+	throttledResponses *prometheus.CounterVec
+	throttleDuration   *prometheus.HistogramVec
+}
+
+// NewThrottleMetricsHooks creates the throttle metrics with the given namespace and subsystem and registers them with
+// the given registerer.
+func NewThrottleMetricsHooks(namespace string, subsystem string, registerer prometheus.Registerer) *ThrottleMetricsHooks {
+	throttledResponses := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      "client_throttled_responses_total",
+		Help:      "Number of responses from the broker that reported a throttle time, usually because a quota has been exceeded",
+	}, []string{"broker_id"})
+	throttleDuration := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      "client_throttle_duration_seconds",
+		Help:      "Throttle time reported by the broker in throttled responses",
+		Buckets:   prometheus.ExponentialBuckets(0.001, 2, 16),
+	}, []string{"broker_id"})
+	registerer.MustRegister(throttledResponses, throttleDuration)
+
+	return &ThrottleMetricsHooks{
+		throttledResponses: throttledResponses,
+		throttleDuration:   throttleDuration,
+	}
+}
+
+// OnBrokerThrottle is called when a response reports a throttle time greater than zero.
+func (h *ThrottleMetricsHooks) OnBrokerThrottle(meta kgo.BrokerMetadata, throttleInterval time.Duration, _ bool) {
+	brokerID := brokerIDLabel(meta)
+	h.throttledResponses.WithLabelValues(brokerID).Inc()
+	h.throttleDuration.WithLabelValues(brokerID).Observe(throttleInterval.Seconds())
+}
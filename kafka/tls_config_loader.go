@@ -0,0 +1,233 @@
+package kafka
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"slices"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// tlsConfigLoader provides the TLS config for new connections. If the CA, certificate or key are read from files, the
+// files are checked for modifications before each dial and the TLS config is rebuilt if any of them changed. This
+// way rotated certificates (e.g. by cert-manager) are picked up by new connections without restarting kminion.
+// Established connections are not affected, because certificates are only verified during the handshake.
+// Material that is read from AWS secrets is read again after the configured refresh interval.
+type tlsConfigLoader struct {
+	cfg    TLSConfig
+	logger *zap.Logger
+
+	mutex    sync.Mutex
+	tlsCfg   *tls.Config
+	modTimes map[string]time.Time
+
+	awsSecrets          *awsSecretsResolver
+	awsSecretValues     []string // ca, cert and key
+	awsSecretsRefreshAt time.Time
+}
+
+func newTLSConfigLoader(cfg TLSConfig, logger *zap.Logger) (*tlsConfigLoader, error) {
+	l := &tlsConfigLoader{
+		cfg:    cfg,
+		logger: logger,
+	}
+
+	if cfg.AWSSecrets.Enabled {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		resolver, err := newAWSSecretsResolver(ctx, cfg.AWSSecrets.Region)
+		if err != nil {
+			return nil, err
+		}
+		values, err := resolver.resolveAll(ctx, cfg.AWSSecrets.Ca, cfg.AWSSecrets.Cert, cfg.AWSSecrets.Key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read TLS material from aws secrets: %w", err)
+		}
+		l.awsSecrets = resolver
+		l.awsSecretValues = values
+		l.awsSecretsRefreshAt = time.Now().Add(cfg.AWSSecrets.RefreshInterval)
+	}
+
+	modTimes := l.fileModTimes()
+	tlsCfg, err := buildTLSConfig(l.effectiveConfig(), logger)
+	if err != nil {
+		return nil, err
+	}
+	l.tlsCfg = tlsCfg
+	l.modTimes = modTimes
+
+	return l, nil
+}
+
+// Get returns the current TLS config, which is rebuilt first if any of the configured files have been modified. If
+// the rebuild fails (e.g. because the certificate has been written but the key not yet), the previous config is
+// returned and the rebuild is retried on the next call.
+func (l *tlsConfigLoader) Get() *tls.Config {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	awsSecretsChanged := l.refreshAWSSecrets()
+	modTimes := l.fileModTimes()
+	if !awsSecretsChanged && !l.hasChanged(modTimes) {
+		return l.tlsCfg
+	}
+
+	tlsCfg, err := buildTLSConfig(l.effectiveConfig(), l.logger)
+	if err != nil {
+		l.logger.Warn("failed to reload modified TLS material, continuing to use the previous TLS config", zap.Error(err))
+		return l.tlsCfg
+	}
+	l.logger.Info("reloaded TLS config because the TLS material has been modified")
+	l.tlsCfg = tlsCfg
+	l.modTimes = modTimes
+
+	return l.tlsCfg
+}
+
+// refreshAWSSecrets reads the TLS material from AWS again if the refresh interval has passed. It returns whether the
+// material has changed.
+func (l *tlsConfigLoader) refreshAWSSecrets() bool {
+	if l.awsSecrets == nil || time.Now().Before(l.awsSecretsRefreshAt) {
+		return false
+	}
+	l.awsSecretsRefreshAt = time.Now().Add(l.cfg.AWSSecrets.RefreshInterval)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	values, err := l.awsSecrets.resolveAll(ctx, l.cfg.AWSSecrets.Ca, l.cfg.AWSSecrets.Cert, l.cfg.AWSSecrets.Key)
+	if err != nil {
+		l.logger.Warn("failed to refresh TLS material from aws secrets, continuing to use the previous TLS config", zap.Error(err))
+		return false
+	}
+	if slices.Equal(values, l.awsSecretValues) {
+		return false
+	}
+	l.awsSecretValues = values
+	return true
+}
+
+// effectiveConfig returns the TLS config with the material that has been read from AWS secrets.
+func (l *tlsConfigLoader) effectiveConfig() TLSConfig {
+	cfg := l.cfg
+	if l.awsSecrets == nil {
+		return cfg
+	}
+	if cfg.AWSSecrets.Ca != "" {
+		cfg.Ca = l.awsSecretValues[0]
+	}
+	if cfg.AWSSecrets.Cert != "" {
+		cfg.Cert = l.awsSecretValues[1]
+	}
+	if cfg.AWSSecrets.Key != "" {
+		cfg.Key = l.awsSecretValues[2]
+	}
+	return cfg
+}
+
+// fileModTimes returns the modification times of all configured TLS files. Files that can't be stat'ed are omitted.
+func (l *tlsConfigLoader) fileModTimes() map[string]time.Time {
+	modTimes := make(map[string]time.Time)
+	for _, path := range []string{l.cfg.CaFilepath, l.cfg.CertFilepath, l.cfg.KeyFilepath} {
+		if path == "" {
+			continue
+		}
+		info, err := os.Stat(path)
+		if err != nil {
+			continue
+		}
+		modTimes[path] = info.ModTime()
+	}
+	return modTimes
+}
+
+func (l *tlsConfigLoader) hasChanged(modTimes map[string]time.Time) bool {
+	if len(modTimes) != len(l.modTimes) {
+		return true
+	}
+	for path, modTime := range modTimes {
+		if !modTime.Equal(l.modTimes[path]) {
+			return true
+		}
+	}
+	return false
+}
+
+// buildTLSConfig creates the TLS config from the given TLS settings, reading all configured files.
+// logger is only used to print warnings about TLS.
+func buildTLSConfig(cfg TLSConfig, logger *zap.Logger) (*tls.Config, error) {
+	// Root CA
+	var caCertPool *x509.CertPool
+	if cfg.CaFilepath != "" || len(cfg.Ca) > 0 {
+		ca := []byte(cfg.Ca)
+		if cfg.CaFilepath != "" {
+			caBytes, err := ioutil.ReadFile(cfg.CaFilepath)
+			if err != nil {
+				return nil, fmt.Errorf("failed to load ca cert: %w", err)
+			}
+			ca = caBytes
+		}
+		caCertPool = x509.NewCertPool()
+		isSuccessful := caCertPool.AppendCertsFromPEM(ca)
+		if !isSuccessful {
+			logger.Warn("failed to append ca file to cert pool, is this a valid PEM format?")
+		}
+	}
+
+	// If configured load TLS cert & key - Mutual TLS
+	var certificates []tls.Certificate
+	hasCertFile := cfg.CertFilepath != "" || len(cfg.Cert) > 0
+	hasKeyFile := cfg.KeyFilepath != "" || len(cfg.Key) > 0
+	if hasCertFile || hasKeyFile {
+		cert := []byte(cfg.Cert)
+		privateKey := []byte(cfg.Key)
+		// 1. Read certificates
+		if cfg.CertFilepath != "" {
+			certBytes, err := ioutil.ReadFile(cfg.CertFilepath)
+			if err != nil {
+				return nil, fmt.Errorf("failed to TLS certificate: %w", err)
+			}
+			cert = certBytes
+		}
+
+		if cfg.KeyFilepath != "" {
+			keyBytes, err := ioutil.ReadFile(cfg.KeyFilepath)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read TLS key: %w", err)
+			}
+			privateKey = keyBytes
+		}
+
+		// 2. Check if private key needs to be decrypted. Decrypt it if passphrase is given, otherwise return error
+		pemBlock, _ := pem.Decode(privateKey)
+		if pemBlock == nil {
+			return nil, fmt.Errorf("no valid private key found")
+		}
+
+		if x509.IsEncryptedPEMBlock(pemBlock) {
+			decryptedKey, err := x509.DecryptPEMBlock(pemBlock, []byte(cfg.Passphrase))
+			if err != nil {
+				return nil, fmt.Errorf("private key is encrypted, but could not decrypt it: %s", err)
+			}
+			// If private key was encrypted we can overwrite the original contents now with the decrypted version
+			privateKey = pem.EncodeToMemory(&pem.Block{Type: pemBlock.Type, Bytes: decryptedKey})
+		}
+		tlsCert, err := tls.X509KeyPair(cert, privateKey)
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse pem: %s", err)
+		}
+		certificates = []tls.Certificate{tlsCert}
+	}
+
+	return &tls.Config{
+		InsecureSkipVerify: cfg.InsecureSkipTLSVerify,
+		Certificates:       certificates,
+		RootCAs:            caCertPool,
+	}, nil
+}
@@ -0,0 +1,76 @@
+package kafka
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"time"
+)
+
+// TLSConn is a TLS connection that remembers how long its handshake took. Connections established with TLS enabled
+// are returned as TLSConn, so that client hooks can report the handshake latency separately from the TCP dial.
+type TLSConn struct {
+	*tls.Conn
+	handshakeDuration time.Duration
+}
+
+// HandshakeDuration returns the time it took to complete the TLS handshake.
+func (c *TLSConn) HandshakeDuration() time.Duration {
+	return c.handshakeDuration
+}
+
+// PeerCertificateExpiry returns the expiry of the leaf certificate presented by the broker. False is returned if the
+// broker didn't present a certificate.
+func (c *TLSConn) PeerCertificateExpiry() (time.Time, bool) {
+	peerCertificates := c.ConnectionState().PeerCertificates
+	if len(peerCertificates) == 0 {
+		return time.Time{}, false
+	}
+	return peerCertificates[0].NotAfter, true
+}
+
+// TLSHandshakeError is returned by the dialer if the TCP connection could be established, but the TLS handshake
+// failed.
+type TLSHandshakeError struct {
+	Err error
+}
+
+func (e *TLSHandshakeError) Error() string {
+	return fmt.Sprintf("tls handshake failed: %v", e.Err)
+}
+
+func (e *TLSHandshakeError) Unwrap() error {
+	return e.Err
+}
+
+// newTLSDialFunc returns a dial function that establishes the TCP connection and performs the TLS handshake in two
+// separate steps, so that both can be timed individually. getTLSConfig is called for each dial, so that reloaded TLS
+// configs are used for new connections.
+func newTLSDialFunc(dial dialFunc, getTLSConfig func() *tls.Config) dialFunc {
+	return func(ctx context.Context, network, host string) (net.Conn, error) {
+		conn, err := dial(ctx, network, host)
+		if err != nil {
+			return nil, err
+		}
+
+		cfg := getTLSConfig().Clone()
+		if cfg.ServerName == "" {
+			// Same as tls.Dialer, the server name is derived from the address we dial
+			hostname, _, err := net.SplitHostPort(host)
+			if err != nil {
+				hostname = host
+			}
+			cfg.ServerName = hostname
+		}
+
+		handshakeStart := time.Now()
+		tlsConn := tls.Client(conn, cfg)
+		if err := tlsConn.HandshakeContext(ctx); err != nil {
+			conn.Close()
+			return nil, &TLSHandshakeError{Err: err}
+		}
+
+		return &TLSConn{Conn: tlsConn, handshakeDuration: time.Since(handshakeStart)}, nil
+	}
+}
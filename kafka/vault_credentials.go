@@ -0,0 +1,197 @@
+package kafka
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// vaultCredentialsProvider reads the SASL username and password from a Vault secret. The credentials are cached for
+// the configured refresh interval, so that Vault is not called for every connection that is authenticated.
+type vaultCredentialsProvider struct {
+	cfg        SASLVaultConfig
+	httpClient *http.Client
+
+	// defaultUsername is used if the secret doesn't contain the username key
+	defaultUsername string
+
+	mutex          sync.Mutex
+	token          string
+	tokenExpiresAt time.Time // zero if the token doesn't expire
+	username       string
+	password       string
+	refreshAt      time.Time
+}
+
+func newVaultCredentialsProvider(cfg SASLVaultConfig, defaultUsername string) (*vaultCredentialsProvider, error) {
+	httpClient := &http.Client{Timeout: 10 * time.Second}
+	if cfg.CaFilepath != "" {
+		ca, err := ioutil.ReadFile(cfg.CaFilepath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read vault ca cert: %w", err)
+		}
+		caCertPool := x509.NewCertPool()
+		if !caCertPool.AppendCertsFromPEM(ca) {
+			return nil, fmt.Errorf("failed to append vault ca cert to cert pool, is this a valid PEM format?")
+		}
+		httpClient.Transport = &http.Transport{TLSClientConfig: &tls.Config{RootCAs: caCertPool}}
+	}
+
+	token := cfg.Token
+	if cfg.AuthMethod == VaultAuthMethodToken && token == "" {
+		token = os.Getenv("VAULT_TOKEN")
+	}
+
+	return &vaultCredentialsProvider{
+		cfg:             cfg,
+		httpClient:      httpClient,
+		defaultUsername: defaultUsername,
+		token:           token,
+	}, nil
+}
+
+// Credentials returns the cached username and password or reads them from Vault if they are due for a refresh. If
+// the refresh fails, the previously read credentials are returned as long as there are any and the refresh is retried
+// later (see refreshRetryAt).
+func (p *vaultCredentialsProvider) Credentials(ctx context.Context) (string, string, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.password != "" && time.Now().Before(p.refreshAt) {
+		return p.username, p.password, nil
+	}
+
+	username, password, err := p.readCredentials(ctx)
+	if err != nil {
+		if p.password != "" {
+			p.refreshAt = refreshRetryAt(p.cfg.RefreshInterval)
+			return p.username, p.password, nil
+		}
+		return "", "", err
+	}
+	p.username = username
+	p.password = password
+	p.refreshAt = time.Now().Add(p.cfg.RefreshInterval)
+
+	return username, password, nil
+}
+
+func (p *vaultCredentialsProvider) readCredentials(ctx context.Context) (string, string, error) {
+	if p.cfg.AuthMethod == VaultAuthMethodKubernetes {
+		tokenExpired := !p.tokenExpiresAt.IsZero() && time.Now().After(p.tokenExpiresAt)
+		if p.token == "" || tokenExpired {
+			err := p.loginKubernetes(ctx)
+			if err != nil {
+				return "", "", err
+			}
+		}
+	}
+
+	var res struct {
+		Data map[string]interface{} `json:"data"`
+	}
+	err := p.request(ctx, http.MethodGet, p.cfg.SecretPath, nil, &res)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to read vault secret: %w", err)
+	}
+
+	// Secrets of the KV v2 engine are nested in another data object
+	data := res.Data
+	if nestedData, ok := data["data"].(map[string]interface{}); ok {
+		data = nestedData
+	}
+	username, _ := data[p.cfg.UsernameKey].(string)
+	if username == "" {
+		username = p.defaultUsername
+	}
+	password, ok := data[p.cfg.PasswordKey].(string)
+	if !ok || password == "" {
+		return "", "", fmt.Errorf("vault secret does not contain the password key '%v'", p.cfg.PasswordKey)
+	}
+
+	return username, password, nil
+}
+
+// loginKubernetes logs in with the service account token. The Vault token is considered expired once 80% of its
+// lease duration have passed.
+func (p *vaultCredentialsProvider) loginKubernetes(ctx context.Context) error {
+	jwt, err := ioutil.ReadFile(p.cfg.KubernetesTokenFilepath)
+	if err != nil {
+		return fmt.Errorf("failed to read kubernetes service account token: %w", err)
+	}
+
+	body := map[string]string{
+		"role": p.cfg.KubernetesRole,
+		"jwt":  strings.TrimSpace(string(jwt)),
+	}
+	var res struct {
+		Auth struct {
+			ClientToken   string `json:"client_token"`
+			LeaseDuration int    `json:"lease_duration"`
+		} `json:"auth"`
+	}
+	loginPath := "auth/" + strings.Trim(p.cfg.KubernetesMountPath, "/") + "/login"
+	p.token = ""
+	err = p.request(ctx, http.MethodPost, loginPath, body, &res)
+	if err != nil {
+		return fmt.Errorf("failed to login to vault with kubernetes auth: %w", err)
+	}
+	if res.Auth.ClientToken == "" {
+		return fmt.Errorf("vault kubernetes login response does not contain a client token")
+	}
+
+	p.token = res.Auth.ClientToken
+	p.tokenExpiresAt = time.Time{}
+	if res.Auth.LeaseDuration > 0 {
+		leaseDuration := time.Duration(res.Auth.LeaseDuration) * time.Second
+		p.tokenExpiresAt = time.Now().Add(leaseDuration * 8 / 10)
+	}
+
+	return nil
+}
+
+// request sends a request to the given path of the Vault HTTP API and decodes the JSON response into res.
+func (p *vaultCredentialsProvider) request(ctx context.Context, method string, path string, body interface{}, res interface{}) error {
+	var reqBody bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&reqBody).Encode(body); err != nil {
+			return fmt.Errorf("failed to encode request body: %w", err)
+		}
+	}
+
+	url := strings.TrimRight(p.cfg.Address, "/") + "/v1/" + strings.TrimLeft(path, "/")
+	req, err := http.NewRequestWithContext(ctx, method, url, &reqBody)
+	if err != nil {
+		return fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+	if p.token != "" {
+		req.Header.Set("X-Vault-Token", p.token)
+	}
+	if p.cfg.Namespace != "" {
+		req.Header.Set("X-Vault-Namespace", p.cfg.Namespace)
+	}
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("HTTP request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("vault request failed with status code %d", resp.StatusCode)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(res); err != nil {
+		return fmt.Errorf("failed to parse vault response: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,52 @@
+package kafka
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVaultCredentials(t *testing.T) {
+	var requests atomic.Int32
+	var failing atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if failing.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"data": {"data": {"password": "secret"}}}`))
+	}))
+	t.Cleanup(server.Close)
+
+	cfg := SASLVaultConfig{}
+	cfg.SetDefaults()
+	cfg.Address = server.URL
+	cfg.Token = "token"
+	cfg.SecretPath = "secret/data/kminion"
+	cfg.RefreshInterval = 100 * time.Millisecond
+	provider, err := newVaultCredentialsProvider(cfg, "kminion")
+	require.NoError(t, err)
+
+	username, password, err := provider.Credentials(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "kminion", username, "the username must fall back to sasl.username")
+	assert.Equal(t, "secret", password)
+
+	// Once the refresh fails, the previous credentials are used until the refresh is retried
+	failing.Store(true)
+	time.Sleep(2 * cfg.RefreshInterval)
+	for i := 0; i < 3; i++ {
+		username, password, err = provider.Credentials(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "kminion", username)
+		assert.Equal(t, "secret", password)
+	}
+	assert.Equal(t, int32(2), requests.Load(), "a failed refresh must not be retried upon each authentication")
+}
@@ -59,6 +59,7 @@ func main() {
 
 	// Create kafka service
 	kafkaSvc := kafka.NewService(cfg.Kafka, logger)
+	kafkaSvc.Start(ctx)
 
 	// Create minion service
 	// Prometheus exporter only talks to the minion service which
@@ -73,21 +74,30 @@ func main() {
 		logger.Fatal("failed to start minion service", zap.Error(err))
 	}
 
-	// Create end to end testing service
+	// Create end to end testing services, one for each configured topic
+	var e2eServices []*e2e.Service
 	if cfg.Minion.EndToEnd.Enabled {
-		e2eService, err := e2e.NewService(
-			ctx,
-			cfg.Minion.EndToEnd,
-			logger,
-			kafkaSvc,
-			wrappedRegisterer,
-		)
-		if err != nil {
-			logger.Fatal("failed to create end-to-end monitoring service: %w", zap.Error(err))
-		}
-
-		if err = e2eService.Start(ctx); err != nil {
-			logger.Fatal("failed to start end-to-end monitoring service", zap.Error(err))
+		for _, topicCfg := range cfg.Minion.EndToEnd.TopicConfigs() {
+			e2eCfg := cfg.Minion.EndToEnd
+			e2eCfg.TopicManagement = topicCfg
+			if e2eCfg.Consumer.Rack == "" {
+				e2eCfg.Consumer.Rack = cfg.Kafka.RackID
+			}
+			e2eService, err := e2e.NewService(
+				ctx,
+				e2eCfg,
+				logger.With(zap.String("e2e_topic", topicCfg.Name)),
+				kafkaSvc,
+				promclient.WrapRegistererWith(promclient.Labels{"topic": topicCfg.Name}, wrappedRegisterer),
+			)
+			if err != nil {
+				logger.Fatal("failed to create end-to-end monitoring service: %w", zap.Error(err))
+			}
+
+			if err = e2eService.Start(ctx); err != nil {
+				logger.Fatal("failed to start end-to-end monitoring service", zap.Error(err))
+			}
+			e2eServices = append(e2eServices, e2eService)
 		}
 	}
 
@@ -104,11 +114,21 @@ func main() {
 			promclient.DefaultRegisterer,
 			promhttp.HandlerFor(
 				promclient.DefaultGatherer,
-				promhttp.HandlerOpts{},
+				promhttp.HandlerOpts{EnableOpenMetrics: cfg.Exporter.EnableOpenMetrics},
 			),
 		),
 	)
 	http.Handle("/ready", minionSvc.HandleIsReady())
+	if cfg.Minion.ConsumerGroups.Enabled && cfg.Minion.ConsumerGroups.DeleteAbandonedEndpoint {
+		http.Handle("/admin/consumer-groups/delete-abandoned", minionSvc.HandleDeleteAbandonedGroups())
+	}
+	if cfg.Minion.ACLs.Enabled && cfg.Minion.ACLs.ListingEndpoint {
+		http.Handle("/api/acls", minionSvc.HandleACLs())
+	}
+	if len(e2eServices) > 0 && cfg.Minion.EndToEnd.PauseEndpoint {
+		http.Handle("/admin/e2e/pause", e2e.HandlePause(e2eServices))
+		http.Handle("/admin/e2e/resume", e2e.HandleResume(e2eServices))
+	}
 
 	// Start HTTP server
 	address := net.JoinHostPort(cfg.Exporter.Host, strconv.Itoa(cfg.Exporter.Port))
@@ -0,0 +1,155 @@
+package minion
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/twmb/franz-go/pkg/kerr"
+	"github.com/twmb/franz-go/pkg/kmsg"
+	"go.uber.org/zap"
+)
+
+// AbandonedGroup is a consumer group without any members that still has committed offsets.
+type AbandonedGroup struct {
+	GroupID string
+
+	// NewestCommit is the timestamp of the group's most recent offset commit. It is only known in the offsetsTopic
+	// scrape mode and zero otherwise.
+	NewestCommit time.Time
+}
+
+// GetAbandonedGroups returns all empty groups of the given described groups that still have committed offsets.
+func (s *Service) GetAbandonedGroups(ctx context.Context, groups []DescribeConsumerGroupsResponse) ([]AbandonedGroup, error) {
+	var emptyGroupIDs []string
+	for _, shard := range groups {
+		for _, group := range shard.Groups.Groups {
+			if kerr.ErrorForCode(group.ErrorCode) == nil && group.State == "Empty" {
+				emptyGroupIDs = append(emptyGroupIDs, group.Group)
+			}
+		}
+	}
+
+	abandonedGroups := make([]AbandonedGroup, 0)
+	if s.Cfg.ConsumerGroups.ScrapeMode == ConsumerGroupScrapeModeOffsetsTopic {
+		offsets := s.storage.getGroupOffsets()
+		for _, groupID := range emptyGroupIDs {
+			newestCommit := int64(0)
+			for _, topic := range offsets[groupID] {
+				for _, partition := range topic {
+					if partition.Value.CommitTimestamp > newestCommit {
+						newestCommit = partition.Value.CommitTimestamp
+					}
+				}
+			}
+			if newestCommit == 0 {
+				continue
+			}
+			abandonedGroups = append(abandonedGroups, AbandonedGroup{
+				GroupID:      groupID,
+				NewestCommit: time.UnixMilli(newestCommit),
+			})
+		}
+		return abandonedGroups, nil
+	}
+
+	// The Admin API doesn't return the commit timestamps, hence we can only check whether there are any offsets
+	offsets, err := s.listConsumerGroupOffsetsBulk(ctx, emptyGroupIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list offsets of empty groups: %w", err)
+	}
+	for groupID, res := range offsets {
+		hasOffsets := false
+		for _, topic := range res.Topics {
+			for _, partition := range topic.Partitions {
+				if partition.ErrorCode == 0 && partition.Offset >= 0 {
+					hasOffsets = true
+				}
+			}
+		}
+		if hasOffsets {
+			abandonedGroups = append(abandonedGroups, AbandonedGroup{GroupID: groupID})
+		}
+	}
+
+	return abandonedGroups, nil
+}
+
+// HandleDeleteAbandonedGroups deletes all allowed abandoned groups whose newest offset commit is older than the
+// duration given in the minAge query parameter. If the dryRun query parameter is set to true, the groups are only
+// listed. Only POST requests are accepted.
+func (s *Service) HandleDeleteAbandonedGroups() http.HandlerFunc {
+	type deletedGroup struct {
+		GroupID      string    `json:"groupId"`
+		NewestCommit time.Time `json:"newestCommit"`
+		Error        string    `json:"error,omitempty"`
+	}
+	type response struct {
+		DryRun bool           `json:"dryRun"`
+		Groups []deletedGroup `json:"groups"`
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		minAge, err := time.ParseDuration(r.URL.Query().Get("minAge"))
+		if err != nil || minAge <= 0 {
+			http.Error(w, "query parameter minAge must be a positive duration, e.g. 720h", http.StatusBadRequest)
+			return
+		}
+		dryRun := r.URL.Query().Get("dryRun") == "true"
+
+		ctx, cancel := context.WithTimeout(r.Context(), 30*time.Second)
+		defer cancel()
+
+		groups, err := s.DescribeConsumerGroups(ctx)
+		if err != nil {
+			s.logger.Warn("failed to describe consumer groups", zap.Error(err))
+			http.Error(w, "failed to describe consumer groups", http.StatusInternalServerError)
+			return
+		}
+		abandonedGroups, err := s.GetAbandonedGroups(ctx, groups)
+		if err != nil {
+			s.logger.Warn("failed to get abandoned consumer groups", zap.Error(err))
+			http.Error(w, "failed to get abandoned consumer groups", http.StatusInternalServerError)
+			return
+		}
+
+		res := response{DryRun: dryRun, Groups: make([]deletedGroup, 0)}
+		req := kmsg.NewDeleteGroupsRequest()
+		for _, group := range abandonedGroups {
+			if !s.IsGroupAllowed(group.GroupID) || time.Since(group.NewestCommit) < minAge {
+				continue
+			}
+			res.Groups = append(res.Groups, deletedGroup{GroupID: group.GroupID, NewestCommit: group.NewestCommit})
+			req.Groups = append(req.Groups, group.GroupID)
+		}
+
+		if !dryRun && len(req.Groups) > 0 {
+			deleteRes, err := req.RequestWith(ctx, s.client)
+			if err != nil {
+				s.logger.Warn("failed to delete abandoned consumer groups", zap.Error(err))
+				http.Error(w, "failed to delete abandoned consumer groups", http.StatusInternalServerError)
+				return
+			}
+			groupErrors := make(map[string]string)
+			for _, group := range deleteRes.Groups {
+				if err := kerr.ErrorForCode(group.ErrorCode); err != nil {
+					groupErrors[group.Group] = err.Error()
+				}
+			}
+			for i := range res.Groups {
+				res.Groups[i].Error = groupErrors[res.Groups[i].GroupID]
+			}
+			s.logger.Info("deleted abandoned consumer groups", zap.Int("count", len(req.Groups)-len(groupErrors)))
+		}
+
+		resJson, _ := json.Marshal(res)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(resJson)
+	}
+}
@@ -0,0 +1,53 @@
+package minion
+
+import (
+	"context"
+	"sync"
+
+	"github.com/twmb/franz-go/pkg/kerr"
+	"github.com/twmb/franz-go/pkg/kgo"
+	"github.com/twmb/franz-go/pkg/kmsg"
+)
+
+type APIVersionsResponseShard struct {
+	Err      error
+	Broker   kgo.BrokerMetadata
+	Versions *kmsg.ApiVersionsResponse
+}
+
+// DescribeBrokerAPIVersions sends an ApiVersions request to each broker, so that brokers which still run an older
+// Kafka version (e.g. during a rolling upgrade) can be identified.
+func (s *Service) DescribeBrokerAPIVersions(ctx context.Context) ([]APIVersionsResponseShard, error) {
+	metadata, err := s.GetMetadataCached(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]APIVersionsResponseShard, len(metadata.Brokers))
+	wg := sync.WaitGroup{}
+	for i, broker := range metadata.Brokers {
+		res[i].Broker = kgo.BrokerMetadata{
+			NodeID: broker.NodeID,
+			Host:   broker.Host,
+			Port:   broker.Port,
+			Rack:   broker.Rack,
+		}
+
+		wg.Add(1)
+		go func(i int, nodeID int32) {
+			defer wg.Done()
+			req := kmsg.NewApiVersionsRequest()
+			req.ClientSoftwareName = "kminion"
+			req.ClientSoftwareVersion = "v2"
+			versions, err := req.RequestWith(ctx, s.client.Broker(int(nodeID)))
+			if err == nil {
+				err = kerr.ErrorForCode(versions.ErrorCode)
+			}
+			res[i].Versions = versions
+			res[i].Err = err
+		}(i, broker.NodeID)
+	}
+	wg.Wait()
+
+	return res, nil
+}
@@ -0,0 +1,253 @@
+package minion
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/twmb/franz-go/pkg/kerr"
+	"github.com/twmb/franz-go/pkg/kgo"
+	"github.com/twmb/franz-go/pkg/kmsg"
+	"go.uber.org/zap"
+)
+
+// BrokerProbeResult is the outcome of the most recent probe of a broker.
+type BrokerProbeResult struct {
+	Host string
+	Port int32
+	IsUp bool
+
+	// DialDuration is the time it took to establish the connection, including the TLS handshake if TLS is enabled.
+	// It is only set if the broker is up and the probe established a new connection, rather than reusing one.
+	DialDuration time.Duration
+}
+
+// brokerProbeTracker holds the results of the most recent probe round. Brokers that are no longer part of the
+// cluster metadata are removed with the next round.
+type brokerProbeTracker struct {
+	mutex   sync.Mutex
+	results map[int32]BrokerProbeResult
+
+	// clients are the long-lived probe clients by broker id. They are only accessed by the probe loop.
+	clients map[int32]*brokerProbeClient
+}
+
+// brokerProbeClient is the dedicated client that probes a single broker. It is reused across probe rounds, so that
+// credentials, tokens and TLS certificates are not loaded again for each probe.
+type brokerProbeClient struct {
+	address string
+	client  *kgo.Client
+	hooks   *brokerProbeHooks
+}
+
+func newBrokerProbeTracker() *brokerProbeTracker {
+	return &brokerProbeTracker{
+		results: make(map[int32]BrokerProbeResult),
+		clients: make(map[int32]*brokerProbeClient),
+	}
+}
+
+func (t *brokerProbeTracker) set(results map[int32]BrokerProbeResult) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.results = results
+}
+
+// setAllDown marks all brokers of the most recent probe round as down, because the brokers to probe could not be
+// fetched.
+func (t *brokerProbeTracker) setAllDown() {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	results := make(map[int32]BrokerProbeResult, len(t.results))
+	for brokerID, result := range t.results {
+		results[brokerID] = BrokerProbeResult{Host: result.Host, Port: result.Port}
+	}
+	t.results = results
+}
+
+func (t *brokerProbeTracker) get() map[int32]BrokerProbeResult {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	results := make(map[int32]BrokerProbeResult, len(t.results))
+	for brokerID, result := range t.results {
+		results[brokerID] = result
+	}
+	return results
+}
+
+// brokerProbeHooks records the dial duration of the probe connection.
+type brokerProbeHooks struct {
+	brokerID int32
+
+	mutex        sync.Mutex
+	dialDuration time.Duration
+	connected    bool // whether a connection to the broker has been established since the last takeDialDuration
+}
+
+func (h *brokerProbeHooks) OnBrokerConnect(meta kgo.BrokerMetadata, dialDur time.Duration, _ net.Conn, err error) {
+	// Connections to the seed broker are only used to discover the broker by id
+	if err != nil || meta.NodeID != h.brokerID {
+		return
+	}
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	h.dialDuration = dialDur
+	h.connected = true
+}
+
+// takeDialDuration returns the dial duration of the connection that has been established to the broker since the
+// last call. The second return value is false if no connection has been established.
+func (h *brokerProbeHooks) takeDialDuration() (time.Duration, bool) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	connected := h.connected
+	h.connected = false
+	return h.dialDuration, connected
+}
+
+// GetBrokerProbeResults returns the results of the most recent probe of each broker by broker id.
+func (s *Service) GetBrokerProbeResults() map[int32]BrokerProbeResult {
+	return s.brokerProbes.get()
+}
+
+// startBrokerProbes probes all brokers of the cluster metadata periodically until the context is cancelled.
+func (s *Service) startBrokerProbes(ctx context.Context) {
+	ticker := time.NewTicker(s.Cfg.BrokerProbes.Interval)
+	defer ticker.Stop()
+	defer s.closeBrokerProbeClients(nil)
+
+	for {
+		s.probeBrokers(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *Service) probeBrokers(ctx context.Context) {
+	metadataCtx, cancel := context.WithTimeout(ctx, s.Cfg.BrokerProbes.Timeout)
+	defer cancel()
+
+	// Request the brokers only, without any topics
+	req := kmsg.NewMetadataRequest()
+	req.Topics = []kmsg.MetadataRequestTopic{}
+	res, err := req.RequestWith(metadataCtx, s.client)
+	if err != nil {
+		// Not even the metadata could be fetched, so none of the known brokers is considered up until the next round
+		s.logger.Warn("failed to fetch the brokers to probe", zap.Error(err))
+		s.brokerProbes.setAllDown()
+		return
+	}
+
+	brokers := make(map[int32]kmsg.MetadataResponseBroker, len(res.Brokers))
+	for _, broker := range res.Brokers {
+		brokers[broker.NodeID] = broker
+	}
+	s.closeBrokerProbeClients(brokers)
+
+	results := make(map[int32]BrokerProbeResult, len(res.Brokers))
+	resultsLock := sync.Mutex{}
+	wg := sync.WaitGroup{}
+	for _, broker := range res.Brokers {
+		probeClient := s.getBrokerProbeClient(broker)
+		wg.Add(1)
+		go func(broker kmsg.MetadataResponseBroker) {
+			defer wg.Done()
+			result := s.probeBroker(ctx, broker, probeClient)
+			resultsLock.Lock()
+			results[broker.NodeID] = result
+			resultsLock.Unlock()
+		}(broker)
+	}
+	wg.Wait()
+
+	s.brokerProbes.set(results)
+}
+
+// getBrokerProbeClient returns the probe client of the given broker, creating it if it doesn't exist yet. nil is
+// returned if the client can't be created.
+func (s *Service) getBrokerProbeClient(broker kmsg.MetadataResponseBroker) *brokerProbeClient {
+	if probeClient, exists := s.brokerProbes.clients[broker.NodeID]; exists {
+		return probeClient
+	}
+
+	logger := s.logger.With(zap.Int32("broker_id", broker.NodeID))
+	hooks := &brokerProbeHooks{brokerID: broker.NodeID}
+	address := net.JoinHostPort(broker.Host, strconv.Itoa(int(broker.Port)))
+	// Idle connections are usually closed before the next probe round, so that most probes establish a new
+	// connection. franz-go closes connections after 1-2x the idle timeout, probes that reuse a connection don't
+	// report a dial duration.
+	idleTimeout := s.Cfg.BrokerProbes.Interval / 2
+	if idleTimeout < time.Second {
+		idleTimeout = time.Second
+	}
+	client, err := s.kafkaSvc.CreateClient(logger.Named("broker_probe"), []kgo.Opt{
+		kgo.SeedBrokers(address),
+		kgo.ConnIdleTimeout(idleTimeout),
+		kgo.WithHooks(hooks),
+	})
+	if err != nil {
+		logger.Warn("failed to create kafka client to probe broker", zap.Error(err))
+		return nil
+	}
+
+	probeClient := &brokerProbeClient{address: address, client: client, hooks: hooks}
+	s.brokerProbes.clients[broker.NodeID] = probeClient
+	return probeClient
+}
+
+// closeBrokerProbeClients closes the probe clients of all brokers that are not part of the given brokers or whose
+// address has changed.
+func (s *Service) closeBrokerProbeClients(brokers map[int32]kmsg.MetadataResponseBroker) {
+	for brokerID, probeClient := range s.brokerProbes.clients {
+		broker, exists := brokers[brokerID]
+		if exists && net.JoinHostPort(broker.Host, strconv.Itoa(int(broker.Port))) == probeClient.address {
+			continue
+		}
+		probeClient.client.Close()
+		delete(s.brokerProbes.clients, brokerID)
+	}
+}
+
+// probeBroker sends an ApiVersions request to the given broker using its dedicated client, so that the connections
+// of the other clients are not reused. The probe client's idle connections are closed between two probe rounds.
+func (s *Service) probeBroker(ctx context.Context, broker kmsg.MetadataResponseBroker, probeClient *brokerProbeClient) BrokerProbeResult {
+	result := BrokerProbeResult{
+		Host: broker.Host,
+		Port: broker.Port,
+	}
+	if probeClient == nil {
+		return result
+	}
+	logger := s.logger.With(zap.Int32("broker_id", broker.NodeID))
+
+	probeCtx, cancel := context.WithTimeout(ctx, s.Cfg.BrokerProbes.Timeout)
+	defer cancel()
+	// Connections that have been established before, e.g. by a previous probe that timed out, are not part of this probe
+	probeClient.hooks.takeDialDuration()
+
+	// The request is sent to the broker by id, because the client discovers the other brokers of the cluster too
+	req := kmsg.NewPtrApiVersionsRequest()
+	res, err := probeClient.client.Broker(int(broker.NodeID)).Request(probeCtx, req)
+	if err == nil {
+		err = kerr.ErrorForCode(res.(*kmsg.ApiVersionsResponse).ErrorCode)
+	}
+	if err != nil {
+		logger.Debug("broker probe failed", zap.String("address", probeClient.address), zap.Error(err))
+		return result
+	}
+
+	result.IsUp = true
+	// The connection of a previous probe may still be open, its dial duration has already been reported then
+	if dialDuration, connected := probeClient.hooks.takeDialDuration(); connected {
+		result.DialDuration = dialDuration
+	}
+	return result
+}
@@ -0,0 +1,64 @@
+package minion
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/cloudhut/kminion/v2/kafka"
+)
+
+func TestProbeBrokersReusesClient(t *testing.T) {
+	broker := newFakeBroker(t, nil)
+	kafkaCfg := kafka.Config{}
+	kafkaCfg.SetDefaults()
+	kafkaCfg.Brokers = []string{broker.listener.Addr().String()}
+	svc := &Service{
+		logger:       zap.NewNop(),
+		kafkaSvc:     kafka.NewService(kafkaCfg, zap.NewNop()),
+		client:       broker.newClient(),
+		brokerProbes: newBrokerProbeTracker(),
+	}
+	svc.Cfg.BrokerProbes.SetDefaults()
+	t.Cleanup(func() { svc.closeBrokerProbeClients(nil) })
+
+	host, port := broker.hostPort()
+	svc.probeBrokers(context.Background())
+	assert.Equal(t, BrokerProbeResult{Host: host, Port: port, IsUp: true}, withoutDialDuration(svc.GetBrokerProbeResults()[0]))
+	assert.NotZero(t, svc.GetBrokerProbeResults()[0].DialDuration, "the first probe must establish a connection")
+	require.Len(t, svc.brokerProbes.clients, 1)
+	probeClient := svc.brokerProbes.clients[0]
+
+	// The connection of the previous probe is still open, so there is no dial duration to report
+	svc.probeBrokers(context.Background())
+	assert.Equal(t, BrokerProbeResult{Host: host, Port: port, IsUp: true}, svc.GetBrokerProbeResults()[0])
+	assert.Same(t, probeClient, svc.brokerProbes.clients[0])
+}
+
+func withoutDialDuration(result BrokerProbeResult) BrokerProbeResult {
+	result.DialDuration = 0
+	return result
+}
+
+func TestProbeBrokersMetadataFailure(t *testing.T) {
+	broker := newFakeBroker(t, nil)
+	svc := &Service{
+		logger:       zap.NewNop(),
+		client:       broker.newClient(),
+		brokerProbes: newBrokerProbeTracker(),
+	}
+	svc.Cfg.BrokerProbes.Timeout = 500 * time.Millisecond
+	svc.brokerProbes.set(map[int32]BrokerProbeResult{
+		0: {Host: "broker-0", Port: 9092, IsUp: true, DialDuration: time.Millisecond},
+	})
+
+	// All requests fail once the broker is gone
+	broker.listener.Close()
+	svc.probeBrokers(context.Background())
+
+	assert.Equal(t, map[int32]BrokerProbeResult{0: {Host: "broker-0", Port: 9092}}, svc.GetBrokerProbeResults())
+}
@@ -2,12 +2,15 @@ package minion
 
 import (
 	"net"
+	"strconv"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 	"github.com/twmb/franz-go/pkg/kgo"
 	"go.uber.org/zap"
+
+	"github.com/cloudhut/kminion/v2/kafka"
 )
 
 // clientHooks implements the various hook interfaces from the franz-go (kafka) library. We can use these hooks to
@@ -20,6 +23,11 @@ type clientHooks struct {
 
 	requestsReceivedCount prometheus.Counter
 	bytesReceived         prometheus.Counter
+
+	brokerTLSCertExpiry *prometheus.GaugeVec
+
+	brokerDialLatency         *prometheus.HistogramVec
+	brokerTLSHandshakeLatency *prometheus.HistogramVec
 }
 
 func newMinionClientHooks(logger *zap.Logger, metricsNamespace string) *clientHooks {
@@ -43,6 +51,30 @@ func newMinionClientHooks(logger *zap.Logger, metricsNamespace string) *clientHo
 		Name:      "received_bytes",
 	})
 
+	brokerTLSCertExpiry := promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "kafka",
+		Name:      "broker_tls_cert_expiry_timestamp_seconds",
+		Help:      "Unix timestamp in seconds when the TLS leaf certificate presented by the broker expires",
+	}, []string{"broker_id"})
+
+	// Same buckets as the end-to-end connection latencies
+	connectionBuckets := prometheus.ExponentialBuckets(0.001, 2, 14)
+	brokerDialLatency := promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "kafka",
+		Name:      "broker_dial_latency_seconds",
+		Help:      "Time it took to establish a TCP connection to a broker, excluding the TLS handshake",
+		Buckets:   connectionBuckets,
+	}, []string{"broker_id"})
+	brokerTLSHandshakeLatency := promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "kafka",
+		Name:      "broker_tls_handshake_latency_seconds",
+		Help:      "Time it took to complete the TLS handshake with a broker",
+		Buckets:   connectionBuckets,
+	}, []string{"broker_id"})
+
 	return &clientHooks{
 		logger: logger,
 
@@ -51,10 +83,17 @@ func newMinionClientHooks(logger *zap.Logger, metricsNamespace string) *clientHo
 
 		requestsReceivedCount: requestsReceivedCount,
 		bytesReceived:         bytesReceived,
+
+		brokerTLSCertExpiry: brokerTLSCertExpiry,
+
+		brokerDialLatency:         brokerDialLatency,
+		brokerTLSHandshakeLatency: brokerTLSHandshakeLatency,
 	}
 }
 
-func (c clientHooks) OnBrokerConnect(meta kgo.BrokerMetadata, dialDur time.Duration, _ net.Conn, err error) {
+// OnBrokerConnect logs the connection attempt and records the expiry of the broker's TLS certificate, which is
+// captured during the handshake of each new connection.
+func (c clientHooks) OnBrokerConnect(meta kgo.BrokerMetadata, dialDur time.Duration, conn net.Conn, err error) {
 	if err != nil {
 		c.logger.Debug("kafka connection failed", zap.String("broker_host", meta.Host), zap.Error(err))
 		return
@@ -62,6 +101,30 @@ func (c clientHooks) OnBrokerConnect(meta kgo.BrokerMetadata, dialDur time.Durat
 	c.logger.Debug("kafka connection succeeded",
 		zap.String("host", meta.Host),
 		zap.Duration("dial_duration", dialDur))
+
+	// Seed brokers have negative node ids, which are reported as "seed"
+	brokerID := "seed"
+	if meta.NodeID >= 0 {
+		brokerID = strconv.Itoa(int(meta.NodeID))
+	}
+
+	// If TLS is enabled, the dial duration reported by kgo includes the handshake, which is reported separately
+	tlsConn, ok := conn.(*kafka.TLSConn)
+	if !ok {
+		c.brokerDialLatency.WithLabelValues(brokerID).Observe(dialDur.Seconds())
+		return
+	}
+	handshakeDur := tlsConn.HandshakeDuration()
+	c.brokerTLSHandshakeLatency.WithLabelValues(brokerID).Observe(handshakeDur.Seconds())
+	c.brokerDialLatency.WithLabelValues(brokerID).Observe((dialDur - handshakeDur).Seconds())
+
+	// Certificates of seed brokers are recorded once the broker ids are known
+	if meta.NodeID < 0 {
+		return
+	}
+	if expiry, ok := tlsConn.PeerCertificateExpiry(); ok {
+		c.brokerTLSCertExpiry.WithLabelValues(brokerID).Set(float64(expiry.Unix()))
+	}
 }
 
 func (c clientHooks) OnBrokerDisconnect(meta kgo.BrokerMetadata, _ net.Conn) {
@@ -7,16 +7,36 @@ import (
 )
 
 type Config struct {
-	ConsumerGroups ConsumerGroupConfig `koanf:"consumerGroups"`
-	Topics         TopicConfig         `koanf:"topics"`
-	LogDirs        LogDirsConfig       `koanf:"logDirs"`
-	EndToEnd       e2e.Config          `koanf:"endToEnd"`
+	ConsumerGroups   ConsumerGroupConfig    `koanf:"consumerGroups"`
+	Topics           TopicConfig            `koanf:"topics"`
+	LogDirs          LogDirsConfig          `koanf:"logDirs"`
+	BrokerConfigs    BrokerConfigsConfig    `koanf:"brokerConfigs"`
+	APIVersions      APIVersionsConfig      `koanf:"apiVersions"`
+	ACLs             ACLsConfig             `koanf:"acls"`
+	ClientQuotas     ClientQuotasConfig     `koanf:"clientQuotas"`
+	DelegationTokens DelegationTokensConfig `koanf:"delegationTokens"`
+	KRaftQuorum      KRaftQuorumConfig      `koanf:"kraftQuorum"`
+	Transactions     TransactionsConfig     `koanf:"transactions"`
+	ProducerStates   ProducerStatesConfig   `koanf:"producerStates"`
+	BrokerProbes     BrokerProbesConfig     `koanf:"brokerProbes"`
+	Requests         RequestsConfig         `koanf:"requests"`
+	EndToEnd         e2e.Config             `koanf:"endToEnd"`
 }
 
 func (c *Config) SetDefaults() {
 	c.ConsumerGroups.SetDefaults()
 	c.Topics.SetDefaults()
 	c.LogDirs.SetDefaults()
+	c.BrokerConfigs.SetDefaults()
+	c.APIVersions.SetDefaults()
+	c.ACLs.SetDefaults()
+	c.ClientQuotas.SetDefaults()
+	c.DelegationTokens.SetDefaults()
+	c.KRaftQuorum.SetDefaults()
+	c.Transactions.SetDefaults()
+	c.ProducerStates.SetDefaults()
+	c.BrokerProbes.SetDefaults()
+	c.Requests.SetDefaults()
 	c.EndToEnd.SetDefaults()
 }
 
@@ -36,6 +56,56 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("failed to validate log dirs config: %w", err)
 	}
 
+	err = c.BrokerConfigs.Validate()
+	if err != nil {
+		return fmt.Errorf("failed to validate broker configs config: %w", err)
+	}
+
+	err = c.APIVersions.Validate()
+	if err != nil {
+		return fmt.Errorf("failed to validate api versions config: %w", err)
+	}
+
+	err = c.ACLs.Validate()
+	if err != nil {
+		return fmt.Errorf("failed to validate acls config: %w", err)
+	}
+
+	err = c.ClientQuotas.Validate()
+	if err != nil {
+		return fmt.Errorf("failed to validate client quotas config: %w", err)
+	}
+
+	err = c.DelegationTokens.Validate()
+	if err != nil {
+		return fmt.Errorf("failed to validate delegation tokens config: %w", err)
+	}
+
+	err = c.KRaftQuorum.Validate()
+	if err != nil {
+		return fmt.Errorf("failed to validate kraft quorum config: %w", err)
+	}
+
+	err = c.Transactions.Validate()
+	if err != nil {
+		return fmt.Errorf("failed to validate transactions config: %w", err)
+	}
+
+	err = c.ProducerStates.Validate()
+	if err != nil {
+		return fmt.Errorf("failed to validate producer states config: %w", err)
+	}
+
+	err = c.BrokerProbes.Validate()
+	if err != nil {
+		return fmt.Errorf("failed to validate broker probes config: %w", err)
+	}
+
+	err = c.Requests.Validate()
+	if err != nil {
+		return fmt.Errorf("failed to validate requests config: %w", err)
+	}
+
 	err = c.EndToEnd.Validate()
 	if err != nil {
 		return fmt.Errorf("failed to validate endToEnd config: %w", err)
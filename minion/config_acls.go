@@ -0,0 +1,22 @@
+package minion
+
+type ACLsConfig struct {
+	// Enabled specifies whether ACLs shall be scraped and exported or not. This requires the Describe permission on
+	// the cluster resource.
+	Enabled bool `koanf:"enabled"`
+
+	// ListingEndpoint serves all ACLs as JSON on /api/acls. Enable it only if the kminion HTTP endpoint is not
+	// publicly reachable, as it reveals the principals and resources of the cluster.
+	ListingEndpoint bool `koanf:"listingEndpoint"`
+}
+
+// Validate if provided ACLsConfig is valid.
+func (c *ACLsConfig) Validate() error {
+	return nil
+}
+
+// SetDefaults for acls config
+func (c *ACLsConfig) SetDefaults() {
+	c.Enabled = false
+	c.ListingEndpoint = false
+}
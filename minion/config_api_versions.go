@@ -0,0 +1,43 @@
+package minion
+
+import (
+	"fmt"
+
+	"github.com/twmb/franz-go/pkg/kmsg"
+)
+
+type APIVersionsConfig struct {
+	// Enabled specifies whether the supported API versions of each broker shall be scraped and exported or not.
+	Enabled bool `koanf:"enabled"`
+
+	// APIKeys are the names of the API keys (e.g. "Produce" or "Fetch") whose min and max supported versions shall
+	// be exported.
+	APIKeys []string `koanf:"apiKeys"`
+}
+
+// Validate if provided APIVersionsConfig is valid.
+func (c *APIVersionsConfig) Validate() error {
+	for _, name := range c.APIKeys {
+		if _, exists := apiKeyForName(name); !exists {
+			return fmt.Errorf("api key '%v' is unknown", name)
+		}
+	}
+
+	return nil
+}
+
+// SetDefaults for api versions config
+func (c *APIVersionsConfig) SetDefaults() {
+	c.Enabled = false
+	c.APIKeys = []string{"Produce", "Fetch", "Metadata", "OffsetCommit", "OffsetFetch", "JoinGroup"}
+}
+
+// apiKeyForName returns the API key with the given name, as returned by kmsg.NameForKey.
+func apiKeyForName(name string) (int16, bool) {
+	for key := int16(0); key <= kmsg.MaxKey; key++ {
+		if kmsg.NameForKey(key) == name {
+			return key, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,41 @@
+package minion
+
+import "fmt"
+
+type BrokerConfigsConfig struct {
+	// Enabled specifies whether broker configs shall be scraped and exported or not.
+	Enabled bool `koanf:"enabled"`
+
+	// ConfigKeys is the allowlist of broker config keys that shall be exported. Sensitive configs (such as passwords)
+	// are never exported, even if they are part of this list.
+	ConfigKeys []string `koanf:"configKeys"`
+}
+
+// Validate if provided BrokerConfigsConfig is valid.
+func (c *BrokerConfigsConfig) Validate() error {
+	if c.Enabled && len(c.ConfigKeys) == 0 {
+		return fmt.Errorf("at least one config key must be specified if broker configs are enabled")
+	}
+
+	for _, key := range c.ConfigKeys {
+		if key == "" {
+			return fmt.Errorf("config keys must not be empty")
+		}
+	}
+
+	return nil
+}
+
+// SetDefaults for broker configs config
+func (c *BrokerConfigsConfig) SetDefaults() {
+	c.Enabled = false
+	c.ConfigKeys = []string{
+		"log.retention.hours",
+		"num.network.threads",
+		"num.io.threads",
+		"inter.broker.protocol.version",
+		"default.replication.factor",
+		"min.insync.replicas",
+		"auto.create.topics.enable",
+	}
+}
@@ -0,0 +1,40 @@
+package minion
+
+import (
+	"fmt"
+	"time"
+)
+
+type BrokerProbesConfig struct {
+	// Enabled specifies whether each broker shall be probed periodically, independently of the scrapes. Each probe
+	// establishes a new connection to the broker and sends an ApiVersions request. The probe client of each broker is
+	// reused across probes, while its idle connections are closed between two probes.
+	Enabled bool `koanf:"enabled"`
+
+	// Interval is the time between two probes of each broker.
+	Interval time.Duration `koanf:"interval"`
+
+	// Timeout is the time after which a probe is considered failed.
+	Timeout time.Duration `koanf:"timeout"`
+}
+
+// Validate if provided BrokerProbesConfig is valid.
+func (c *BrokerProbesConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+	if c.Interval <= 0 {
+		return fmt.Errorf("interval must be positive, but got '%v'", c.Interval)
+	}
+	if c.Timeout <= 0 {
+		return fmt.Errorf("timeout must be positive, but got '%v'", c.Timeout)
+	}
+	return nil
+}
+
+// SetDefaults for broker probes config
+func (c *BrokerProbesConfig) SetDefaults() {
+	c.Enabled = false
+	c.Interval = 15 * time.Second
+	c.Timeout = 5 * time.Second
+}
@@ -0,0 +1,17 @@
+package minion
+
+type ClientQuotasConfig struct {
+	// Enabled specifies whether client quotas shall be scraped and exported or not. This requires the
+	// DescribeConfigs permission on the cluster resource and Kafka 2.6+.
+	Enabled bool `koanf:"enabled"`
+}
+
+// Validate if provided ClientQuotasConfig is valid.
+func (c *ClientQuotasConfig) Validate() error {
+	return nil
+}
+
+// SetDefaults for client quotas config
+func (c *ClientQuotasConfig) SetDefaults() {
+	c.Enabled = false
+}
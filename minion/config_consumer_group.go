@@ -2,6 +2,7 @@ package minion
 
 import (
 	"fmt"
+	"time"
 )
 
 const (
@@ -25,12 +26,71 @@ type ConsumerGroupConfig struct {
 	// and only topic lags will be exported.
 	Granularity string `koanf:"granularity"`
 
+	// PartitionGranularityGroups are regex strings of group ids whose partition lags shall be exported even if the
+	// granularity is "topic". This allows to keep per partition lags for a few important groups, while only topic
+	// lags are exported for all other groups.
+	PartitionGranularityGroups []string `koanf:"partitionGranularityGroups"`
+
 	// AllowedGroups are regex strings of group ids that shall be exported
 	AllowedGroupIDs []string `koanf:"allowedGroups"`
 
 	// IgnoredGroups are regex strings of group ids that shall be ignored/skipped when exporting metrics. Ignored groups
 	// take precedence over allowed groups.
 	IgnoredGroupIDs []string `koanf:"ignoredGroups"`
+
+	// FilterRules are ordered allow and deny rules for group ids. The first matching rule decides whether a group is
+	// exported, the allowed and ignored groups only apply to groups that match none of the rules.
+	FilterRules []FilterRule `koanf:"filterRules"`
+
+	// LabelRules extract labels from group ids, which are added to all series that have a group_id label.
+	LabelRules []LabelRule `koanf:"labelRules"`
+
+	// TimeLag exports how many seconds each consumer group is lagging behind, in addition to the offset lag. The lag
+	// is derived from the timestamp of the record at the committed offset, which requires additional fetch requests
+	// to the partition leaders on each scrape.
+	TimeLag bool `koanf:"timeLag"`
+
+	// MemberLabels adds the client id and client host of the member that is assigned to a partition as labels to
+	// the partition lag metric. This helps to identify the instance that owns lagging partitions, but increases the
+	// number of exported metric series as the labels change with each rebalance.
+	MemberLabels bool `koanf:"memberLabels"`
+
+	// OffsetsTopicShardCount is the number of kminion instances that share the consumption of the __consumer_offsets
+	// topic in the offsetsTopic scrape mode. Each instance only consumes the partitions whose id modulo the shard
+	// count equals its shard index, and hence only exports the offsets of the groups coordinated by these partitions.
+	OffsetsTopicShardCount int `koanf:"offsetsTopicShardCount"`
+
+	// OffsetsTopicShardIndex is the zero based index of this kminion instance's shard of the __consumer_offsets topic.
+	OffsetsTopicShardIndex int `koanf:"offsetsTopicShardIndex"`
+
+	// LagVelocity exports the rate at which the lag of each group on each topic changes within the lag velocity
+	// window, as well as the estimated time until the group has caught up.
+	LagVelocity bool `koanf:"lagVelocity"`
+
+	// LagVelocityWindow is the duration over which the lag velocity is calculated. Longer windows smooth out short
+	// spikes, but react slower to changes.
+	LagVelocityWindow time.Duration `koanf:"lagVelocityWindow"`
+
+	// LagThresholds configure the lag at which the lag status of matching groups changes to warn and critical. The
+	// first threshold whose groups match a group applies. Groups that match no threshold have no lag status.
+	LagThresholds []LagThreshold `koanf:"lagThresholds"`
+
+	// DeleteAbandonedEndpoint serves an admin endpoint on /admin/consumer-groups/delete-abandoned, which deletes all
+	// allowed groups that are empty and whose newest offset commit is older than a given age. It requires the
+	// offsetsTopic scrape mode, because the commit timestamps are not returned by the Admin API.
+	DeleteAbandonedEndpoint bool `koanf:"deleteAbandonedEndpoint"`
+}
+
+// LagThreshold configures the lag status of the groups that match one of the group strings.
+type LagThreshold struct {
+	// Groups are regex strings or literals of group ids the thresholds apply to.
+	Groups []string `koanf:"groups"`
+
+	// Warn is the total lag of a group at which its lag status changes to warn.
+	Warn int64 `koanf:"warn"`
+
+	// Critical is the total lag of a group at which its lag status changes to critical.
+	Critical int64 `koanf:"critical"`
 }
 
 func (c *ConsumerGroupConfig) SetDefaults() {
@@ -38,6 +98,13 @@ func (c *ConsumerGroupConfig) SetDefaults() {
 	c.ScrapeMode = ConsumerGroupScrapeModeAdminAPI
 	c.Granularity = ConsumerGroupGranularityPartition
 	c.AllowedGroupIDs = []string{"/.*/"}
+	c.TimeLag = false
+	c.MemberLabels = false
+	c.DeleteAbandonedEndpoint = false
+	c.OffsetsTopicShardCount = 1
+	c.OffsetsTopicShardIndex = 0
+	c.LagVelocity = false
+	c.LagVelocityWindow = 5 * time.Minute
 }
 
 func (c *ConsumerGroupConfig) Validate() error {
@@ -59,6 +126,10 @@ func (c *ConsumerGroupConfig) Validate() error {
 			ConsumerGroupGranularityPartition)
 	}
 
+	if c.DeleteAbandonedEndpoint && c.ScrapeMode != ConsumerGroupScrapeModeOffsetsTopic {
+		return fmt.Errorf("the delete abandoned groups endpoint requires the scrape mode '%v'", ConsumerGroupScrapeModeOffsetsTopic)
+	}
+
 	// Check if all group strings are valid regex or literals
 	for _, groupID := range c.AllowedGroupIDs {
 		_, err := compileRegex(groupID)
@@ -74,5 +145,53 @@ func (c *ConsumerGroupConfig) Validate() error {
 		}
 	}
 
+	for _, groupID := range c.PartitionGranularityGroups {
+		_, err := compileRegex(groupID)
+		if err != nil {
+			return fmt.Errorf("partition granularity group string '%v' is not valid regex", groupID)
+		}
+	}
+
+	if c.OffsetsTopicShardCount < 1 {
+		return fmt.Errorf("offsets topic shard count must be at least 1, but got '%v'", c.OffsetsTopicShardCount)
+	}
+	if c.OffsetsTopicShardIndex < 0 || c.OffsetsTopicShardIndex >= c.OffsetsTopicShardCount {
+		return fmt.Errorf("offsets topic shard index must be between 0 and the shard count (exclusive), but got '%v'",
+			c.OffsetsTopicShardIndex)
+	}
+	if c.OffsetsTopicShardCount > 1 && c.ScrapeMode != ConsumerGroupScrapeModeOffsetsTopic {
+		return fmt.Errorf("sharding the offsets topic requires the scrape mode '%v'", ConsumerGroupScrapeModeOffsetsTopic)
+	}
+
+	if c.LagVelocity && c.LagVelocityWindow <= 0 {
+		return fmt.Errorf("lag velocity window must be greater than zero, but got '%v'", c.LagVelocityWindow)
+	}
+
+	for i, threshold := range c.LagThresholds {
+		if len(threshold.Groups) == 0 {
+			return fmt.Errorf("lag threshold at index '%v' must specify at least one group", i)
+		}
+		for _, groupID := range threshold.Groups {
+			_, err := compileRegex(groupID)
+			if err != nil {
+				return fmt.Errorf("lag threshold group string '%v' is not valid regex", groupID)
+			}
+		}
+		if threshold.Warn <= 0 || threshold.Critical < threshold.Warn {
+			return fmt.Errorf("lag threshold at index '%v' must have a warn threshold greater than zero and a "+
+				"critical threshold not less than the warn threshold", i)
+		}
+	}
+
+	err := validateFilterRules(c.FilterRules)
+	if err != nil {
+		return fmt.Errorf("invalid group filter rules: %w", err)
+	}
+
+	err = validateLabelRules(c.LabelRules)
+	if err != nil {
+		return fmt.Errorf("invalid group label rules: %w", err)
+	}
+
 	return nil
 }
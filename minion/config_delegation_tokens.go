@@ -0,0 +1,17 @@
+package minion
+
+type DelegationTokensConfig struct {
+	// Enabled specifies whether delegation tokens shall be scraped and exported or not. Only the tokens that the
+	// kminion principal owns, renews or is allowed to describe are returned by the brokers.
+	Enabled bool `koanf:"enabled"`
+}
+
+// Validate if provided DelegationTokensConfig is valid.
+func (c *DelegationTokensConfig) Validate() error {
+	return nil
+}
+
+// SetDefaults for delegation tokens config
+func (c *DelegationTokensConfig) SetDefaults() {
+	c.Enabled = false
+}
@@ -0,0 +1,74 @@
+package minion
+
+import (
+	"fmt"
+	"regexp"
+)
+
+const (
+	FilterRuleActionAllow string = "allow"
+	FilterRuleActionDeny  string = "deny"
+)
+
+// labelNameRegex matches valid Prometheus label names. Names with a leading double underscore are reserved.
+var labelNameRegex = regexp.MustCompile("^[a-zA-Z_][a-zA-Z0-9_]*$")
+
+// FilterRule allows or denies the topics or groups that match the pattern. Rules are evaluated in order and the
+// first matching rule decides. If no rule matches, the allowed and ignored lists decide.
+type FilterRule struct {
+	// Action is either "allow" or "deny".
+	Action string `koanf:"action"`
+
+	// Pattern is a regex string or literal of the topic names or group ids the rule applies to.
+	Pattern string `koanf:"pattern"`
+}
+
+// LabelRule extracts labels from topic names or group ids via the named capture groups of its pattern, e.g.
+// "/^team-(?P<team>.+)-events$/" adds the label team to all series of matching topics. The first matching rule
+// provides the label values, labels that are not captured by it are exported with an empty value.
+type LabelRule struct {
+	// Pattern is a regex string with named capture groups.
+	Pattern string `koanf:"pattern"`
+}
+
+func validateFilterRules(rules []FilterRule) error {
+	for i, rule := range rules {
+		switch rule.Action {
+		case FilterRuleActionAllow, FilterRuleActionDeny:
+		default:
+			return fmt.Errorf("filter rule at index '%v' has invalid action '%v'. Valid actions are '%v' or '%v'",
+				i, rule.Action, FilterRuleActionAllow, FilterRuleActionDeny)
+		}
+		_, err := compileRegex(rule.Pattern)
+		if err != nil {
+			return fmt.Errorf("filter rule pattern '%v' is not valid regex", rule.Pattern)
+		}
+	}
+
+	return nil
+}
+
+func validateLabelRules(rules []LabelRule) error {
+	for _, rule := range rules {
+		regex, err := compileRegex(rule.Pattern)
+		if err != nil {
+			return fmt.Errorf("label rule pattern '%v' is not valid regex", rule.Pattern)
+		}
+		hasLabels := false
+		for _, name := range regex.SubexpNames() {
+			if name == "" {
+				continue
+			}
+			hasLabels = true
+			if !labelNameRegex.MatchString(name) || (len(name) > 1 && name[:2] == "__") {
+				return fmt.Errorf("label rule pattern '%v' has capture group '%v' which is not a valid label name",
+					rule.Pattern, name)
+			}
+		}
+		if !hasLabels {
+			return fmt.Errorf("label rule pattern '%v' has no named capture groups", rule.Pattern)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,17 @@
+package minion
+
+type KRaftQuorumConfig struct {
+	// Enabled specifies whether the health of the KRaft controller quorum shall be scraped and exported or not. This
+	// requires a KRaft based cluster running Kafka 3.3+ and the Describe permission on the cluster resource.
+	Enabled bool `koanf:"enabled"`
+}
+
+// Validate if provided KRaftQuorumConfig is valid.
+func (c *KRaftQuorumConfig) Validate() error {
+	return nil
+}
+
+// SetDefaults for kraft quorum config
+func (c *KRaftQuorumConfig) SetDefaults() {
+	c.Enabled = false
+}
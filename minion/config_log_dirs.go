@@ -1,17 +1,52 @@
 package minion
 
+import "fmt"
+
 type LogDirsConfig struct {
 	// Enabled specifies whether log dirs shall be scraped and exported or not. This should be disabled for clusters prior
 	// to version 1.0.0 as describing log dirs was not supported back then.
 	Enabled bool `koanf:"enabled"`
+
+	// Granularity can be per topic or per partition. Partition sizes are only exported if set to "partition", because
+	// the number of exported metric series grows with the number of partitions.
+	Granularity string `koanf:"granularity"`
+
+	// AllowedTopics are regex strings of topic names whose size metrics shall be exported.
+	AllowedTopics []string `koanf:"allowedTopics"`
+
+	// IgnoredTopics are regex strings of topic names whose size metrics shall not be exported. Ignored topics take
+	// precedence over allowed topics.
+	IgnoredTopics []string `koanf:"ignoredTopics"`
 }
 
 // Validate if provided LogDirsConfig is valid.
 func (c *LogDirsConfig) Validate() error {
+	switch c.Granularity {
+	case TopicGranularityPartition, TopicGranularityTopic:
+	default:
+		return fmt.Errorf("given log dirs granularity '%v' is invalid", c.Granularity)
+	}
+
+	for _, topic := range c.AllowedTopics {
+		_, err := compileRegex(topic)
+		if err != nil {
+			return fmt.Errorf("allowed log dirs topic string '%v' is not valid regex", topic)
+		}
+	}
+
+	for _, topic := range c.IgnoredTopics {
+		_, err := compileRegex(topic)
+		if err != nil {
+			return fmt.Errorf("ignored log dirs topic string '%v' is not valid regex", topic)
+		}
+	}
+
 	return nil
 }
 
 // SetDefaults for topic config
 func (c *LogDirsConfig) SetDefaults() {
 	c.Enabled = true
+	c.Granularity = TopicGranularityTopic
+	c.AllowedTopics = []string{"/.*/"}
 }
@@ -0,0 +1,30 @@
+package minion
+
+import (
+	"fmt"
+)
+
+type ProducerStatesConfig struct {
+	// Enabled specifies whether the active producer ids of each partition shall be scraped and exported or not. This
+	// requires Kafka 2.8+ and sends a DescribeProducers request for all allowed topics to the partition leaders.
+	Enabled bool `koanf:"enabled"`
+
+	// HighCountThreshold is the number of active producer ids at which a partition is reported as having a high
+	// producer state count. Brokers keep the state of each producer id in memory.
+	HighCountThreshold int `koanf:"highCountThreshold"`
+}
+
+// Validate if provided ProducerStatesConfig is valid.
+func (c *ProducerStatesConfig) Validate() error {
+	if c.HighCountThreshold <= 0 {
+		return fmt.Errorf("high count threshold must be greater than zero, but got '%v'", c.HighCountThreshold)
+	}
+
+	return nil
+}
+
+// SetDefaults for producer states config
+func (c *ProducerStatesConfig) SetDefaults() {
+	c.Enabled = false
+	c.HighCountThreshold = 1000
+}
@@ -0,0 +1,80 @@
+package minion
+
+import (
+	"fmt"
+	"time"
+)
+
+// RequestsConfig configures the timeouts and retries of the requests that are sent on each scrape, by the kind of
+// request. This allows cheap requests to still succeed quickly on a degraded cluster, while expensive requests are
+// given more time and back off between retries.
+type RequestsConfig struct {
+	// Metadata requests, which are used by almost all collectors
+	Metadata RequestConfig `koanf:"metadata"`
+
+	// Offsets are the ListOffsets requests for the partition watermarks
+	Offsets RequestConfig `koanf:"offsets"`
+
+	// ConsumerGroups are the ListGroups, DescribeGroups and OffsetFetch requests
+	ConsumerGroups RequestConfig `koanf:"consumerGroups"`
+
+	// LogDirs are the DescribeLogDirs requests
+	LogDirs RequestConfig `koanf:"logDirs"`
+}
+
+type RequestConfig struct {
+	// Timeout of a single attempt. If it is 0, attempts are only bounded by the scrape timeout.
+	Timeout time.Duration `koanf:"timeout"`
+
+	// Retries is the number of times a failed attempt is retried.
+	Retries int `koanf:"retries"`
+
+	// Backoff is the time to wait before retrying a failed attempt.
+	Backoff time.Duration `koanf:"backoff"`
+}
+
+// Validate if provided RequestsConfig is valid.
+func (c *RequestsConfig) Validate() error {
+	requests := map[string]RequestConfig{
+		"metadata":       c.Metadata,
+		"offsets":        c.Offsets,
+		"consumerGroups": c.ConsumerGroups,
+		"logDirs":        c.LogDirs,
+	}
+	for name, request := range requests {
+		err := request.Validate()
+		if err != nil {
+			return fmt.Errorf("invalid %v request config: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// SetDefaults for requests config
+func (c *RequestsConfig) SetDefaults() {
+	c.Metadata.SetDefaults()
+	c.Offsets.SetDefaults()
+	c.ConsumerGroups.SetDefaults()
+	c.LogDirs.SetDefaults()
+}
+
+// Validate if provided RequestConfig is valid.
+func (c *RequestConfig) Validate() error {
+	if c.Timeout < 0 {
+		return fmt.Errorf("timeout must not be negative, but got '%v'", c.Timeout)
+	}
+	if c.Retries < 0 {
+		return fmt.Errorf("retries must not be negative, but got '%v'", c.Retries)
+	}
+	if c.Backoff < 0 {
+		return fmt.Errorf("backoff must not be negative, but got '%v'", c.Backoff)
+	}
+	return nil
+}
+
+// SetDefaults for request config
+func (c *RequestConfig) SetDefaults() {
+	c.Timeout = 0
+	c.Retries = 0
+	c.Backoff = time.Second
+}
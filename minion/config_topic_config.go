@@ -24,8 +24,55 @@ type TopicConfig struct {
 	// take precedence over allowed topics.
 	IgnoredTopics []string `koanf:"ignoredTopics"`
 
+	// FilterRules are ordered allow and deny rules for topic names. The first matching rule decides whether a topic
+	// is exported, the allowed and ignored topics only apply to topics that match none of the rules.
+	FilterRules []FilterRule `koanf:"filterRules"`
+
+	// LabelRules extract labels from topic names, which are added to all series that have a topic_name label.
+	LabelRules []LabelRule `koanf:"labelRules"`
+
 	// InfoMetric configures how the kafka_topic_info metric is populated
 	InfoMetric InfoMetricConfig `koanf:"infoMetric"`
+
+	// OldestMessageAge exports the age of the oldest message in each partition, so that you can verify that the
+	// retention works as expected. The age is derived from the timestamp of the record at the low water mark, which
+	// requires additional fetch requests to the partition leaders on each scrape.
+	OldestMessageAge bool `koanf:"oldestMessageAge"`
+
+	// NewestMessageAge exports the timestamp and age of the newest message in each partition, so that you can detect
+	// topics that stopped receiving data. This requires Kafka 3.0+.
+	NewestMessageAge bool `koanf:"newestMessageAge"`
+
+	// TieredStorage exports whether remote storage is enabled for each topic, as well as the approximate number of
+	// messages that are only available in remote storage. This requires Kafka 3.5+.
+	TieredStorage bool `koanf:"tieredStorage"`
+
+	// Throughput exports the approximate number of messages per second produced to each topic, derived from the high
+	// water marks of subsequent scrapes. If log dirs are scraped, the bytes per second are estimated as well.
+	Throughput bool `koanf:"throughput"`
+
+	// MinReplicationFactor is the desired minimum replication factor of all topics. Topics with a lower replication
+	// factor are reported. Set it to 0 to disable the check.
+	MinReplicationFactor int `koanf:"minReplicationFactor"`
+
+	// ConfigBaselines declare the expected configs of topics. Topics whose actual config values differ from the
+	// expected values are reported as drifted.
+	ConfigBaselines []TopicConfigBaseline `koanf:"configBaselines"`
+}
+
+// TopicConfigBaseline declares the expected config values for all topics that match one of the topic strings. If a
+// topic matches multiple baselines, the later baselines take precedence.
+type TopicConfigBaseline struct {
+	// Topics are regex strings or literals of topic names the baseline applies to.
+	Topics []string `koanf:"topics"`
+
+	// Configs are the expected config values.
+	Configs []TopicConfigBaselineEntry `koanf:"configs"`
+}
+
+type TopicConfigBaselineEntry struct {
+	Name  string `koanf:"name"`
+	Value string `koanf:"value"`
 }
 
 type InfoMetricConfig struct {
@@ -58,6 +105,37 @@ func (c *TopicConfig) Validate() error {
 		}
 	}
 
+	if c.MinReplicationFactor < 0 {
+		return fmt.Errorf("min replication factor must not be negative, but got '%v'", c.MinReplicationFactor)
+	}
+
+	err := validateFilterRules(c.FilterRules)
+	if err != nil {
+		return fmt.Errorf("invalid topic filter rules: %w", err)
+	}
+
+	err = validateLabelRules(c.LabelRules)
+	if err != nil {
+		return fmt.Errorf("invalid topic label rules: %w", err)
+	}
+
+	for i, baseline := range c.ConfigBaselines {
+		if len(baseline.Topics) == 0 {
+			return fmt.Errorf("config baseline at index '%v' must specify at least one topic", i)
+		}
+		for _, topic := range baseline.Topics {
+			_, err := compileRegex(topic)
+			if err != nil {
+				return fmt.Errorf("config baseline topic string '%v' is not valid regex", topic)
+			}
+		}
+		for _, config := range baseline.Configs {
+			if config.Name == "" {
+				return fmt.Errorf("config baseline at index '%v' must not contain configs without a name", i)
+			}
+		}
+	}
+
 	return nil
 }
 
@@ -67,4 +145,9 @@ func (c *TopicConfig) SetDefaults() {
 	c.Granularity = TopicGranularityPartition
 	c.AllowedTopics = []string{"/.*/"}
 	c.InfoMetric = InfoMetricConfig{ConfigKeys: []string{"cleanup.policy"}}
+	c.OldestMessageAge = false
+	c.NewestMessageAge = false
+	c.TieredStorage = false
+	c.MinReplicationFactor = 0
+	c.Throughput = false
 }
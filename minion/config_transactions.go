@@ -0,0 +1,45 @@
+package minion
+
+import (
+	"fmt"
+	"time"
+)
+
+type TransactionsConfig struct {
+	// Enabled specifies whether transactions shall be scraped and exported or not. This requires Kafka 3.0+ and the
+	// Describe permission on the cluster resource, otherwise only the transactions whose transactional ids kminion
+	// is allowed to describe are returned.
+	Enabled bool `koanf:"enabled"`
+
+	// MaxAge is the age after which an ongoing transaction is considered long-running. Long-running transactions
+	// pin the last stable offset of their partitions and thereby stall read_committed consumers.
+	MaxAge time.Duration `koanf:"maxAge"`
+
+	// StuckLastStableOffsets specifies whether partitions with a stuck last stable offset shall be exported. It is
+	// independent of Enabled, because it only requires ListOffsets requests, which are supported by all Kafka versions.
+	StuckLastStableOffsets bool `koanf:"stuckLastStableOffsets"`
+
+	// LastStableOffsetStuckAfter is the duration after which a partition is reported as stuck, if its last stable
+	// offset has not advanced while its high water mark has. This is usually caused by a hanging transaction.
+	LastStableOffsetStuckAfter time.Duration `koanf:"lastStableOffsetStuckAfter"`
+}
+
+// Validate if provided TransactionsConfig is valid.
+func (c *TransactionsConfig) Validate() error {
+	if c.MaxAge <= 0 {
+		return fmt.Errorf("max age must be greater than zero, but got '%v'", c.MaxAge)
+	}
+	if c.LastStableOffsetStuckAfter <= 0 {
+		return fmt.Errorf("last stable offset stuck after must be greater than zero, but got '%v'", c.LastStableOffsetStuckAfter)
+	}
+
+	return nil
+}
+
+// SetDefaults for transactions config
+func (c *TransactionsConfig) SetDefaults() {
+	c.Enabled = false
+	c.StuckLastStableOffsets = false
+	c.MaxAge = 5 * time.Minute
+	c.LastStableOffsetStuckAfter = 5 * time.Minute
+}
@@ -70,7 +70,9 @@ func (s *Service) listConsumerGroupOffsets(ctx context.Context, group string) (*
 	req := kmsg.NewOffsetFetchRequest()
 	req.Group = group
 	req.Topics = nil
-	res, err := req.RequestWith(ctx, s.client)
+	res, err := requestWithRetries(ctx, s.Cfg.Requests.ConsumerGroups, func(ctx context.Context) (*kmsg.OffsetFetchResponse, error) {
+		return req.RequestWith(ctx, s.client)
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to request group offsets for group '%v': %w", group, err)
 	}
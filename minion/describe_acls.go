@@ -0,0 +1,104 @@
+package minion
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/twmb/franz-go/pkg/kerr"
+	"github.com/twmb/franz-go/pkg/kmsg"
+	"go.uber.org/zap"
+)
+
+func (s *Service) DescribeACLsCached(ctx context.Context) (*kmsg.DescribeACLsResponse, error) {
+	reqId := ctx.Value("requestId").(string)
+	key := "describe-acls-" + reqId
+
+	if cachedRes, exists := s.getCachedItem(key); exists {
+		return cachedRes.(*kmsg.DescribeACLsResponse), nil
+	}
+
+	res, err, _ := s.requestGroup.Do(key, func() (interface{}, error) {
+		acls, err := s.DescribeACLs(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		s.setCachedItem(key, acls, 120*time.Second)
+
+		return acls, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return res.(*kmsg.DescribeACLsResponse), nil
+}
+
+// DescribeACLs describes all ACLs of the cluster.
+func (s *Service) DescribeACLs(ctx context.Context) (*kmsg.DescribeACLsResponse, error) {
+	req := kmsg.NewDescribeACLsRequest()
+	req.ResourceType = kmsg.ACLResourceTypeAny
+	req.ResourcePatternType = kmsg.ACLResourcePatternTypeAny
+	req.Operation = kmsg.ACLOperationAny
+	req.PermissionType = kmsg.ACLPermissionTypeAny
+
+	res, err := req.RequestWith(ctx, s.client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to request acls: %w", err)
+	}
+
+	err = kerr.ErrorForCode(res.ErrorCode)
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe acls. Inner kafka error: %w", err)
+	}
+
+	return res, nil
+}
+
+// HandleACLs lists all ACLs of the cluster as JSON.
+func (s *Service) HandleACLs() http.HandlerFunc {
+	type acl struct {
+		ResourceType   string `json:"resourceType"`
+		ResourceName   string `json:"resourceName"`
+		PatternType    string `json:"patternType"`
+		Principal      string `json:"principal"`
+		Host           string `json:"host"`
+		Operation      string `json:"operation"`
+		PermissionType string `json:"permissionType"`
+	}
+	type response struct {
+		ACLs []acl `json:"acls"`
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), 15*time.Second)
+		defer cancel()
+
+		acls, err := s.DescribeACLs(ctx)
+		if err != nil {
+			s.logger.Warn("failed to describe acls", zap.Error(err))
+			http.Error(w, "failed to describe acls", http.StatusInternalServerError)
+			return
+		}
+
+		res := response{ACLs: make([]acl, 0)}
+		for _, resource := range acls.Resources {
+			for _, entry := range resource.ACLs {
+				res.ACLs = append(res.ACLs, acl{
+					ResourceType:   resource.ResourceType.String(),
+					ResourceName:   resource.ResourceName,
+					PatternType:    resource.ResourcePatternType.String(),
+					Principal:      entry.Principal,
+					Host:           entry.Host,
+					Operation:      entry.Operation.String(),
+					PermissionType: entry.PermissionType.String(),
+				})
+			}
+		}
+		resJson, _ := json.Marshal(res)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(resJson)
+	}
+}
@@ -0,0 +1,60 @@
+package minion
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/twmb/franz-go/pkg/kmsg"
+)
+
+func (s *Service) GetBrokerConfigsCached(ctx context.Context) (*kmsg.DescribeConfigsResponse, error) {
+	reqId := ctx.Value("requestId").(string)
+	key := "broker-configs-" + reqId
+
+	if cachedRes, exists := s.getCachedItem(key); exists {
+		return cachedRes.(*kmsg.DescribeConfigsResponse), nil
+	}
+
+	res, err, _ := s.requestGroup.Do(key, func() (interface{}, error) {
+		configs, err := s.GetBrokerConfigs(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		s.setCachedItem(key, configs, 120*time.Second)
+
+		return configs, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return res.(*kmsg.DescribeConfigsResponse), nil
+}
+
+// GetBrokerConfigs describes the configured broker config keys of all brokers. The client sends the request for
+// each broker resource to the respective broker.
+func (s *Service) GetBrokerConfigs(ctx context.Context) (*kmsg.DescribeConfigsResponse, error) {
+	metadata, err := s.GetMetadataCached(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get metadata: %w", err)
+	}
+
+	req := kmsg.NewDescribeConfigsRequest()
+	for _, broker := range metadata.Brokers {
+		resourceReq := kmsg.NewDescribeConfigsRequestResource()
+		resourceReq.ResourceType = kmsg.ConfigResourceTypeBroker
+		resourceReq.ResourceName = strconv.Itoa(int(broker.NodeID))
+		resourceReq.ConfigNames = s.Cfg.BrokerConfigs.ConfigKeys
+		req.Resources = append(req.Resources, resourceReq)
+	}
+
+	res, err := req.RequestWith(ctx, s.client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to request broker configs: %w", err)
+	}
+
+	return res, nil
+}
@@ -0,0 +1,28 @@
+package minion
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/twmb/franz-go/pkg/kerr"
+	"github.com/twmb/franz-go/pkg/kmsg"
+)
+
+// DescribeClientQuotas describes the quotas of all entities (users, client ids and ips), including the defaults.
+func (s *Service) DescribeClientQuotas(ctx context.Context) (*kmsg.DescribeClientQuotasResponse, error) {
+	// Without any components and strict matching, all configured quotas are returned
+	req := kmsg.NewDescribeClientQuotasRequest()
+	req.Strict = false
+
+	res, err := req.RequestWith(ctx, s.client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to request client quotas: %w", err)
+	}
+
+	err = kerr.ErrorForCode(res.ErrorCode)
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe client quotas. Inner kafka error: %w", err)
+	}
+
+	return res, nil
+}
@@ -41,7 +41,9 @@ func (s *Service) listConsumerGroupsCached(ctx context.Context) (*kmsg.ListGroup
 
 func (s *Service) listConsumerGroups(ctx context.Context) (*kmsg.ListGroupsResponse, error) {
 	listReq := kmsg.NewListGroupsRequest()
-	res, err := listReq.RequestWith(ctx, s.client)
+	res, err := requestWithRetries(ctx, s.Cfg.Requests.ConsumerGroups, func(ctx context.Context) (*kmsg.ListGroupsResponse, error) {
+		return listReq.RequestWith(ctx, s.client)
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to list consumer groups: %w", err)
 	}
@@ -53,6 +55,29 @@ func (s *Service) listConsumerGroups(ctx context.Context) (*kmsg.ListGroupsRespo
 	return res, nil
 }
 
+func (s *Service) DescribeConsumerGroupsCached(ctx context.Context) ([]DescribeConsumerGroupsResponse, error) {
+	reqId := ctx.Value("requestId").(string)
+	key := "describe-consumer-groups-" + reqId
+
+	if cachedRes, exists := s.getCachedItem(key); exists {
+		return cachedRes.([]DescribeConsumerGroupsResponse), nil
+	}
+	res, err, _ := s.requestGroup.Do(key, func() (interface{}, error) {
+		res, err := s.DescribeConsumerGroups(ctx)
+		if err != nil {
+			return nil, err
+		}
+		s.setCachedItem(key, res, 120*time.Second)
+
+		return res, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return res.([]DescribeConsumerGroupsResponse), nil
+}
+
 func (s *Service) DescribeConsumerGroups(ctx context.Context) ([]DescribeConsumerGroupsResponse, error) {
 	listRes, err := s.listConsumerGroupsCached(ctx)
 	if err != nil {
@@ -67,7 +92,9 @@ func (s *Service) DescribeConsumerGroups(ctx context.Context) ([]DescribeConsume
 	describeReq := kmsg.NewDescribeGroupsRequest()
 	describeReq.Groups = groupIDs
 	describeReq.IncludeAuthorizedOperations = false
-	shardedResp := s.client.RequestSharded(ctx, &describeReq)
+	shardedResp, _ := requestWithRetries(ctx, s.Cfg.Requests.ConsumerGroups, func(ctx context.Context) ([]kgo.ResponseShard, error) {
+		return shardedResponseWithError(s.client.RequestSharded(ctx, &describeReq))
+	})
 
 	describedGroups := make([]DescribeConsumerGroupsResponse, 0)
 	for _, kresp := range shardedResp {
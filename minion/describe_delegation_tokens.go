@@ -0,0 +1,27 @@
+package minion
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/twmb/franz-go/pkg/kerr"
+	"github.com/twmb/franz-go/pkg/kmsg"
+)
+
+// DescribeDelegationTokens describes the delegation tokens of all owners.
+func (s *Service) DescribeDelegationTokens(ctx context.Context) (*kmsg.DescribeDelegationTokenResponse, error) {
+	req := kmsg.NewDescribeDelegationTokenRequest()
+	req.Owners = nil // Describe tokens of all owners
+
+	res, err := req.RequestWith(ctx, s.client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to request delegation tokens: %w", err)
+	}
+
+	err = kerr.ErrorForCode(res.ErrorCode)
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe delegation tokens. Inner kafka error: %w", err)
+	}
+
+	return res, nil
+}
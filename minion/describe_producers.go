@@ -0,0 +1,56 @@
+package minion
+
+import (
+	"context"
+
+	"github.com/twmb/franz-go/pkg/kgo"
+	"github.com/twmb/franz-go/pkg/kmsg"
+)
+
+type DescribeProducersResponseShard struct {
+	Err       error
+	Broker    kgo.BrokerMetadata
+	Producers *kmsg.DescribeProducersResponse
+}
+
+// DescribeProducers describes the active producers of all partitions of the allowed topics. The requests are sent
+// to the partition leaders, hence each shard contains the partitions led by one broker.
+func (s *Service) DescribeProducers(ctx context.Context) ([]DescribeProducersResponseShard, error) {
+	metadata, err := s.GetMetadataCached(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	req := kmsg.NewDescribeProducersRequest()
+	for _, topic := range metadata.Topics {
+		if !s.IsTopicAllowed(*topic.Topic) {
+			continue
+		}
+		topicReq := kmsg.NewDescribeProducersRequestTopic()
+		topicReq.Topic = *topic.Topic
+		for _, partition := range topic.Partitions {
+			topicReq.Partitions = append(topicReq.Partitions, partition.Partition)
+		}
+		req.Topics = append(req.Topics, topicReq)
+	}
+	if len(req.Topics) == 0 {
+		return nil, nil
+	}
+
+	responses := s.client.RequestSharded(ctx, &req)
+	res := make([]DescribeProducersResponseShard, len(responses))
+	for i, responseShard := range responses {
+		producers, ok := responseShard.Resp.(*kmsg.DescribeProducersResponse)
+		if !ok {
+			producers = &kmsg.DescribeProducersResponse{}
+		}
+
+		res[i] = DescribeProducersResponseShard{
+			Err:       responseShard.Err,
+			Broker:    responseShard.Meta,
+			Producers: producers,
+		}
+	}
+
+	return res, nil
+}
@@ -0,0 +1,49 @@
+package minion
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twmb/franz-go/pkg/kmsg"
+	"golang.org/x/sync/singleflight"
+)
+
+func TestDescribeProducers(t *testing.T) {
+	broker := newFakeBroker(t, map[int16]func(req kmsg.Request) kmsg.Response{
+		kmsg.DescribeProducers.Int16(): func(req kmsg.Request) kmsg.Response {
+			producer := kmsg.NewDescribeProducersResponseTopicPartitionActiveProducer()
+			producer.ProducerID = 42
+			partition := kmsg.NewDescribeProducersResponseTopicPartition()
+			partition.ActiveProducers = []kmsg.DescribeProducersResponseTopicPartitionActiveProducer{producer}
+			topic := kmsg.NewDescribeProducersResponseTopic()
+			topic.Topic = "orders"
+			topic.Partitions = []kmsg.DescribeProducersResponseTopicPartition{partition}
+
+			resp := req.ResponseKind().(*kmsg.DescribeProducersResponse)
+			resp.Topics = []kmsg.DescribeProducersResponseTopic{topic}
+			return resp
+		},
+	})
+	broker.topics = []string{"orders"}
+	svc := &Service{
+		client:            broker.newClient(),
+		requestGroup:      &singleflight.Group{},
+		cache:             make(map[string]interface{}),
+		AllowedTopicsExpr: []*regexp.Regexp{regexp.MustCompile(".*")},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	ctx = context.WithValue(ctx, "requestId", "test")
+
+	shards, err := svc.DescribeProducers(ctx)
+	require.NoError(t, err)
+	require.Len(t, shards, 1)
+	require.NoError(t, shards[0].Err)
+	require.Len(t, shards[0].Producers.Topics, 1)
+	assert.Equal(t, int64(42), shards[0].Producers.Topics[0].Partitions[0].ActiveProducers[0].ProducerID)
+}
@@ -0,0 +1,50 @@
+package minion
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/twmb/franz-go/pkg/kerr"
+	"github.com/twmb/franz-go/pkg/kmsg"
+)
+
+// clusterMetadataTopic is the internal topic that is replicated by the KRaft controller quorum.
+const clusterMetadataTopic = "__cluster_metadata"
+
+// DescribeQuorum describes the state of the KRaft controller quorum, which replicates the cluster metadata
+// partition. The request is forwarded to the active controller by the brokers.
+func (s *Service) DescribeQuorum(ctx context.Context) (*kmsg.DescribeQuorumResponseTopicPartition, error) {
+	partitionReq := kmsg.NewDescribeQuorumRequestTopicPartition()
+	partitionReq.Partition = 0
+	topicReq := kmsg.NewDescribeQuorumRequestTopic()
+	topicReq.Topic = clusterMetadataTopic
+	topicReq.Partitions = []kmsg.DescribeQuorumRequestTopicPartition{partitionReq}
+
+	req := kmsg.NewDescribeQuorumRequest()
+	req.Topics = []kmsg.DescribeQuorumRequestTopic{topicReq}
+
+	res, err := req.RequestWith(ctx, s.client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to request quorum description: %w", err)
+	}
+
+	err = kerr.ErrorForCode(res.ErrorCode)
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe quorum. Inner kafka error: %w", err)
+	}
+
+	for _, topic := range res.Topics {
+		for _, partition := range topic.Partitions {
+			if topic.Topic != clusterMetadataTopic || partition.Partition != 0 {
+				continue
+			}
+			err = kerr.ErrorForCode(partition.ErrorCode)
+			if err != nil {
+				return nil, fmt.Errorf("failed to describe quorum partition. Inner kafka error: %w", err)
+			}
+			return &partition, nil
+		}
+	}
+
+	return nil, fmt.Errorf("quorum description did not contain the cluster metadata partition")
+}
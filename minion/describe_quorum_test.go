@@ -0,0 +1,40 @@
+package minion
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twmb/franz-go/pkg/kmsg"
+)
+
+func TestDescribeQuorum(t *testing.T) {
+	broker := newFakeBroker(t, map[int16]func(req kmsg.Request) kmsg.Response{
+		kmsg.DescribeQuorum.Int16(): func(req kmsg.Request) kmsg.Response {
+			partition := kmsg.NewDescribeQuorumResponseTopicPartition()
+			partition.LeaderID = 3000
+			partition.LeaderEpoch = 7
+			partition.HighWatermark = 1234
+			topic := kmsg.NewDescribeQuorumResponseTopic()
+			topic.Topic = clusterMetadataTopic
+			topic.Partitions = []kmsg.DescribeQuorumResponseTopicPartition{partition}
+
+			resp := req.ResponseKind().(*kmsg.DescribeQuorumResponse)
+			resp.Topics = []kmsg.DescribeQuorumResponseTopic{topic}
+			return resp
+		},
+	})
+	svc := &Service{client: broker.newClient()}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	partition, err := svc.DescribeQuorum(ctx)
+	require.NoError(t, err)
+
+	assert.Equal(t, int32(3000), partition.LeaderID)
+	assert.Equal(t, int32(7), partition.LeaderEpoch)
+	assert.Equal(t, int64(1234), partition.HighWatermark)
+	assert.Equal(t, kmsg.NewPtrDescribeQuorumRequest().MaxVersion(), broker.requestVersion(kmsg.DescribeQuorum.Int16()))
+}
@@ -3,11 +3,37 @@ package minion
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/twmb/franz-go/pkg/kmsg"
 )
 
+func (s *Service) GetTopicConfigsCached(ctx context.Context) (*kmsg.DescribeConfigsResponse, error) {
+	reqId := ctx.Value("requestId").(string)
+	key := "topic-configs-" + reqId
+
+	if cachedRes, exists := s.getCachedItem(key); exists {
+		return cachedRes.(*kmsg.DescribeConfigsResponse), nil
+	}
+
+	res, err, _ := s.requestGroup.Do(key, func() (interface{}, error) {
+		configs, err := s.GetTopicConfigs(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		s.setCachedItem(key, configs, 120*time.Second)
+
+		return configs, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return res.(*kmsg.DescribeConfigsResponse), nil
+}
+
 func (s *Service) GetTopicConfigs(ctx context.Context) (*kmsg.DescribeConfigsResponse, error) {
 	metadata, err := s.GetMetadataCached(ctx)
 	if err != nil {
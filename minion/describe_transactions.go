@@ -0,0 +1,60 @@
+package minion
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/twmb/franz-go/pkg/kerr"
+	"github.com/twmb/franz-go/pkg/kmsg"
+)
+
+// ListTransactions lists the transactions of all transaction coordinators. An error is returned if any of the
+// brokers fails to respond, as the list of transactions would be incomplete otherwise.
+func (s *Service) ListTransactions(ctx context.Context) ([]kmsg.ListTransactionsResponseTransactionState, error) {
+	req := kmsg.NewListTransactionsRequest()
+	shardedResp := s.client.RequestSharded(ctx, &req)
+
+	transactions := make([]kmsg.ListTransactionsResponseTransactionState, 0)
+	for _, kresp := range shardedResp {
+		if kresp.Err != nil {
+			return nil, fmt.Errorf("broker '%v' failed to list transactions: %w", kresp.Meta.NodeID, kresp.Err)
+		}
+		res := kresp.Resp.(*kmsg.ListTransactionsResponse)
+		err := kerr.ErrorForCode(res.ErrorCode)
+		if err != nil {
+			return nil, fmt.Errorf("broker '%v' failed to list transactions. Inner kafka error: %w",
+				kresp.Meta.NodeID, err)
+		}
+		transactions = append(transactions, res.TransactionStates...)
+	}
+
+	return transactions, nil
+}
+
+// DescribeTransactions describes the given transactional ids at their transaction coordinators. Transactions that
+// could not be described are omitted.
+func (s *Service) DescribeTransactions(ctx context.Context, transactionalIDs []string) ([]kmsg.DescribeTransactionsResponseTransactionState, error) {
+	if len(transactionalIDs) == 0 {
+		return nil, nil
+	}
+
+	req := kmsg.NewDescribeTransactionsRequest()
+	req.TransactionalIDs = transactionalIDs
+	shardedResp := s.client.RequestSharded(ctx, &req)
+
+	transactions := make([]kmsg.DescribeTransactionsResponseTransactionState, 0, len(transactionalIDs))
+	for _, kresp := range shardedResp {
+		if kresp.Err != nil {
+			return nil, fmt.Errorf("broker '%v' failed to describe transactions: %w", kresp.Meta.NodeID, kresp.Err)
+		}
+		res := kresp.Resp.(*kmsg.DescribeTransactionsResponse)
+		for _, transaction := range res.TransactionStates {
+			if kerr.ErrorForCode(transaction.ErrorCode) != nil {
+				continue
+			}
+			transactions = append(transactions, transaction)
+		}
+	}
+
+	return transactions, nil
+}
@@ -0,0 +1,50 @@
+package minion
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twmb/franz-go/pkg/kmsg"
+)
+
+func TestListAndDescribeTransactions(t *testing.T) {
+	broker := newFakeBroker(t, map[int16]func(req kmsg.Request) kmsg.Response{
+		kmsg.ListTransactions.Int16(): func(req kmsg.Request) kmsg.Response {
+			state := kmsg.NewListTransactionsResponseTransactionState()
+			state.TransactionalID = "payments-tx"
+			state.ProducerID = 42
+			state.TransactionState = "Ongoing"
+
+			resp := req.ResponseKind().(*kmsg.ListTransactionsResponse)
+			resp.TransactionStates = []kmsg.ListTransactionsResponseTransactionState{state}
+			return resp
+		},
+		kmsg.DescribeTransactions.Int16(): func(req kmsg.Request) kmsg.Response {
+			state := kmsg.NewDescribeTransactionsResponseTransactionState()
+			state.TransactionalID = "payments-tx"
+			state.State = "Ongoing"
+			state.ProducerID = 42
+
+			resp := req.ResponseKind().(*kmsg.DescribeTransactionsResponse)
+			resp.TransactionStates = []kmsg.DescribeTransactionsResponseTransactionState{state}
+			return resp
+		},
+	})
+	svc := &Service{client: broker.newClient()}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	listed, err := svc.ListTransactions(ctx)
+	require.NoError(t, err)
+	require.Len(t, listed, 1)
+	assert.Equal(t, "payments-tx", listed[0].TransactionalID)
+
+	described, err := svc.DescribeTransactions(ctx, []string{"payments-tx"})
+	require.NoError(t, err)
+	require.Len(t, described, 1)
+	assert.Equal(t, int64(42), described[0].ProducerID)
+}
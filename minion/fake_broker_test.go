@@ -0,0 +1,226 @@
+package minion
+
+import (
+	"encoding/binary"
+	"io"
+	"net"
+	"strconv"
+	"testing"
+
+	"github.com/twmb/franz-go/pkg/kgo"
+	"github.com/twmb/franz-go/pkg/kmsg"
+	"go.uber.org/zap"
+
+	"github.com/cloudhut/kminion/v2/kafka"
+)
+
+// fakeBroker is a single Kafka broker that speaks just enough of the protocol to let kminion's client issue
+// requests against it. It advertises the max versions that franz-go supports for all keys and answers requests with
+// the responses returned by the handler of the request's key.
+type fakeBroker struct {
+	t        *testing.T
+	listener net.Listener
+	handlers map[int16]func(req kmsg.Request) kmsg.Response
+
+	// topics are reported in the metadata responses with a single partition each, which is led by the fake broker
+	topics []string
+
+	// requests receives the version of each request, by key
+	requests chan fakeBrokerRequest
+}
+
+type fakeBrokerRequest struct {
+	Key     int16
+	Version int16
+}
+
+func newFakeBroker(t *testing.T, handlers map[int16]func(req kmsg.Request) kmsg.Response) *fakeBroker {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	b := &fakeBroker{
+		t:        t,
+		listener: listener,
+		handlers: handlers,
+		requests: make(chan fakeBrokerRequest, 100),
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go b.serve(conn)
+		}
+	}()
+
+	return b
+}
+
+// newClient returns a client that is configured the same way as kminion's clients and whose seed broker is the
+// fake broker.
+func (b *fakeBroker) newClient() *kgo.Client {
+	cfg := kafka.Config{}
+	cfg.SetDefaults()
+	cfg.Brokers = []string{b.listener.Addr().String()}
+	opts, err := kafka.NewKgoConfig(cfg, zap.NewNop())
+	if err != nil {
+		b.t.Fatalf("failed to create kgo config: %v", err)
+	}
+	client, err := kgo.NewClient(opts...)
+	if err != nil {
+		b.t.Fatalf("failed to create client: %v", err)
+	}
+	b.t.Cleanup(client.Close)
+	return client
+}
+
+func (b *fakeBroker) serve(conn net.Conn) {
+	defer conn.Close()
+
+	for {
+		var size [4]byte
+		if _, err := io.ReadFull(conn, size[:]); err != nil {
+			return
+		}
+		body := make([]byte, binary.BigEndian.Uint32(size[:]))
+		if _, err := io.ReadFull(conn, body); err != nil {
+			return
+		}
+
+		// Request header: api key, api version, correlation id, client id and tagged fields if flexible
+		key := int16(binary.BigEndian.Uint16(body[0:]))
+		version := int16(binary.BigEndian.Uint16(body[2:]))
+		correlationID := binary.BigEndian.Uint32(body[4:])
+		body = body[8:]
+		if clientIDLen := int16(binary.BigEndian.Uint16(body)); clientIDLen > 0 {
+			body = body[clientIDLen:]
+		}
+		body = body[2:]
+
+		req := kmsg.RequestForKey(key)
+		req.SetVersion(version)
+		if req.IsFlexible() {
+			body = skipTaggedFields(body)
+		}
+		if err := req.ReadFrom(body); err != nil {
+			b.t.Errorf("failed to read request with key %v: %v", key, err)
+			return
+		}
+		select {
+		case b.requests <- fakeBrokerRequest{Key: key, Version: version}:
+		default:
+		}
+
+		resp := b.handle(req)
+		resp.SetVersion(version)
+
+		out := make([]byte, 4, 64)
+		out = binary.BigEndian.AppendUint32(out, correlationID)
+		// ApiVersions responses always use the response header v0, so that clients can parse them
+		if resp.IsFlexible() && key != kmsg.ApiVersions.Int16() {
+			out = append(out, 0) // no tagged fields
+		}
+		out = resp.AppendTo(out)
+		binary.BigEndian.PutUint32(out, uint32(len(out)-4))
+		if _, err := conn.Write(out); err != nil {
+			return
+		}
+	}
+}
+
+// skipTaggedFields returns the given bytes without the leading tagged fields.
+func skipTaggedFields(b []byte) []byte {
+	numTags, n := binary.Uvarint(b)
+	b = b[n:]
+	for i := uint64(0); i < numTags; i++ {
+		_, n = binary.Uvarint(b) // tag
+		b = b[n:]
+		size, n := binary.Uvarint(b)
+		b = b[uint64(n)+size:]
+	}
+	return b
+}
+
+func (b *fakeBroker) handle(req kmsg.Request) kmsg.Response {
+	if handler, ok := b.handlers[req.Key()]; ok {
+		return handler(req)
+	}
+
+	switch req := req.(type) {
+	case *kmsg.ApiVersionsRequest:
+		resp := req.ResponseKind().(*kmsg.ApiVersionsResponse)
+		for key := int16(0); key <= kmsg.MaxKey; key++ {
+			keyReq := kmsg.RequestForKey(key)
+			if keyReq == nil {
+				continue
+			}
+			apiKey := kmsg.NewApiVersionsResponseApiKey()
+			apiKey.ApiKey = key
+			apiKey.MaxVersion = keyReq.MaxVersion()
+			resp.ApiKeys = append(resp.ApiKeys, apiKey)
+		}
+		return resp
+	case *kmsg.FindCoordinatorRequest:
+		// The fake broker is the coordinator of all groups and transactional ids
+		host, port := b.hostPort()
+		resp := req.ResponseKind().(*kmsg.FindCoordinatorResponse)
+		resp.Host = host
+		resp.Port = port
+		for _, coordinatorKey := range req.CoordinatorKeys {
+			coordinator := kmsg.NewFindCoordinatorResponseCoordinator()
+			coordinator.Key = coordinatorKey
+			coordinator.Host = host
+			coordinator.Port = port
+			resp.Coordinators = append(resp.Coordinators, coordinator)
+		}
+		return resp
+	case *kmsg.MetadataRequest:
+		host, port := b.hostPort()
+		resp := req.ResponseKind().(*kmsg.MetadataResponse)
+		broker := kmsg.NewMetadataResponseBroker()
+		broker.NodeID = 0
+		broker.Host = host
+		broker.Port = port
+		resp.Brokers = []kmsg.MetadataResponseBroker{broker}
+		resp.ControllerID = 0
+		for _, topicName := range b.topics {
+			topic := kmsg.NewMetadataResponseTopic()
+			topic.Topic = kmsg.StringPtr(topicName)
+			partition := kmsg.NewMetadataResponseTopicPartition()
+			partition.Leader = 0
+			partition.Replicas = []int32{0}
+			partition.ISR = []int32{0}
+			topic.Partitions = []kmsg.MetadataResponseTopicPartition{partition}
+			resp.Topics = append(resp.Topics, topic)
+		}
+		return resp
+	}
+
+	b.t.Errorf("fake broker has no handler for request key %v", req.Key())
+	return req.ResponseKind()
+}
+
+func (b *fakeBroker) hostPort() (string, int32) {
+	host, portStr, _ := net.SplitHostPort(b.listener.Addr().String())
+	port, _ := strconv.Atoi(portStr)
+	return host, int32(port)
+}
+
+// requestVersion returns the version of the first received request with the given key, or -1 if no such request has
+// been received.
+func (b *fakeBroker) requestVersion(key int16) int16 {
+	for {
+		select {
+		case req := <-b.requests:
+			if req.Key == key {
+				return req.Version
+			}
+		default:
+			return -1
+		}
+	}
+}
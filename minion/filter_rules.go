@@ -0,0 +1,102 @@
+package minion
+
+import (
+	"regexp"
+)
+
+// filterRule is a FilterRule with a compiled pattern
+type filterRule struct {
+	isAllowed bool
+	expr      *regexp.Regexp
+}
+
+func compileFilterRules(rules []FilterRule) []filterRule {
+	compiled := make([]filterRule, len(rules))
+	for i, rule := range rules {
+		// We can ignore the error because valid compilation has been validated already
+		expr, _ := compileRegex(rule.Pattern)
+		compiled[i] = filterRule{isAllowed: rule.Action == FilterRuleActionAllow, expr: expr}
+	}
+	return compiled
+}
+
+// matchFilterRules returns whether the first rule that matches the given name allows it. The second return value
+// is false if no rule matches.
+func matchFilterRules(rules []filterRule, name string) (bool, bool) {
+	for _, rule := range rules {
+		if rule.expr.MatchString(name) {
+			return rule.isAllowed, true
+		}
+	}
+	return false, false
+}
+
+// labelRules are the compiled label rules along with the names of all labels they extract.
+type labelRules struct {
+	exprs []*regexp.Regexp
+	names []string
+}
+
+func compileLabelRules(rules []LabelRule) labelRules {
+	compiled := labelRules{exprs: make([]*regexp.Regexp, len(rules))}
+	seen := make(map[string]struct{})
+	for i, rule := range rules {
+		// We can ignore the error because valid compilation has been validated already
+		expr, _ := compileRegex(rule.Pattern)
+		compiled.exprs[i] = expr
+		for _, name := range expr.SubexpNames() {
+			if _, exists := seen[name]; exists || name == "" {
+				continue
+			}
+			seen[name] = struct{}{}
+			compiled.names = append(compiled.names, name)
+		}
+	}
+	return compiled
+}
+
+// extract returns the label values for the given name in the order of the label names. The first matching rule
+// provides the values.
+func (l labelRules) extract(name string) []string {
+	values := make([]string, len(l.names))
+	for _, expr := range l.exprs {
+		match := expr.FindStringSubmatch(name)
+		if match == nil {
+			continue
+		}
+		for i, subexpName := range expr.SubexpNames() {
+			if subexpName == "" {
+				continue
+			}
+			for j, labelName := range l.names {
+				if labelName == subexpName {
+					values[j] = match[i]
+				}
+			}
+		}
+		break
+	}
+	return values
+}
+
+// TopicLabelNames returns the names of the labels that are extracted from topic names.
+func (s *Service) TopicLabelNames() []string {
+	return s.topicLabelRules.names
+}
+
+// TopicLabelValues returns the values of the labels that are extracted from the given topic name, in the order of
+// TopicLabelNames.
+func (s *Service) TopicLabelValues(topicName string) []string {
+	return s.topicLabelRules.extract(topicName)
+}
+
+// GroupLabelNames returns the names of the labels that are extracted from group ids.
+func (s *Service) GroupLabelNames() []string {
+	return s.groupLabelRules.names
+}
+
+// GroupLabelValues returns the values of the labels that are extracted from the given group id, in the order of
+// GroupLabelNames.
+func (s *Service) GroupLabelValues(groupName string) []string {
+	return s.groupLabelRules.extract(groupName)
+}
@@ -0,0 +1,108 @@
+package minion
+
+import (
+	"sync"
+)
+
+// groupRebalanceTracker counts the rebalances of consumer groups. Depending on the scrape mode rebalances are
+// derived from:
+//   - offsetsTopic: the generation of the group metadata records, which is bumped by each completed rebalance
+//   - adminApi: the group state transitions into a rebalancing state between subsequent scrapes. Rebalances that
+//     start and complete between two scrapes are not observed.
+type groupRebalanceTracker struct {
+	mutex       sync.Mutex
+	states      map[string]string
+	generations map[string]int32
+	rebalances  map[string]float64
+}
+
+func newGroupRebalanceTracker() *groupRebalanceTracker {
+	return &groupRebalanceTracker{
+		states:      make(map[string]string),
+		generations: make(map[string]int32),
+		rebalances:  make(map[string]float64),
+	}
+}
+
+func isRebalancingState(state string) bool {
+	return state == "PreparingRebalance" || state == "CompletingRebalance"
+}
+
+// observeStates records the current states of all groups. Groups that are not part of the given states are
+// forgotten.
+func (t *groupRebalanceTracker) observeStates(states map[string]string) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	for groupID, state := range states {
+		previousState, exists := t.states[groupID]
+		if !exists {
+			t.rebalances[groupID] = 0
+		} else if isRebalancingState(state) && !isRebalancingState(previousState) {
+			t.rebalances[groupID]++
+		}
+		t.states[groupID] = state
+	}
+
+	for groupID := range t.states {
+		if _, exists := states[groupID]; !exists {
+			delete(t.states, groupID)
+			delete(t.rebalances, groupID)
+		}
+	}
+}
+
+// observeGeneration records the generation of a group metadata record.
+func (t *groupRebalanceTracker) observeGeneration(groupID string, generation int32) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	previousGeneration, exists := t.generations[groupID]
+	if !exists {
+		t.rebalances[groupID] = 0
+	} else if generation > previousGeneration {
+		t.rebalances[groupID] += float64(generation - previousGeneration)
+	}
+	t.generations[groupID] = generation
+}
+
+// deleteGroup forgets a group, e.g. because its group metadata has been deleted.
+func (t *groupRebalanceTracker) deleteGroup(groupID string) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	delete(t.generations, groupID)
+	delete(t.rebalances, groupID)
+}
+
+func (t *groupRebalanceTracker) getRebalances() map[string]float64 {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	rebalances := make(map[string]float64, len(t.rebalances))
+	for groupID, count := range t.rebalances {
+		rebalances[groupID] = count
+	}
+	return rebalances
+}
+
+// GetGroupRebalances returns the number of rebalances per group, that have been observed since kminion started.
+// In the adminApi scrape mode the given described groups are used to detect new rebalances.
+func (s *Service) GetGroupRebalances(groups []DescribeConsumerGroupsResponse) map[string]float64 {
+	if s.Cfg.ConsumerGroups.ScrapeMode == ConsumerGroupScrapeModeOffsetsTopic {
+		if !s.storage.isReady() {
+			return map[string]float64{}
+		}
+		return s.groupRebalances.getRebalances()
+	}
+
+	states := make(map[string]string)
+	for _, shard := range groups {
+		for _, group := range shard.Groups.Groups {
+			states[group.Group] = group.State
+		}
+	}
+	s.groupRebalances.observeStates(states)
+
+	return s.groupRebalances.getRebalances()
+}
@@ -0,0 +1,77 @@
+package minion
+
+import (
+	"sync"
+	"time"
+)
+
+type lagSample struct {
+	lag        int64
+	observedAt time.Time
+}
+
+// LagVelocity is the rate at which a group's lag on a topic changes. A positive velocity means that the group is
+// falling behind, a negative velocity that it is catching up.
+type LagVelocity struct {
+	Lag               int64
+	MessagesPerSecond float64
+}
+
+// lagHistoryTracker keeps the topic lags of each group that have been observed within the configured window, so
+// that the rate at which the lag changes can be calculated.
+type lagHistoryTracker struct {
+	mutex   sync.Mutex
+	samples map[string]map[string][]lagSample
+}
+
+func newLagHistoryTracker() *lagHistoryTracker {
+	return &lagHistoryTracker{
+		samples: make(map[string]map[string][]lagSample),
+	}
+}
+
+// observe adds the given lags, indexed by group id and topic name, to the history and returns the lag velocity of
+// all group topics that have been observed before within the window. Group topics that are not part of the given
+// lags are forgotten.
+func (t *lagHistoryTracker) observe(lags map[string]map[string]int64, window time.Duration, now time.Time) map[string]map[string]LagVelocity {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	samples := make(map[string]map[string][]lagSample, len(lags))
+	velocities := make(map[string]map[string]LagVelocity)
+	for groupID, topics := range lags {
+		samples[groupID] = make(map[string][]lagSample, len(topics))
+		for topicName, lag := range topics {
+			var topicSamples []lagSample
+			for _, sample := range t.samples[groupID][topicName] {
+				if now.Sub(sample.observedAt) <= window {
+					topicSamples = append(topicSamples, sample)
+				}
+			}
+
+			if len(topicSamples) > 0 {
+				oldest := topicSamples[0]
+				if elapsed := now.Sub(oldest.observedAt).Seconds(); elapsed > 0 {
+					if _, exists := velocities[groupID]; !exists {
+						velocities[groupID] = make(map[string]LagVelocity)
+					}
+					velocities[groupID][topicName] = LagVelocity{
+						Lag:               lag,
+						MessagesPerSecond: float64(lag-oldest.lag) / elapsed,
+					}
+				}
+			}
+
+			samples[groupID][topicName] = append(topicSamples, lagSample{lag: lag, observedAt: now})
+		}
+	}
+	t.samples = samples
+
+	return velocities
+}
+
+// GetLagVelocities returns the rate at which the lag of each group on each topic changed within the configured
+// window. The given lags, indexed by group id and topic name, are added to the history.
+func (s *Service) GetLagVelocities(lags map[string]map[string]int64) map[string]map[string]LagVelocity {
+	return s.lagHistory.observe(lags, s.Cfg.ConsumerGroups.LagVelocityWindow, time.Now())
+}
@@ -0,0 +1,111 @@
+package minion
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/twmb/franz-go/pkg/kerr"
+)
+
+// lastStableOffsetState is the state of a partition at the time its last stable offset advanced the last time.
+type lastStableOffsetState struct {
+	lastStableOffset int64
+	highWaterMark    int64
+	advancedAt       time.Time
+}
+
+// lastStableOffsetTracker detects partitions whose last stable offset does not advance while their high water mark
+// does, by comparing the offsets between subsequent scrapes. This is the symptom of a hanging transaction that
+// blocks read_committed consumers.
+type lastStableOffsetTracker struct {
+	mutex      sync.Mutex
+	partitions map[string]map[int32]*lastStableOffsetState
+}
+
+func newLastStableOffsetTracker() *lastStableOffsetTracker {
+	return &lastStableOffsetTracker{
+		partitions: make(map[string]map[int32]*lastStableOffsetState),
+	}
+}
+
+// observe updates the state of a partition and returns for how long its last stable offset has been stuck. Zero is
+// returned if the last stable offset has caught up with the high water mark or if the high water mark hasn't
+// advanced either.
+func (t *lastStableOffsetTracker) observe(topicName string, partitionID int32, lastStableOffset, highWaterMark int64, now time.Time) time.Duration {
+	if _, exists := t.partitions[topicName]; !exists {
+		t.partitions[topicName] = make(map[int32]*lastStableOffsetState)
+	}
+	state, exists := t.partitions[topicName][partitionID]
+	if !exists || lastStableOffset != state.lastStableOffset || lastStableOffset >= highWaterMark {
+		t.partitions[topicName][partitionID] = &lastStableOffsetState{
+			lastStableOffset: lastStableOffset,
+			highWaterMark:    highWaterMark,
+			advancedAt:       now,
+		}
+		return 0
+	}
+
+	if highWaterMark <= state.highWaterMark {
+		return 0
+	}
+	return now.Sub(state.advancedAt)
+}
+
+// GetStuckLastStableOffsets returns the partitions whose last stable offset has not advanced for at least the
+// configured duration while their high water mark has, along with the duration since the last stable offset
+// advanced the last time.
+func (s *Service) GetStuckLastStableOffsets(ctx context.Context) (map[string]map[int32]time.Duration, error) {
+	highWaterMarks, err := s.ListOffsetsCached(ctx, -1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch high water marks: %w", err)
+	}
+	lastStableOffsets, err := s.ListLastStableOffsets(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch last stable offsets: %w", err)
+	}
+
+	highWaterMarksByTopic := make(map[string]map[int32]int64)
+	for _, topic := range highWaterMarks.Topics {
+		highWaterMarksByTopic[topic.Topic] = make(map[int32]int64)
+		for _, partition := range topic.Partitions {
+			if kerr.ErrorForCode(partition.ErrorCode) != nil {
+				continue
+			}
+			highWaterMarksByTopic[topic.Topic][partition.Partition] = partition.Offset
+		}
+	}
+
+	s.lastStableOffsets.mutex.Lock()
+	defer s.lastStableOffsets.mutex.Unlock()
+
+	now := time.Now()
+	stuckAfter := s.Cfg.Transactions.LastStableOffsetStuckAfter
+	stuck := make(map[string]map[int32]time.Duration)
+	for _, topic := range lastStableOffsets.Topics {
+		for _, partition := range topic.Partitions {
+			highWaterMark, exists := highWaterMarksByTopic[topic.Topic][partition.Partition]
+			if kerr.ErrorForCode(partition.ErrorCode) != nil || !exists {
+				continue
+			}
+			stuckFor := s.lastStableOffsets.observe(topic.Topic, partition.Partition, partition.Offset, highWaterMark, now)
+			if stuckFor < stuckAfter {
+				continue
+			}
+			if _, exists := stuck[topic.Topic]; !exists {
+				stuck[topic.Topic] = make(map[int32]time.Duration)
+			}
+			stuck[topic.Topic][partition.Partition] = stuckFor
+		}
+	}
+
+	// Forget deleted topics
+	for topicName := range s.lastStableOffsets.partitions {
+		if _, exists := highWaterMarksByTopic[topicName]; !exists {
+			delete(s.lastStableOffsets.partitions, topicName)
+		}
+	}
+
+	return stuck, nil
+}
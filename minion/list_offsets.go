@@ -38,6 +38,18 @@ func (s *Service) ListOffsetsCached(ctx context.Context, timestamp int64) (*kmsg
 
 // ListOffsets fetches the low (timestamp: -2) or high water mark (timestamp: -1) for all topic partitions
 func (s *Service) ListOffsets(ctx context.Context, timestamp int64) (*kmsg.ListOffsetsResponse, error) {
+	return s.listOffsets(ctx, timestamp, 0)
+}
+
+// ListLastStableOffsets fetches the last stable offset for all topic partitions. The last stable offset is the
+// offset up to which read_committed consumers can consume, it does not advance past open transactions.
+func (s *Service) ListLastStableOffsets(ctx context.Context) (*kmsg.ListOffsetsResponse, error) {
+	return s.listOffsets(ctx, -1, 1)
+}
+
+// listOffsets fetches the offsets for the given timestamp with the given isolation level (0: read_uncommitted,
+// 1: read_committed) for all topic partitions.
+func (s *Service) listOffsets(ctx context.Context, timestamp int64, isolationLevel int8) (*kmsg.ListOffsetsResponse, error) {
 	metadata, err := s.GetMetadataCached(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list consumer groups: %w", err)
@@ -60,9 +72,12 @@ func (s *Service) ListOffsets(ctx context.Context, timestamp int64) (*kmsg.ListO
 	}
 
 	req := kmsg.NewListOffsetsRequest()
+	req.IsolationLevel = isolationLevel
 	req.Topics = topicReqs
 
-	res, err := req.RequestWith(ctx, s.client)
+	res, err := requestWithRetries(ctx, s.Cfg.Requests.Offsets, func(ctx context.Context) (*kmsg.ListOffsetsResponse, error) {
+		return req.RequestWith(ctx, s.client)
+	})
 	if err != nil {
 		return res, err
 	}
@@ -0,0 +1,76 @@
+package minion
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twmb/franz-go/pkg/kerr"
+	"github.com/twmb/franz-go/pkg/kmsg"
+	"go.uber.org/zap"
+	"golang.org/x/sync/singleflight"
+)
+
+// listOffsetsMinVersions are the ListOffsets versions that are required by the special timestamps
+var listOffsetsMinVersions = map[int64]int16{
+	-3: 7, // max timestamp
+	-4: 8, // earliest local
+}
+
+// listOffsets lists the offsets of the given timestamp against a fake broker, which rejects special timestamps that
+// are sent with a version that doesn't support them.
+func listOffsets(t *testing.T, timestamp int64) *kmsg.ListOffsetsResponse {
+	broker := newFakeBroker(t, map[int16]func(req kmsg.Request) kmsg.Response{
+		kmsg.ListOffsets.Int16(): func(req kmsg.Request) kmsg.Response {
+			listReq := req.(*kmsg.ListOffsetsRequest)
+			resp := req.ResponseKind().(*kmsg.ListOffsetsResponse)
+			for _, topicReq := range listReq.Topics {
+				topic := kmsg.NewListOffsetsResponseTopic()
+				topic.Topic = topicReq.Topic
+				for _, partitionReq := range topicReq.Partitions {
+					partition := kmsg.NewListOffsetsResponseTopicPartition()
+					partition.Partition = partitionReq.Partition
+					partition.Offset = 99
+					partition.Timestamp = 1700000000000
+					if minVersion, ok := listOffsetsMinVersions[partitionReq.Timestamp]; ok && listReq.Version < minVersion {
+						partition.ErrorCode = kerr.UnsupportedVersion.Code
+					}
+					topic.Partitions = append(topic.Partitions, partition)
+				}
+				resp.Topics = append(resp.Topics, topic)
+			}
+			return resp
+		},
+	})
+	broker.topics = []string{"orders"}
+	svc := &Service{
+		logger:       zap.NewNop(),
+		client:       broker.newClient(),
+		requestGroup: &singleflight.Group{},
+		cache:        make(map[string]interface{}),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	ctx = context.WithValue(ctx, "requestId", "test")
+
+	res, err := svc.ListOffsets(ctx, timestamp)
+	require.NoError(t, err)
+	require.Len(t, res.Topics, 1)
+	require.Len(t, res.Topics[0].Partitions, 1)
+	return res
+}
+
+func TestListOffsetsEarliestLocal(t *testing.T) {
+	res := listOffsets(t, -4)
+	assert.Equal(t, int16(0), res.Topics[0].Partitions[0].ErrorCode)
+	assert.Equal(t, int64(99), res.Topics[0].Partitions[0].Offset)
+}
+
+func TestListOffsetsMaxTimestamp(t *testing.T) {
+	res := listOffsets(t, -3)
+	assert.Equal(t, int16(0), res.Topics[0].Partitions[0].ErrorCode)
+	assert.Equal(t, int64(1700000000000), res.Topics[0].Partitions[0].Timestamp)
+}
@@ -2,7 +2,9 @@ package minion
 
 import (
 	"context"
+	"time"
 
+	"github.com/twmb/franz-go/pkg/kerr"
 	"github.com/twmb/franz-go/pkg/kgo"
 	"github.com/twmb/franz-go/pkg/kmsg"
 )
@@ -13,10 +15,30 @@ type LogDirResponseShard struct {
 	LogDirs *kmsg.DescribeLogDirsResponse
 }
 
+func (s *Service) DescribeLogDirsCached(ctx context.Context) []LogDirResponseShard {
+	reqId := ctx.Value("requestId").(string)
+	key := "describe-log-dirs-" + reqId
+
+	if cachedRes, exists := s.getCachedItem(key); exists {
+		return cachedRes.([]LogDirResponseShard)
+	}
+
+	res, _, _ := s.requestGroup.Do(key, func() (interface{}, error) {
+		logDirs := s.DescribeLogDirs(ctx)
+		s.setCachedItem(key, logDirs, 120*time.Second)
+
+		return logDirs, nil
+	})
+
+	return res.([]LogDirResponseShard)
+}
+
 func (s *Service) DescribeLogDirs(ctx context.Context) []LogDirResponseShard {
 	req := kmsg.NewDescribeLogDirsRequest()
 	req.Topics = nil // Describe all topics
-	responses := s.client.RequestSharded(ctx, &req)
+	responses, _ := requestWithRetries(ctx, s.Cfg.Requests.LogDirs, func(ctx context.Context) ([]kgo.ResponseShard, error) {
+		return shardedResponseWithError(s.client.RequestSharded(ctx, &req))
+	})
 
 	res := make([]LogDirResponseShard, len(responses))
 	for i, responseShard := range responses {
@@ -25,8 +47,14 @@ func (s *Service) DescribeLogDirs(ctx context.Context) []LogDirResponseShard {
 			logDirs = &kmsg.DescribeLogDirsResponse{}
 		}
 
+		// Since v3 errors that affect the whole response are reported by a top-level error code
+		err := responseShard.Err
+		if err == nil {
+			err = kerr.ErrorForCode(logDirs.ErrorCode)
+		}
+
 		res[i] = LogDirResponseShard{
-			Err:     responseShard.Err,
+			Err:     err,
 			Broker:  responseShard.Meta,
 			LogDirs: logDirs,
 		}
@@ -0,0 +1,37 @@
+package minion
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twmb/franz-go/pkg/kmsg"
+)
+
+func TestDescribeLogDirsReportsCapacity(t *testing.T) {
+	broker := newFakeBroker(t, map[int16]func(req kmsg.Request) kmsg.Response{
+		kmsg.DescribeLogDirs.Int16(): func(req kmsg.Request) kmsg.Response {
+			dir := kmsg.NewDescribeLogDirsResponseDir()
+			dir.Dir = "/var/lib/kafka"
+			dir.TotalBytes = 1000
+			dir.UsableBytes = 400
+
+			resp := req.ResponseKind().(*kmsg.DescribeLogDirsResponse)
+			resp.Dirs = []kmsg.DescribeLogDirsResponseDir{dir}
+			return resp
+		},
+	})
+	svc := &Service{client: broker.newClient()}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	shards := svc.DescribeLogDirs(ctx)
+	require.Len(t, shards, 1)
+	require.NoError(t, shards[0].Err)
+	require.Len(t, shards[0].LogDirs.Dirs, 1)
+	assert.Equal(t, int64(1000), shards[0].LogDirs.Dirs[0].TotalBytes)
+	assert.Equal(t, int64(400), shards[0].LogDirs.Dirs[0].UsableBytes)
+}
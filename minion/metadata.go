@@ -37,7 +37,9 @@ func (s *Service) GetMetadata(ctx context.Context) (*kmsg.MetadataResponse, erro
 	req := kmsg.NewMetadataRequest()
 	req.Topics = nil
 
-	res, err := req.RequestWith(ctx, s.client)
+	res, err := requestWithRetries(ctx, s.Cfg.Requests.Metadata, func(ctx context.Context) (*kmsg.MetadataResponse, error) {
+		return req.RequestWith(ctx, s.client)
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to request metadata: %w", err)
 	}
@@ -116,6 +116,10 @@ func (s *Service) checkIfConsumerLagIsCaughtUp(ctx context.Context) {
 		var partitionsLagging []laggingParition
 		totalLag := int64(0)
 		for _, partition := range topicRes.Partitions {
+			if !s.Cfg.ConsumerGroups.isOffsetsTopicPartitionAssigned(partition.Partition) {
+				// The partition is consumed by another kminion instance
+				continue
+			}
 			err := kerr.ErrorForCode(partition.ErrorCode)
 			if err != nil {
 				s.logger.Warn("failed to check if consumer lag on offsets metadataReqTopic is caught up because high "+
@@ -204,6 +208,8 @@ func (s *Service) decodeOffsetMetadata(record *kgo.Record) error {
 	}
 
 	if record.Value == nil {
+		// Tombstone - The group has been deleted
+		s.groupRebalances.deleteGroup(metadataKey.Group)
 		return nil
 	}
 	metadataValue := kmsg.NewGroupMetadataValue()
@@ -212,6 +218,7 @@ func (s *Service) decodeOffsetMetadata(record *kgo.Record) error {
 		childLogger.Warn("failed to decode offset metadata value", zap.Error(err))
 		return fmt.Errorf("failed to decode offset metadata value: %w", err)
 	}
+	s.groupRebalances.observeGeneration(metadataKey.Group, metadataValue.Generation)
 
 	return nil
 }
@@ -0,0 +1,68 @@
+package minion
+
+import (
+	"sync"
+)
+
+// offsetRewindTracker counts how often the committed offset of a group's partition moved backwards between
+// subsequent scrapes in the adminApi scrape mode. In the offsetsTopic scrape mode rewinds are counted for each
+// offset commit by the storage instead.
+type offsetRewindTracker struct {
+	mutex   sync.Mutex
+	offsets map[string]map[string]map[int32]int64
+	rewinds map[string]map[string]float64
+}
+
+func newOffsetRewindTracker() *offsetRewindTracker {
+	return &offsetRewindTracker{
+		offsets: make(map[string]map[string]map[int32]int64),
+		rewinds: make(map[string]map[string]float64),
+	}
+}
+
+// observe compares the given committed offsets, indexed by group id, topic name and partition id, with the
+// previously observed offsets. Groups that are not part of the given offsets are forgotten.
+func (t *offsetRewindTracker) observe(offsets map[string]map[string]map[int32]int64) map[string]map[string]float64 {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	for groupID, topics := range offsets {
+		if _, exists := t.rewinds[groupID]; !exists {
+			t.rewinds[groupID] = make(map[string]float64)
+		}
+		for topicName, partitions := range topics {
+			if _, exists := t.rewinds[groupID][topicName]; !exists {
+				t.rewinds[groupID][topicName] = 0
+			}
+			for partitionID, offset := range partitions {
+				previousOffset, exists := t.offsets[groupID][topicName][partitionID]
+				if exists && offset < previousOffset {
+					t.rewinds[groupID][topicName]++
+				}
+			}
+		}
+	}
+
+	for groupID := range t.rewinds {
+		if _, exists := offsets[groupID]; !exists {
+			delete(t.rewinds, groupID)
+		}
+	}
+	t.offsets = offsets
+
+	rewinds := make(map[string]map[string]float64, len(t.rewinds))
+	for groupID, topics := range t.rewinds {
+		rewinds[groupID] = make(map[string]float64, len(topics))
+		for topicName, count := range topics {
+			rewinds[groupID][topicName] = count
+		}
+	}
+	return rewinds
+}
+
+// GetOffsetRewinds returns the number of times the committed offsets of each group and topic moved backwards since
+// kminion started. The given committed offsets, indexed by group id, topic name and partition id, are compared with
+// the offsets of the previous call. It is only used in the adminApi scrape mode.
+func (s *Service) GetOffsetRewinds(offsets map[string]map[string]map[int32]int64) map[string]map[string]float64 {
+	return s.offsetRewinds.observe(offsets)
+}
@@ -0,0 +1,64 @@
+package minion
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/twmb/franz-go/pkg/kerr"
+	"github.com/twmb/franz-go/pkg/kgo"
+	"github.com/twmb/franz-go/pkg/kmsg"
+	"go.uber.org/zap"
+
+	"github.com/cloudhut/kminion/v2/kafka"
+)
+
+// isOffsetsTopicPartitionAssigned returns whether the given partition of the __consumer_offsets topic shall be
+// consumed by this kminion instance according to the configured shard.
+func (c *ConsumerGroupConfig) isOffsetsTopicPartitionAssigned(partitionID int32) bool {
+	if c.OffsetsTopicShardCount <= 1 {
+		return true
+	}
+	return int(partitionID)%c.OffsetsTopicShardCount == c.OffsetsTopicShardIndex
+}
+
+// offsetsTopicShardPartitions returns the partitions of the __consumer_offsets topic that are assigned to the
+// configured shard, along with the offset to start consuming from. A separate client is used to fetch the number of
+// partitions, because the partitions to consume must be known when the consuming client is created.
+func offsetsTopicShardPartitions(ctx context.Context, logger *zap.Logger, kafkaSvc *kafka.Service, cfg ConsumerGroupConfig) (map[int32]kgo.Offset, error) {
+	client, err := kafkaSvc.CreateAndTestClient(ctx, logger, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create kafka client: %w", err)
+	}
+	defer client.Close()
+
+	topicName := "__consumer_offsets"
+	metadataReqTopic := kmsg.NewMetadataRequestTopic()
+	metadataReqTopic.Topic = &topicName
+	metadataReq := kmsg.NewMetadataRequest()
+	metadataReq.Topics = []kmsg.MetadataRequestTopic{metadataReqTopic}
+	res, err := metadataReq.RequestWith(ctx, client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to request metadata of the offsets topic: %w", err)
+	}
+	if len(res.Topics) != 1 {
+		return nil, fmt.Errorf("expected exactly one topic in the metadata response, but got %v", len(res.Topics))
+	}
+	err = kerr.ErrorForCode(res.Topics[0].ErrorCode)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get metadata of the offsets topic. Inner kafka error: %w", err)
+	}
+
+	partitions := make(map[int32]kgo.Offset)
+	for _, partition := range res.Topics[0].Partitions {
+		if cfg.isOffsetsTopicPartitionAssigned(partition.Partition) {
+			partitions[partition.Partition] = kgo.NewOffset().AtStart()
+		}
+	}
+	logger.Info("consuming a shard of the offsets topic",
+		zap.Int("shard_index", cfg.OffsetsTopicShardIndex),
+		zap.Int("shard_count", cfg.OffsetsTopicShardCount),
+		zap.Int("assigned_partitions", len(partitions)),
+		zap.Int("total_partitions", len(res.Topics[0].Partitions)))
+
+	return partitions, nil
+}
@@ -0,0 +1,186 @@
+package minion
+
+import (
+	"context"
+	"encoding/binary"
+	"sync"
+	"time"
+
+	"github.com/twmb/franz-go/pkg/kerr"
+	"github.com/twmb/franz-go/pkg/kmsg"
+	"go.uber.org/zap"
+)
+
+// recordTimestampFetchMaxBytes is the maximum number of bytes fetched per partition. We only need the first record
+// batch, which brokers return in full even if it's larger than this limit.
+const recordTimestampFetchMaxBytes = 4 * 1024
+
+// recordTimestampFetchMaxBytesTotal caps the maximum number of bytes of a whole fetch request. It matches the
+// default of the consumer setting fetch.max.bytes.
+const recordTimestampFetchMaxBytesTotal = 50 * 1024 * 1024
+
+// TopicPartitionOffset identifies a single record
+type TopicPartitionOffset struct {
+	Topic     string
+	Partition int32
+	Offset    int64
+}
+
+// GetRecordTimestamps returns the timestamps of the records at the given offsets. The timestamp of a record is
+// approximated by the timestamp of the record batch that contains it. Records that could not be fetched (e.g.
+// because they have been deleted by retention already) are missing in the returned map.
+func (s *Service) GetRecordTimestamps(ctx context.Context, offsets []TopicPartitionOffset) (map[TopicPartitionOffset]time.Time, error) {
+	metadata, err := s.GetMetadataCached(ctx)
+	if err != nil {
+		return nil, err
+	}
+	leaders := make(map[string]map[int32]int32)
+	for _, topic := range metadata.Topics {
+		partitionLeaders := make(map[int32]int32, len(topic.Partitions))
+		for _, partition := range topic.Partitions {
+			partitionLeaders[partition.Partition] = partition.Leader
+		}
+		leaders[*topic.Topic] = partitionLeaders
+	}
+
+	// A fetch request can contain each partition only once, hence multiple offsets of the same partition are fetched
+	// in subsequent rounds. Within each round one request is sent to each partition leader.
+	type round = map[int32][]TopicPartitionOffset // leader id -> offsets
+	var rounds []round
+	seen := make(map[TopicPartitionOffset]struct{}, len(offsets))
+	offsetsPerPartition := make(map[string]map[int32]int)
+	for _, offset := range offsets {
+		if _, exists := seen[offset]; exists {
+			continue
+		}
+		seen[offset] = struct{}{}
+
+		leader, exists := leaders[offset.Topic][offset.Partition]
+		if !exists || leader < 0 {
+			continue
+		}
+		if offsetsPerPartition[offset.Topic] == nil {
+			offsetsPerPartition[offset.Topic] = make(map[int32]int)
+		}
+		roundIndex := offsetsPerPartition[offset.Topic][offset.Partition]
+		offsetsPerPartition[offset.Topic][offset.Partition]++
+		if roundIndex == len(rounds) {
+			rounds = append(rounds, make(round))
+		}
+		rounds[roundIndex][leader] = append(rounds[roundIndex][leader], offset)
+	}
+
+	timestamps := make(map[TopicPartitionOffset]time.Time, len(seen))
+	var mutex sync.Mutex
+	for _, r := range rounds {
+		wg := sync.WaitGroup{}
+		for leader, leaderOffsets := range r {
+			wg.Add(1)
+			go func(leader int32, leaderOffsets []TopicPartitionOffset) {
+				defer wg.Done()
+				res, err := s.fetchRecordTimestamps(ctx, leader, leaderOffsets)
+				if err != nil {
+					s.logger.Warn("failed to fetch record timestamps from broker",
+						zap.Int32("broker_id", leader),
+						zap.Error(err))
+					return
+				}
+				mutex.Lock()
+				for offset, timestamp := range res {
+					timestamps[offset] = timestamp
+				}
+				mutex.Unlock()
+			}(leader, leaderOffsets)
+		}
+		wg.Wait()
+	}
+
+	return timestamps, nil
+}
+
+// fetchRecordTimestamps sends a single fetch request for the given offsets, which must all be led by the given
+// broker and contain each partition only once.
+func (s *Service) fetchRecordTimestamps(ctx context.Context, leader int32, offsets []TopicPartitionOffset) (map[TopicPartitionOffset]time.Time, error) {
+	requestedOffsets := make(map[string]map[int32]TopicPartitionOffset)
+	req := kmsg.NewFetchRequest()
+	req.ReplicaID = -1
+	req.MaxWaitMillis = 0
+	req.MinBytes = 1
+	req.MaxBytes = int32(min(int64(len(offsets))*recordTimestampFetchMaxBytes, recordTimestampFetchMaxBytesTotal))
+	req.IsolationLevel = 0
+
+	topicIndexes := make(map[string]int)
+	for _, offset := range offsets {
+		if requestedOffsets[offset.Topic] == nil {
+			requestedOffsets[offset.Topic] = make(map[int32]TopicPartitionOffset)
+		}
+		requestedOffsets[offset.Topic][offset.Partition] = offset
+
+		index, exists := topicIndexes[offset.Topic]
+		if !exists {
+			topic := kmsg.NewFetchRequestTopic()
+			topic.Topic = offset.Topic
+			req.Topics = append(req.Topics, topic)
+			index = len(req.Topics) - 1
+			topicIndexes[offset.Topic] = index
+		}
+		partition := kmsg.NewFetchRequestTopicPartition()
+		partition.Partition = offset.Partition
+		partition.FetchOffset = offset.Offset
+		partition.PartitionMaxBytes = recordTimestampFetchMaxBytes
+		req.Topics[index].Partitions = append(req.Topics[index].Partitions, partition)
+	}
+
+	res, err := req.RequestWith(ctx, s.client.Broker(int(leader)))
+	if err != nil {
+		return nil, err
+	}
+	if err := kerr.ErrorForCode(res.ErrorCode); err != nil {
+		return nil, err
+	}
+
+	timestamps := make(map[TopicPartitionOffset]time.Time, len(offsets))
+	for _, topic := range res.Topics {
+		for _, partition := range topic.Partitions {
+			offset, exists := requestedOffsets[topic.Topic][partition.Partition]
+			if !exists || partition.ErrorCode != 0 {
+				continue
+			}
+			timestamp, ok := firstBatchTimestamp(partition.RecordBatches)
+			if !ok {
+				continue
+			}
+			timestamps[offset] = timestamp
+		}
+	}
+
+	return timestamps, nil
+}
+
+// firstBatchTimestamp returns the timestamp of the first record batch in the given fetch response data. If the
+// batch uses LogAppendTime, all records carry the batch's max timestamp, otherwise the first record's timestamp is
+// returned.
+func firstBatchTimestamp(data []byte) (time.Time, bool) {
+	// Only the v2 format (magic byte 2) contains a record batch. The magic byte is located after the batch's base
+	// offset (8 bytes), length (4 bytes) and partition leader epoch (4 bytes).
+	if len(data) < 17 || data[16] != 2 {
+		return time.Time{}, false
+	}
+
+	// The response may contain multiple batches, the last of which may be truncated
+	batchSize := 12 + int(binary.BigEndian.Uint32(data[8:12]))
+	if batchSize > len(data) {
+		return time.Time{}, false
+	}
+
+	var batch kmsg.RecordBatch
+	if err := batch.ReadFrom(data[:batchSize]); err != nil {
+		return time.Time{}, false
+	}
+
+	const timestampTypeLogAppendTime = 0b0000_1000
+	if batch.Attributes&timestampTypeLogAppendTime != 0 {
+		return time.UnixMilli(batch.MaxTimestamp), true
+	}
+	return time.UnixMilli(batch.FirstTimestamp), true
+}
@@ -0,0 +1,43 @@
+package minion
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twmb/franz-go/pkg/kmsg"
+	"go.uber.org/zap"
+)
+
+func TestFetchRecordTimestampsMaxBytes(t *testing.T) {
+	requests := make(chan *kmsg.FetchRequest, 1)
+	broker := newFakeBroker(t, map[int16]func(req kmsg.Request) kmsg.Response{
+		kmsg.Fetch.Int16(): func(req kmsg.Request) kmsg.Response {
+			requests <- req.(*kmsg.FetchRequest)
+			return req.ResponseKind()
+		},
+	})
+	svc := &Service{
+		logger: zap.NewNop(),
+		client: broker.newClient(),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// The per partition limits of that many partitions exceed the total limit, which must not overflow
+	offsets := make([]TopicPartitionOffset, 20000)
+	for i := range offsets {
+		offsets[i] = TopicPartitionOffset{Topic: "orders", Partition: int32(i), Offset: 10}
+	}
+	_, err := svc.fetchRecordTimestamps(ctx, 0, offsets)
+	require.NoError(t, err)
+
+	req := <-requests
+	assert.Equal(t, int32(recordTimestampFetchMaxBytesTotal), req.MaxBytes)
+	require.Len(t, req.Topics, 1)
+	require.Len(t, req.Topics[0].Partitions, len(offsets))
+	assert.Equal(t, int32(recordTimestampFetchMaxBytes), req.Topics[0].Partitions[0].PartitionMaxBytes)
+}
@@ -0,0 +1,51 @@
+package minion
+
+import (
+	"context"
+	"time"
+
+	"github.com/twmb/franz-go/pkg/kgo"
+)
+
+// requestWithRetries calls request until it succeeds or the configured retries are exhausted. Each attempt is bounded
+// by the configured timeout and failed attempts are retried after the configured backoff. The result of the last
+// attempt is returned along with its error, because sharded requests may still contain the responses of some brokers.
+func requestWithRetries[T any](ctx context.Context, cfg RequestConfig, request func(ctx context.Context) (T, error)) (T, error) {
+	var res T
+	var err error
+	for attempt := 0; attempt <= cfg.Retries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return res, err
+			case <-time.After(cfg.Backoff):
+			}
+		}
+
+		res, err = requestAttempt(ctx, cfg.Timeout, request)
+		if err == nil {
+			return res, nil
+		}
+	}
+	return res, err
+}
+
+func requestAttempt[T any](ctx context.Context, timeout time.Duration, request func(ctx context.Context) (T, error)) (T, error) {
+	if timeout <= 0 {
+		return request(ctx)
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	return request(ctx)
+}
+
+// shardedResponseWithError returns the responses along with the first error of any shard, so that sharded requests
+// are retried if any of the brokers failed to respond.
+func shardedResponseWithError(responses []kgo.ResponseShard) ([]kgo.ResponseShard, error) {
+	for _, response := range responses {
+		if response.Err != nil {
+			return responses, response.Err
+		}
+	}
+	return responses, nil
+}
@@ -10,6 +10,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/twmb/franz-go/pkg/kgo"
 	"github.com/twmb/franz-go/pkg/kmsg"
 	"github.com/twmb/franz-go/pkg/kversion"
@@ -30,11 +31,32 @@ type Service struct {
 
 	AllowedGroupIDsExpr []*regexp.Regexp
 	IgnoredGroupIDsExpr []*regexp.Regexp
-	AllowedTopicsExpr   []*regexp.Regexp
-	IgnoredTopicsExpr   []*regexp.Regexp
 
-	client  *kgo.Client
-	storage *Storage
+	PartitionGranularityGroupsExpr []*regexp.Regexp
+	AllowedTopicsExpr              []*regexp.Regexp
+	IgnoredTopicsExpr              []*regexp.Regexp
+
+	AllowedLogDirTopicsExpr []*regexp.Regexp
+	IgnoredLogDirTopicsExpr []*regexp.Regexp
+
+	topicConfigBaselines []topicConfigBaseline
+	lagThresholds        []lagThreshold
+	topicFilterRules     []filterRule
+	groupFilterRules     []filterRule
+	topicLabelRules      labelRules
+	groupLabelRules      labelRules
+
+	kafkaSvc *kafka.Service
+	client   *kgo.Client
+	storage  *Storage
+
+	groupRebalances   *groupRebalanceTracker
+	topicEvents       *topicEventTracker
+	lastStableOffsets *lastStableOffsetTracker
+	topicThroughput   *topicThroughputTracker
+	offsetRewinds     *offsetRewindTracker
+	lagHistory        *lagHistoryTracker
+	brokerProbes      *brokerProbeTracker
 }
 
 func NewService(cfg Config, logger *zap.Logger, kafkaSvc *kafka.Service, metricsNamespace string, ctx context.Context) (*Service, error) {
@@ -45,13 +67,29 @@ func NewService(cfg Config, logger *zap.Logger, kafkaSvc *kafka.Service, metrics
 
 	// Kafka client
 	minionHooks := newMinionClientHooks(logger.Named("kafka_hooks"), metricsNamespace)
+	throttleMetricsHooks := kafka.NewThrottleMetricsHooks(metricsNamespace, "kafka", prometheus.DefaultRegisterer)
+	metadataMetricsHooks := kafka.NewMetadataMetricsHooks(metricsNamespace, "kafka", prometheus.DefaultRegisterer)
+	saslMetricsHooks := kafka.NewSASLMetricsHooks(metricsNamespace, "kafka", prometheus.DefaultRegisterer)
+	connectionMetricsHooks := kafka.NewConnectionMetricsHooks(metricsNamespace, "kafka", prometheus.DefaultRegisterer)
 	kgoOpts := []kgo.Opt{
-		kgo.WithHooks(minionHooks),
+		kgo.WithHooks(minionHooks, throttleMetricsHooks, metadataMetricsHooks, saslMetricsHooks, connectionMetricsHooks),
+	}
+	if kafkaSvc.RequestMetricsEnabled() {
+		requestMetricsHooks := kafka.NewRequestMetricsHooks(metricsNamespace, "kafka", kafkaSvc.RequestMetricsHistogramType(),
+			prometheus.DefaultRegisterer)
+		kgoOpts = append(kgoOpts, kgo.WithHooks(requestMetricsHooks))
 	}
 	if cfg.ConsumerGroups.Enabled && cfg.ConsumerGroups.ScrapeMode == ConsumerGroupScrapeModeOffsetsTopic {
-		kgoOpts = append(kgoOpts,
-			kgo.ConsumeResetOffset(kgo.NewOffset().AtStart()),
-			kgo.ConsumeTopics("__consumer_offsets"))
+		kgoOpts = append(kgoOpts, kgo.ConsumeResetOffset(kgo.NewOffset().AtStart()))
+		if cfg.ConsumerGroups.OffsetsTopicShardCount > 1 {
+			partitions, err := offsetsTopicShardPartitions(ctx, logger, kafkaSvc, cfg.ConsumerGroups)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get the partitions of the offsets topic shard: %w", err)
+			}
+			kgoOpts = append(kgoOpts, kgo.ConsumePartitions(map[string]map[int32]kgo.Offset{"__consumer_offsets": partitions}))
+		} else {
+			kgoOpts = append(kgoOpts, kgo.ConsumeTopics("__consumer_offsets"))
+		}
 	}
 
 	logger.Info("connecting to Kafka seed brokers, trying to fetch cluster metadata",
@@ -66,8 +104,21 @@ func NewService(cfg Config, logger *zap.Logger, kafkaSvc *kafka.Service, metrics
 	// Compile regexes. We can ignore the errors because valid compilation has been validated already
 	allowedGroupIDsExpr, _ := compileRegexes(cfg.ConsumerGroups.AllowedGroupIDs)
 	ignoredGroupIDsExpr, _ := compileRegexes(cfg.ConsumerGroups.IgnoredGroupIDs)
+	partitionGranularityGroupsExpr, _ := compileRegexes(cfg.ConsumerGroups.PartitionGranularityGroups)
 	allowedTopicsExpr, _ := compileRegexes(cfg.Topics.AllowedTopics)
 	ignoredTopicsExpr, _ := compileRegexes(cfg.Topics.IgnoredTopics)
+	allowedLogDirTopicsExpr, _ := compileRegexes(cfg.LogDirs.AllowedTopics)
+	ignoredLogDirTopicsExpr, _ := compileRegexes(cfg.LogDirs.IgnoredTopics)
+	topicConfigBaselines := make([]topicConfigBaseline, len(cfg.Topics.ConfigBaselines))
+	for i, baseline := range cfg.Topics.ConfigBaselines {
+		topicsExpr, _ := compileRegexes(baseline.Topics)
+		topicConfigBaselines[i] = topicConfigBaseline{topicsExpr: topicsExpr, configs: baseline.Configs}
+	}
+	lagThresholds := make([]lagThreshold, len(cfg.ConsumerGroups.LagThresholds))
+	for i, threshold := range cfg.ConsumerGroups.LagThresholds {
+		groupsExpr, _ := compileRegexes(threshold.Groups)
+		lagThresholds[i] = lagThreshold{groupsExpr: groupsExpr, warn: threshold.Warn, critical: threshold.Critical}
+	}
 
 	service := &Service{
 		Cfg:    cfg,
@@ -79,11 +130,32 @@ func NewService(cfg Config, logger *zap.Logger, kafkaSvc *kafka.Service, metrics
 
 		AllowedGroupIDsExpr: allowedGroupIDsExpr,
 		IgnoredGroupIDsExpr: ignoredGroupIDsExpr,
-		AllowedTopicsExpr:   allowedTopicsExpr,
-		IgnoredTopicsExpr:   ignoredTopicsExpr,
 
-		client:  client,
-		storage: storage,
+		PartitionGranularityGroupsExpr: partitionGranularityGroupsExpr,
+		AllowedTopicsExpr:              allowedTopicsExpr,
+		IgnoredTopicsExpr:              ignoredTopicsExpr,
+
+		AllowedLogDirTopicsExpr: allowedLogDirTopicsExpr,
+		IgnoredLogDirTopicsExpr: ignoredLogDirTopicsExpr,
+
+		topicConfigBaselines: topicConfigBaselines,
+		lagThresholds:        lagThresholds,
+		topicFilterRules:     compileFilterRules(cfg.Topics.FilterRules),
+		groupFilterRules:     compileFilterRules(cfg.ConsumerGroups.FilterRules),
+		topicLabelRules:      compileLabelRules(cfg.Topics.LabelRules),
+		groupLabelRules:      compileLabelRules(cfg.ConsumerGroups.LabelRules),
+
+		kafkaSvc: kafkaSvc,
+		client:   client,
+		storage:  storage,
+
+		groupRebalances:   newGroupRebalanceTracker(),
+		topicEvents:       newTopicEventTracker(),
+		lastStableOffsets: newLastStableOffsetTracker(),
+		topicThroughput:   newTopicThroughputTracker(),
+		offsetRewinds:     newOffsetRewindTracker(),
+		lagHistory:        newLagHistoryTracker(),
+		brokerProbes:      newBrokerProbeTracker(),
 	}
 
 	return service, nil
@@ -99,6 +171,10 @@ func (s *Service) Start(ctx context.Context) error {
 		go s.startConsumingOffsets(ctx)
 	}
 
+	if s.Cfg.BrokerProbes.Enabled {
+		go s.startBrokerProbes(ctx)
+	}
+
 	return nil
 }
 
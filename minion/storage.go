@@ -38,6 +38,10 @@ type OffsetCommit struct {
 	// CommitCount is the number of offset commits for this group-topic-partition combination
 	CommitCount int
 
+	// RewindCount is the number of offset commits for this group-topic-partition combination whose offset is lower
+	// than the previously committed offset, e.g. because the offsets have been reset.
+	RewindCount int
+
 	// ExpireTimestamp is a timestamp that indicates when this offset commit will expire on the Kafka cluster
 	ExpireTimestamp time.Time
 }
@@ -76,10 +80,15 @@ func (s *Storage) addOffsetCommit(key kmsg.OffsetCommitKey, value kmsg.OffsetCom
 	uniqueKey := encodeOffsetCommitKey(key)
 
 	commitCount := 0
+	rewindCount := 0
 	commitInterface, exists := s.offsetCommits.Get(uniqueKey)
 	if exists {
 		offsetCommit := commitInterface.(OffsetCommit)
 		commitCount = offsetCommit.CommitCount
+		rewindCount = offsetCommit.RewindCount
+		if value.Offset < offsetCommit.Value.Offset {
+			rewindCount++
+		}
 	}
 
 	timeDay := 24 * time.Hour
@@ -87,6 +96,7 @@ func (s *Storage) addOffsetCommit(key kmsg.OffsetCommitKey, value kmsg.OffsetCom
 		Key:             key,
 		Value:           value,
 		CommitCount:     commitCount + 1,
+		RewindCount:     rewindCount,
 		ExpireTimestamp: time.Unix(0, value.CommitTimestamp*int64(time.Millisecond)).Add(7 * timeDay),
 	}
 	s.offsetCommits.Set(uniqueKey, commit)
@@ -0,0 +1,96 @@
+package minion
+
+import (
+	"sync"
+
+	"github.com/twmb/franz-go/pkg/kerr"
+	"github.com/twmb/franz-go/pkg/kmsg"
+)
+
+// TopicEvents are the number of topic changes that have been observed for a topic since kminion started.
+type TopicEvents struct {
+	Created         float64
+	Deleted         float64
+	PartitionsAdded float64
+}
+
+// topicEventTracker detects created and deleted topics as well as partition increases by comparing the cluster
+// metadata between subsequent scrapes. Changes that are reverted between two scrapes are not observed.
+type topicEventTracker struct {
+	mutex           sync.Mutex
+	isInitialized   bool
+	partitionCounts map[string]int
+	events          map[string]*TopicEvents
+}
+
+func newTopicEventTracker() *topicEventTracker {
+	return &topicEventTracker{
+		partitionCounts: make(map[string]int),
+		events:          make(map[string]*TopicEvents),
+	}
+}
+
+// observe compares the topics of the given metadata with the previously observed topics. The first observation
+// serves as baseline and doesn't create any events.
+func (t *topicEventTracker) observe(metadata *kmsg.MetadataResponse) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	partitionCounts := make(map[string]int, len(metadata.Topics))
+	for _, topic := range metadata.Topics {
+		if topic.Topic == nil {
+			continue
+		}
+		if kerr.ErrorForCode(topic.ErrorCode) != nil {
+			// The partitions of topics with an error (e.g. LEADER_NOT_AVAILABLE) are not reliable, so the previous
+			// partition count is carried forward in order to not report a transient error as deleted topic
+			if previousCount, existed := t.partitionCounts[*topic.Topic]; existed {
+				partitionCounts[*topic.Topic] = previousCount
+			}
+			continue
+		}
+		partitionCounts[*topic.Topic] = len(topic.Partitions)
+	}
+
+	for topicName, partitionCount := range partitionCounts {
+		events, exists := t.events[topicName]
+		if !exists {
+			events = &TopicEvents{}
+			t.events[topicName] = events
+		}
+
+		previousCount, existed := t.partitionCounts[topicName]
+		if !existed && t.isInitialized {
+			events.Created++
+		} else if existed && partitionCount > previousCount {
+			events.PartitionsAdded += float64(partitionCount - previousCount)
+		}
+	}
+
+	for topicName := range t.partitionCounts {
+		if _, exists := partitionCounts[topicName]; !exists {
+			t.events[topicName].Deleted++
+		}
+	}
+
+	t.partitionCounts = partitionCounts
+	t.isInitialized = true
+}
+
+func (t *topicEventTracker) getEvents() map[string]TopicEvents {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	events := make(map[string]TopicEvents, len(t.events))
+	for topicName, topicEvents := range t.events {
+		events[topicName] = *topicEvents
+	}
+	return events
+}
+
+// GetTopicEvents returns the topic events per topic, that have been observed since kminion started. The given
+// metadata is compared with the metadata of the previous call to detect new events.
+func (s *Service) GetTopicEvents(metadata *kmsg.MetadataResponse) map[string]TopicEvents {
+	s.topicEvents.observe(metadata)
+	return s.topicEvents.getEvents()
+}
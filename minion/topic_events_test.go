@@ -0,0 +1,30 @@
+package minion
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/twmb/franz-go/pkg/kerr"
+	"github.com/twmb/franz-go/pkg/kmsg"
+)
+
+func TestTopicEventsTransientTopicError(t *testing.T) {
+	newMetadata := func(partitionCount int, errorCode int16) *kmsg.MetadataResponse {
+		topic := kmsg.NewMetadataResponseTopic()
+		topic.Topic = kmsg.StringPtr("orders")
+		topic.ErrorCode = errorCode
+		for i := 0; i < partitionCount; i++ {
+			topic.Partitions = append(topic.Partitions, kmsg.NewMetadataResponseTopicPartition())
+		}
+		return &kmsg.MetadataResponse{Topics: []kmsg.MetadataResponseTopic{topic}}
+	}
+
+	tracker := newTopicEventTracker()
+	tracker.observe(newMetadata(3, 0))
+	tracker.observe(newMetadata(0, kerr.LeaderNotAvailable.Code))
+	tracker.observe(newMetadata(3, 0))
+	assert.Equal(t, TopicEvents{}, tracker.getEvents()["orders"])
+
+	tracker.observe(newMetadata(6, 0))
+	assert.Equal(t, TopicEvents{PartitionsAdded: 3}, tracker.getEvents()["orders"])
+}
@@ -0,0 +1,67 @@
+package minion
+
+import (
+	"sync"
+	"time"
+
+	"github.com/twmb/franz-go/pkg/kerr"
+	"github.com/twmb/franz-go/pkg/kmsg"
+)
+
+// topicThroughputTracker approximates the number of messages produced per second to each topic by comparing the
+// summed high water marks between subsequent scrapes.
+type topicThroughputTracker struct {
+	mutex             sync.Mutex
+	highWaterMarkSums map[string]int64
+	observedAt        time.Time
+}
+
+func newTopicThroughputTracker() *topicThroughputTracker {
+	return &topicThroughputTracker{
+		highWaterMarkSums: make(map[string]int64),
+	}
+}
+
+// observe stores the given high water mark sums and returns the messages per second of all topics that have been
+// observed before. Topics whose high water mark sum decreased, e.g. because they have been recreated, are omitted.
+func (t *topicThroughputTracker) observe(highWaterMarkSums map[string]int64, now time.Time) map[string]float64 {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	rates := make(map[string]float64)
+	elapsed := now.Sub(t.observedAt).Seconds()
+	for topicName, sum := range highWaterMarkSums {
+		previousSum, exists := t.highWaterMarkSums[topicName]
+		if !exists || sum < previousSum || elapsed <= 0 {
+			continue
+		}
+		rates[topicName] = float64(sum-previousSum) / elapsed
+	}
+
+	t.highWaterMarkSums = highWaterMarkSums
+	t.observedAt = now
+	return rates
+}
+
+// GetTopicMessageRates returns the approximate number of messages per second that have been produced to each topic
+// since the previous call. Topics with partition errors are omitted, as their high water mark sum is incomplete.
+func (s *Service) GetTopicMessageRates(highWaterMarks *kmsg.ListOffsetsResponse) map[string]float64 {
+	highWaterMarkSums := make(map[string]int64, len(highWaterMarks.Topics))
+	for _, topic := range highWaterMarks.Topics {
+		sum := int64(0)
+		hasErrors := false
+		for _, partition := range topic.Partitions {
+			if kerr.ErrorForCode(partition.ErrorCode) != nil {
+				hasErrors = true
+				break
+			}
+			sum += partition.Offset
+		}
+		if hasErrors {
+			continue
+		}
+		highWaterMarkSums[topic.Topic] = sum
+	}
+
+	return s.topicThroughput.observe(highWaterMarkSums, time.Now())
+}
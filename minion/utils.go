@@ -7,34 +7,50 @@ import (
 )
 
 func (s *Service) IsGroupAllowed(groupName string) bool {
-	isAllowed := false
-	for _, regex := range s.AllowedGroupIDsExpr {
-		if regex.MatchString(groupName) {
-			isAllowed = true
-			break
-		}
+	if isAllowed, isMatch := matchFilterRules(s.groupFilterRules, groupName); isMatch {
+		return isAllowed
 	}
 
-	for _, regex := range s.IgnoredGroupIDsExpr {
+	return isAllowedByExpr(groupName, s.AllowedGroupIDsExpr, s.IgnoredGroupIDsExpr)
+}
+
+// ConsumerGroupGranularity returns the granularity of the given group's lag metrics. Groups that match one of the
+// partition granularity groups always use the partition granularity.
+func (s *Service) ConsumerGroupGranularity(groupName string) string {
+	for _, regex := range s.PartitionGranularityGroupsExpr {
 		if regex.MatchString(groupName) {
-			isAllowed = false
-			break
+			return ConsumerGroupGranularityPartition
 		}
 	}
-	return isAllowed
+	return s.Cfg.ConsumerGroups.Granularity
 }
 
 func (s *Service) IsTopicAllowed(topicName string) bool {
+	if isAllowed, isMatch := matchFilterRules(s.topicFilterRules, topicName); isMatch {
+		return isAllowed
+	}
+
+	return isAllowedByExpr(topicName, s.AllowedTopicsExpr, s.IgnoredTopicsExpr)
+}
+
+// IsLogDirTopicAllowed returns whether the size metrics of the given topic shall be exported.
+func (s *Service) IsLogDirTopicAllowed(topicName string) bool {
+	return isAllowedByExpr(topicName, s.AllowedLogDirTopicsExpr, s.IgnoredLogDirTopicsExpr)
+}
+
+// isAllowedByExpr returns whether the given name matches at least one of the allowed expressions and none of the
+// ignored expressions.
+func isAllowedByExpr(name string, allowedExpr []*regexp.Regexp, ignoredExpr []*regexp.Regexp) bool {
 	isAllowed := false
-	for _, regex := range s.AllowedTopicsExpr {
-		if regex.MatchString(topicName) {
+	for _, regex := range allowedExpr {
+		if regex.MatchString(name) {
 			isAllowed = true
 			break
 		}
 	}
 
-	for _, regex := range s.IgnoredTopicsExpr {
-		if regex.MatchString(topicName) {
+	for _, regex := range ignoredExpr {
+		if regex.MatchString(name) {
 			isAllowed = false
 			break
 		}
@@ -42,6 +58,64 @@ func (s *Service) IsTopicAllowed(topicName string) bool {
 	return isAllowed
 }
 
+// topicConfigBaseline is a TopicConfigBaseline with compiled topic expressions
+type topicConfigBaseline struct {
+	topicsExpr []*regexp.Regexp
+	configs    []TopicConfigBaselineEntry
+}
+
+// ExpectedTopicConfigs returns the config values that are expected for the given topic according to the configured
+// baselines, indexed by config name.
+func (s *Service) ExpectedTopicConfigs(topicName string) map[string]string {
+	expected := make(map[string]string)
+	for _, baseline := range s.topicConfigBaselines {
+		for _, regex := range baseline.topicsExpr {
+			if !regex.MatchString(topicName) {
+				continue
+			}
+			for _, config := range baseline.configs {
+				expected[config.Name] = config.Value
+			}
+			break
+		}
+	}
+	return expected
+}
+
+const (
+	LagStatusOK       string = "ok"
+	LagStatusWarn     string = "warn"
+	LagStatusCritical string = "critical"
+)
+
+// lagThreshold is a LagThreshold with compiled group expressions
+type lagThreshold struct {
+	groupsExpr []*regexp.Regexp
+	warn       int64
+	critical   int64
+}
+
+// LagStatus returns the lag status of the given group according to the first matching lag threshold. The second
+// return value is false if no threshold matches the group.
+func (s *Service) LagStatus(groupName string, lag int64) (string, bool) {
+	for _, threshold := range s.lagThresholds {
+		for _, regex := range threshold.groupsExpr {
+			if !regex.MatchString(groupName) {
+				continue
+			}
+			switch {
+			case lag >= threshold.critical:
+				return LagStatusCritical, true
+			case lag >= threshold.warn:
+				return LagStatusWarn, true
+			default:
+				return LagStatusOK, true
+			}
+		}
+	}
+	return "", false
+}
+
 func compileRegex(expr string) (*regexp.Regexp, error) {
 	if strings.HasPrefix(expr, "/") && strings.HasSuffix(expr, "/") {
 		substr := expr[1 : len(expr)-1]
@@ -0,0 +1,69 @@
+package prometheus
+
+import (
+	"context"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+// collectACLs exports the number of ACLs grouped by resource type, pattern type, operation and permission type.
+// ACLs that apply to all resources or all principals of a kind are additionally counted as wildcard ACLs.
+func (e *Exporter) collectACLs(ctx context.Context, ch chan<- prometheus.Metric) bool {
+	if !e.minionSvc.Cfg.ACLs.Enabled {
+		return true
+	}
+
+	acls, err := e.minionSvc.DescribeACLsCached(ctx)
+	if err != nil {
+		e.logger.Error("failed to describe acls", zap.Error(err))
+		return false
+	}
+
+	type aclGroup struct {
+		resourceType   string
+		patternType    string
+		operation      string
+		permissionType string
+	}
+	counts := make(map[aclGroup]int)
+	wildcardCounts := make(map[aclGroup]int)
+	for _, resource := range acls.Resources {
+		for _, acl := range resource.ACLs {
+			group := aclGroup{
+				resourceType:   resource.ResourceType.String(),
+				patternType:    resource.ResourcePatternType.String(),
+				operation:      acl.Operation.String(),
+				permissionType: acl.PermissionType.String(),
+			}
+			counts[group]++
+			if resource.ResourceName == "*" || strings.HasSuffix(acl.Principal, ":*") {
+				wildcardCounts[group]++
+			}
+		}
+	}
+
+	for group, count := range counts {
+		ch <- prometheus.MustNewConstMetric(
+			e.aclCount,
+			prometheus.GaugeValue,
+			float64(count),
+			group.resourceType,
+			group.patternType,
+			group.operation,
+			group.permissionType,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			e.aclWildcardCount,
+			prometheus.GaugeValue,
+			float64(wildcardCounts[group]),
+			group.resourceType,
+			group.patternType,
+			group.operation,
+			group.permissionType,
+		)
+	}
+
+	return true
+}
@@ -0,0 +1,73 @@
+package prometheus
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/twmb/franz-go/pkg/kmsg"
+	"github.com/twmb/franz-go/pkg/kversion"
+	"go.uber.org/zap"
+)
+
+// collectBrokerAPIVersions exports the Kafka version guessed from each broker's supported API versions, as well as
+// the min and max supported version of the configured API keys.
+func (e *Exporter) collectBrokerAPIVersions(ctx context.Context, ch chan<- prometheus.Metric) bool {
+	if !e.minionSvc.Cfg.APIVersions.Enabled {
+		return true
+	}
+
+	shards, err := e.minionSvc.DescribeBrokerAPIVersions(ctx)
+	if err != nil {
+		e.logger.Error("failed to describe broker api versions", zap.Error(err))
+		return false
+	}
+
+	allowedKeys := make(map[string]struct{}, len(e.minionSvc.Cfg.APIVersions.APIKeys))
+	for _, name := range e.minionSvc.Cfg.APIVersions.APIKeys {
+		allowedKeys[name] = struct{}{}
+	}
+
+	isOk := true
+	for _, shard := range shards {
+		brokerID := strconv.Itoa(int(shard.Broker.NodeID))
+		if shard.Err != nil {
+			e.logger.Error("failed to describe a broker's api versions",
+				zap.String("broker_id", brokerID),
+				zap.Error(shard.Err))
+			isOk = false
+			continue
+		}
+
+		ch <- prometheus.MustNewConstMetric(
+			e.brokerVersionInfo,
+			prometheus.GaugeValue,
+			1,
+			brokerID,
+			kversion.FromApiVersionsResponse(shard.Versions).VersionGuess(),
+		)
+
+		for _, apiKey := range shard.Versions.ApiKeys {
+			name := kmsg.NameForKey(apiKey.ApiKey)
+			if _, isAllowed := allowedKeys[name]; !isAllowed {
+				continue
+			}
+			ch <- prometheus.MustNewConstMetric(
+				e.brokerAPIMinVersion,
+				prometheus.GaugeValue,
+				float64(apiKey.MinVersion),
+				brokerID,
+				name,
+			)
+			ch <- prometheus.MustNewConstMetric(
+				e.brokerAPIMaxVersion,
+				prometheus.GaugeValue,
+				float64(apiKey.MaxVersion),
+				brokerID,
+				name,
+			)
+		}
+	}
+
+	return isOk
+}
@@ -0,0 +1,55 @@
+package prometheus
+
+import (
+	"context"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/twmb/franz-go/pkg/kerr"
+	"go.uber.org/zap"
+)
+
+// collectBrokerConfigs exports the allowed broker config keys as info metrics, so that config inconsistencies
+// between the brokers of a cluster can be spotted.
+func (e *Exporter) collectBrokerConfigs(ctx context.Context, ch chan<- prometheus.Metric) bool {
+	if !e.minionSvc.Cfg.BrokerConfigs.Enabled {
+		return true
+	}
+
+	brokerConfigs, err := e.minionSvc.GetBrokerConfigsCached(ctx)
+	if err != nil {
+		e.logger.Error("failed to get broker configs", zap.Error(err))
+		return false
+	}
+
+	isOk := true
+	for _, resource := range brokerConfigs.Resources {
+		typedErr := kerr.TypedErrorForCode(resource.ErrorCode)
+		if typedErr != nil {
+			isOk = false
+			e.logger.Warn("failed to get broker config of a specific broker",
+				zap.String("broker_id", resource.ResourceName),
+				zap.Error(typedErr))
+			continue
+		}
+
+		for _, config := range resource.Configs {
+			if config.IsSensitive {
+				continue
+			}
+			confVal := "nil"
+			if config.Value != nil {
+				confVal = *config.Value
+			}
+			ch <- prometheus.MustNewConstMetric(
+				e.brokerConfigInfo,
+				prometheus.GaugeValue,
+				1,
+				resource.ResourceName,
+				config.Name,
+				confVal,
+			)
+		}
+	}
+
+	return isOk
+}
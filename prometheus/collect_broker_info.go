@@ -2,9 +2,11 @@ package prometheus
 
 import (
 	"context"
+	"strconv"
+
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/twmb/franz-go/pkg/kmsg"
 	"go.uber.org/zap"
-	"strconv"
 )
 
 func (e *Exporter) collectBrokerInfo(ctx context.Context, ch chan<- prometheus.Metric) bool {
@@ -33,5 +35,78 @@ func (e *Exporter) collectBrokerInfo(ctx context.Context, ch chan<- prometheus.M
 		)
 	}
 
+	e.collectBrokerPartitionSkew(metadata, ch)
+
 	return true
 }
+
+// collectBrokerPartitionSkew reports the number of partition leaders and replicas on each broker across all topics, as
+// well as the deviation of these numbers from the cluster average in percent. Brokers that accumulated a
+// disproportionate number of leaders (e.g. after maintenance) have a positive skew.
+func (e *Exporter) collectBrokerPartitionSkew(metadata *kmsg.MetadataResponse, ch chan<- prometheus.Metric) {
+	if len(metadata.Brokers) == 0 {
+		return
+	}
+
+	leaders := make(map[int32]int, len(metadata.Brokers))
+	replicas := make(map[int32]int, len(metadata.Brokers))
+	for _, broker := range metadata.Brokers {
+		leaders[broker.NodeID] = 0
+		replicas[broker.NodeID] = 0
+	}
+
+	totalLeaders := 0
+	totalReplicas := 0
+	for _, topic := range metadata.Topics {
+		for _, partition := range topic.Partitions {
+			if _, exists := leaders[partition.Leader]; exists {
+				leaders[partition.Leader]++
+				totalLeaders++
+			}
+			for _, replica := range partition.Replicas {
+				if _, exists := replicas[replica]; exists {
+					replicas[replica]++
+					totalReplicas++
+				}
+			}
+		}
+	}
+
+	avgLeaders := float64(totalLeaders) / float64(len(metadata.Brokers))
+	avgReplicas := float64(totalReplicas) / float64(len(metadata.Brokers))
+	for _, broker := range metadata.Brokers {
+		brokerID := strconv.Itoa(int(broker.NodeID))
+		ch <- prometheus.MustNewConstMetric(
+			e.brokerLeaderPartitions,
+			prometheus.GaugeValue,
+			float64(leaders[broker.NodeID]),
+			brokerID,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			e.brokerReplicaPartitions,
+			prometheus.GaugeValue,
+			float64(replicas[broker.NodeID]),
+			brokerID,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			e.brokerLeaderSkew,
+			prometheus.GaugeValue,
+			skewPercent(leaders[broker.NodeID], avgLeaders),
+			brokerID,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			e.brokerReplicaSkew,
+			prometheus.GaugeValue,
+			skewPercent(replicas[broker.NodeID], avgReplicas),
+			brokerID,
+		)
+	}
+}
+
+// skewPercent returns the deviation of count from the average in percent, or 0 if the average is 0
+func skewPercent(count int, avg float64) float64 {
+	if avg == 0 {
+		return 0
+	}
+	return (float64(count) - avg) / avg * 100
+}
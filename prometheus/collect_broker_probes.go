@@ -0,0 +1,44 @@
+package prometheus
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// collectBrokerProbes exports the results of the most recent broker probes. The probes run in the background
+// independently of the scrapes, so that an unreachable broker is reported explicitly rather than via failed scrapes.
+func (e *Exporter) collectBrokerProbes(_ context.Context, ch chan<- prometheus.Metric) bool {
+	if !e.minionSvc.Cfg.BrokerProbes.Enabled {
+		return true
+	}
+
+	for brokerID, result := range e.minionSvc.GetBrokerProbeResults() {
+		brokerIDStr := strconv.Itoa(int(brokerID))
+		up := 0.0
+		if result.IsUp {
+			up = 1
+		}
+		ch <- prometheus.MustNewConstMetric(
+			e.brokerUp,
+			prometheus.GaugeValue,
+			up,
+			brokerIDStr,
+			result.Host,
+		)
+		// The dial duration is only known if the probe established a new connection
+		if !result.IsUp || result.DialDuration == 0 {
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(
+			e.brokerProbeDialDuration,
+			prometheus.GaugeValue,
+			result.DialDuration.Seconds(),
+			brokerIDStr,
+			result.Host,
+		)
+	}
+
+	return true
+}
@@ -0,0 +1,50 @@
+package prometheus
+
+import (
+	"context"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+// clientQuotaDefaultEntity is the label value for quotas that apply to the default entity of a type
+const clientQuotaDefaultEntity = "<default>"
+
+// collectClientQuotas exports the configured quota values (e.g. producer_byte_rate or request_percentage) per
+// quota entity, which is a combination of user, client id and ip.
+func (e *Exporter) collectClientQuotas(ctx context.Context, ch chan<- prometheus.Metric) bool {
+	if !e.minionSvc.Cfg.ClientQuotas.Enabled {
+		return true
+	}
+
+	quotas, err := e.minionSvc.DescribeClientQuotas(ctx)
+	if err != nil {
+		e.logger.Error("failed to describe client quotas", zap.Error(err))
+		return false
+	}
+
+	for _, entry := range quotas.Entries {
+		entityNames := make(map[string]string, len(entry.Entity))
+		for _, entity := range entry.Entity {
+			name := clientQuotaDefaultEntity
+			if entity.Name != nil {
+				name = *entity.Name
+			}
+			entityNames[entity.Type] = name
+		}
+
+		for _, value := range entry.Values {
+			ch <- prometheus.MustNewConstMetric(
+				e.clientQuota,
+				prometheus.GaugeValue,
+				value.Value,
+				entityNames["user"],
+				entityNames["client-id"],
+				entityNames["ip"],
+				value.Key,
+			)
+		}
+	}
+
+	return true
+}
@@ -2,9 +2,10 @@ package prometheus
 
 import (
 	"context"
+	"strconv"
+
 	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/zap"
-	"strconv"
 )
 
 func (e *Exporter) collectClusterInfo(ctx context.Context, ch chan<- prometheus.Metric) bool {
@@ -34,5 +35,11 @@ func (e *Exporter) collectClusterInfo(ctx context.Context, ch chan<- prometheus.
 		strconv.Itoa(int(metadata.ControllerID)),
 		clusterID,
 	)
+	ch <- prometheus.MustNewConstMetric(
+		e.clusterControllerID,
+		prometheus.GaugeValue,
+		float64(metadata.ControllerID),
+		clusterID,
+	)
 	return true
 }
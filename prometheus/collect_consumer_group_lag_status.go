@@ -0,0 +1,43 @@
+package prometheus
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/cloudhut/kminion/v2/minion"
+)
+
+// lagStatuses are all lag statuses a consumer group can have
+var lagStatuses = []string{minion.LagStatusOK, minion.LagStatusWarn, minion.LagStatusCritical}
+
+// collectConsumerGroupLagStatus exports the lag status of each group that matches a configured lag threshold. The
+// status is derived from the group's total lag across all partitions.
+func (e *Exporter) collectConsumerGroupLagStatus(ch chan<- prometheus.Metric, committedOffsets []groupPartitionOffset) {
+	if len(e.minionSvc.Cfg.ConsumerGroups.LagThresholds) == 0 {
+		return
+	}
+
+	lagByGroup := make(map[string]int64)
+	for _, committedOffset := range committedOffsets {
+		lagByGroup[committedOffset.groupID] += committedOffset.lag
+	}
+
+	for groupID, lag := range lagByGroup {
+		status, exists := e.minionSvc.LagStatus(groupID, lag)
+		if !exists {
+			continue
+		}
+		for _, possibleStatus := range lagStatuses {
+			isStatus := 0.0
+			if status == possibleStatus {
+				isStatus = 1
+			}
+			ch <- prometheus.MustNewConstMetric(
+				e.consumerGroupLagStatus,
+				prometheus.GaugeValue,
+				isStatus,
+				groupID,
+				possibleStatus,
+			)
+		}
+	}
+}
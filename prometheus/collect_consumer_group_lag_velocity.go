@@ -0,0 +1,50 @@
+package prometheus
+
+import (
+	"math"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// collectConsumerGroupLagVelocity exports the rate at which the lag of each group on each topic changes, as well as
+// the estimated time until the group has caught up. The estimated time is +Inf if the lag is not decreasing.
+func (e *Exporter) collectConsumerGroupLagVelocity(ch chan<- prometheus.Metric, committedOffsets []groupPartitionOffset) {
+	if !e.minionSvc.Cfg.ConsumerGroups.LagVelocity {
+		return
+	}
+
+	lags := make(map[string]map[string]int64)
+	for _, committedOffset := range committedOffsets {
+		if _, exists := lags[committedOffset.groupID]; !exists {
+			lags[committedOffset.groupID] = make(map[string]int64)
+		}
+		lags[committedOffset.groupID][committedOffset.offset.Topic] += committedOffset.lag
+	}
+
+	for groupID, topics := range e.minionSvc.GetLagVelocities(lags) {
+		for topicName, velocity := range topics {
+			ch <- prometheus.MustNewConstMetric(
+				e.consumerGroupTopicLagVelocity,
+				prometheus.GaugeValue,
+				velocity.MessagesPerSecond,
+				groupID,
+				topicName,
+			)
+
+			eta := 0.0
+			if velocity.Lag > 0 {
+				eta = math.Inf(1)
+				if velocity.MessagesPerSecond < 0 {
+					eta = float64(velocity.Lag) / -velocity.MessagesPerSecond
+				}
+			}
+			ch <- prometheus.MustNewConstMetric(
+				e.consumerGroupTopicLagETA,
+				prometheus.GaugeValue,
+				eta,
+				groupID,
+				topicName,
+			)
+		}
+	}
+}
@@ -40,16 +40,83 @@ func (e *Exporter) collectConsumerGroupLags(ctx context.Context, ch chan<- prome
 	}
 	waterMarksByTopic := e.waterMarksByTopic(lowWaterMarks, highWaterMarks)
 
+	var owners partitionOwners
+	if e.minionSvc.Cfg.ConsumerGroups.MemberLabels {
+		owners = e.partitionOwners(ctx)
+	}
+
 	// We have two different options to get consumer group offsets - either via the AdminAPI or by consuming the
 	// __consumer_offsets topic.
 	if e.minionSvc.Cfg.ConsumerGroups.ScrapeMode == minion.ConsumerGroupScrapeModeAdminAPI {
-		return e.collectConsumerGroupLagsAdminAPI(ctx, ch, waterMarksByTopic)
+		return e.collectConsumerGroupLagsAdminAPI(ctx, ch, waterMarksByTopic, owners)
 	} else {
-		return e.collectConsumerGroupLagsOffsetTopic(ctx, ch, waterMarksByTopic)
+		return e.collectConsumerGroupLagsOffsetTopic(ctx, ch, waterMarksByTopic, owners)
 	}
 }
 
-func (e *Exporter) collectConsumerGroupLagsOffsetTopic(_ context.Context, ch chan<- prometheus.Metric, marks map[string]map[int32]waterMark) bool {
+// partitionOwner is the consumer group member that is assigned to a partition
+type partitionOwner struct {
+	clientID   string
+	clientHost string
+}
+
+// partitionOwners are the assigned members indexed by group id, topic name and partition id
+type partitionOwners map[string]map[string]map[int32]partitionOwner
+
+// partitionOwners returns the members that are currently assigned to each partition. Partitions of groups that
+// could not be described are missing.
+func (e *Exporter) partitionOwners(ctx context.Context) partitionOwners {
+	owners := make(partitionOwners)
+	groups, err := e.minionSvc.DescribeConsumerGroupsCached(ctx)
+	if err != nil {
+		e.logger.Warn("failed to describe consumer groups for partition owners", zap.Error(err))
+		return owners
+	}
+
+	for _, grp := range groups {
+		for _, group := range grp.Groups.Groups {
+			for _, member := range group.Members {
+				if len(member.MemberAssignment) == 0 {
+					continue
+				}
+				kassignment, err := decodeMemberAssignments(group.ProtocolType, member)
+				if err != nil || kassignment == nil {
+					continue
+				}
+				if _, exists := owners[group.Group]; !exists {
+					owners[group.Group] = make(map[string]map[int32]partitionOwner)
+				}
+				for _, topic := range kassignment.Topics {
+					if _, exists := owners[group.Group][topic.Topic]; !exists {
+						owners[group.Group][topic.Topic] = make(map[int32]partitionOwner)
+					}
+					for _, partition := range topic.Partitions {
+						owners[group.Group][topic.Topic][partition] = partitionOwner{
+							clientID:   member.ClientID,
+							clientHost: member.ClientHost,
+						}
+					}
+				}
+			}
+		}
+	}
+
+	return owners
+}
+
+// partitionLagLabelValues returns the label values of the partition lag metric. The member labels are empty if no
+// member is assigned to the partition.
+func (e *Exporter) partitionLagLabelValues(owners partitionOwners, groupID string, topicName string, partitionID int32) []string {
+	labelValues := []string{groupID, topicName, strconv.Itoa(int(partitionID))}
+	if e.minionSvc.Cfg.ConsumerGroups.MemberLabels {
+		owner := owners[groupID][topicName][partitionID]
+		labelValues = append(labelValues, owner.clientID, owner.clientHost)
+	}
+	return labelValues
+}
+
+func (e *Exporter) collectConsumerGroupLagsOffsetTopic(ctx context.Context, ch chan<- prometheus.Metric, marks map[string]map[int32]waterMark, owners partitionOwners) bool {
+	var committedOffsets []groupPartitionOffset
 	offsets := e.minionSvc.ListAllConsumerGroupOffsetsInternal()
 	for groupName, group := range offsets {
 		if !e.minionSvc.IsGroupAllowed(groupName) {
@@ -59,7 +126,10 @@ func (e *Exporter) collectConsumerGroupLagsOffsetTopic(_ context.Context, ch cha
 
 		for topicName, topic := range group {
 			topicLag := float64(0)
+			topicMaxLag := float64(0)
 			topicOffsetSum := float64(0)
+			topicOffsetCommits := 0
+			topicOffsetRewinds := 0
 			for partitionID, partition := range topic {
 				childLogger := e.logger.With(
 					zap.String("consumer_group", groupName),
@@ -82,21 +152,27 @@ func (e *Exporter) collectConsumerGroupLagsOffsetTopic(_ context.Context, ch cha
 				// race condition. Negative lags obviously do not make sense so use at least 0 as lag.
 				lag = math.Max(0, lag)
 				topicLag += lag
+				topicMaxLag = math.Max(topicMaxLag, lag)
 				topicOffsetSum += float64(partition.Value.Offset)
+				committedOffsets = append(committedOffsets, groupPartitionOffset{
+					groupID: groupName,
+					offset:  minion.TopicPartitionOffset{Topic: topicName, Partition: partitionID, Offset: partition.Value.Offset},
+					lag:     int64(lag),
+				})
 
 				// Offset commit count for this consumer group
 				offsetCommits += partition.CommitCount
+				topicOffsetCommits += partition.CommitCount
+				topicOffsetRewinds += partition.RewindCount
 
-				if e.minionSvc.Cfg.ConsumerGroups.Granularity == minion.ConsumerGroupGranularityTopic {
+				if e.minionSvc.ConsumerGroupGranularity(groupName) == minion.ConsumerGroupGranularityTopic {
 					continue
 				}
 				ch <- prometheus.MustNewConstMetric(
 					e.consumerGroupTopicPartitionLag,
 					prometheus.GaugeValue,
 					lag,
-					groupName,
-					topicName,
-					strconv.Itoa(int(partitionID)),
+					e.partitionLagLabelValues(owners, groupName, topicName, partitionID)...,
 				)
 			}
 			ch <- prometheus.MustNewConstMetric(
@@ -106,6 +182,13 @@ func (e *Exporter) collectConsumerGroupLagsOffsetTopic(_ context.Context, ch cha
 				groupName,
 				topicName,
 			)
+			ch <- prometheus.MustNewConstMetric(
+				e.consumerGroupTopicMaxLag,
+				prometheus.GaugeValue,
+				topicMaxLag,
+				groupName,
+				topicName,
+			)
 			ch <- prometheus.MustNewConstMetric(
 				e.consumerGroupTopicOffsetSum,
 				prometheus.GaugeValue,
@@ -113,6 +196,20 @@ func (e *Exporter) collectConsumerGroupLagsOffsetTopic(_ context.Context, ch cha
 				groupName,
 				topicName,
 			)
+			ch <- prometheus.MustNewConstMetric(
+				e.topicOffsetCommits,
+				prometheus.CounterValue,
+				float64(topicOffsetCommits),
+				groupName,
+				topicName,
+			)
+			ch <- prometheus.MustNewConstMetric(
+				e.topicOffsetRewinds,
+				prometheus.CounterValue,
+				float64(topicOffsetRewinds),
+				groupName,
+				topicName,
+			)
 		}
 
 		ch <- prometheus.MustNewConstMetric(
@@ -122,11 +219,15 @@ func (e *Exporter) collectConsumerGroupLagsOffsetTopic(_ context.Context, ch cha
 			groupName,
 		)
 	}
-	return true
+	e.collectConsumerGroupLagStatus(ch, committedOffsets)
+	e.collectConsumerGroupLagVelocity(ch, committedOffsets)
+	return e.collectConsumerGroupTimeLags(ctx, ch, committedOffsets)
 }
 
-func (e *Exporter) collectConsumerGroupLagsAdminAPI(ctx context.Context, ch chan<- prometheus.Metric, marks map[string]map[int32]waterMark) bool {
+func (e *Exporter) collectConsumerGroupLagsAdminAPI(ctx context.Context, ch chan<- prometheus.Metric, marks map[string]map[int32]waterMark, owners partitionOwners) bool {
 	isOk := true
+	var committedOffsets []groupPartitionOffset
+	offsetsByGroup := make(map[string]map[string]map[int32]int64)
 
 	groupOffsets, err := e.minionSvc.ListAllConsumerGroupOffsetsAdminAPI(ctx)
 	for groupName, offsetRes := range groupOffsets {
@@ -142,8 +243,11 @@ func (e *Exporter) collectConsumerGroupLagsAdminAPI(ctx context.Context, ch chan
 			isOk = false
 			continue
 		}
+		offsetsByGroup[groupName] = make(map[string]map[int32]int64)
 		for _, topic := range offsetRes.Topics {
+			offsetsByGroup[groupName][topic.Topic] = make(map[int32]int64)
 			topicLag := float64(0)
+			topicMaxLag := float64(0)
 			topicOffsetSum := float64(0)
 			for _, partition := range topic.Partitions {
 				err := kerr.ErrorForCode(partition.ErrorCode)
@@ -155,6 +259,8 @@ func (e *Exporter) collectConsumerGroupLagsAdminAPI(ctx context.Context, ch chan
 					continue
 				}
 
+				offsetsByGroup[groupName][topic.Topic][partition.Partition] = partition.Offset
+
 				childLogger := e.logger.With(
 					zap.String("consumer_group", groupName),
 					zap.String("topic_name", topic.Topic),
@@ -177,18 +283,22 @@ func (e *Exporter) collectConsumerGroupLagsAdminAPI(ctx context.Context, ch chan
 				// race condition. Negative lags obviously do not make sense so use at least 0 as lag.
 				lag = math.Max(0, lag)
 				topicLag += lag
+				topicMaxLag = math.Max(topicMaxLag, lag)
 				topicOffsetSum += float64(partition.Offset)
+				committedOffsets = append(committedOffsets, groupPartitionOffset{
+					groupID: groupName,
+					offset:  minion.TopicPartitionOffset{Topic: topic.Topic, Partition: partition.Partition, Offset: partition.Offset},
+					lag:     int64(lag),
+				})
 
-				if e.minionSvc.Cfg.ConsumerGroups.Granularity == minion.ConsumerGroupGranularityTopic {
+				if e.minionSvc.ConsumerGroupGranularity(groupName) == minion.ConsumerGroupGranularityTopic {
 					continue
 				}
 				ch <- prometheus.MustNewConstMetric(
 					e.consumerGroupTopicPartitionLag,
 					prometheus.GaugeValue,
 					lag,
-					groupName,
-					topic.Topic,
-					strconv.Itoa(int(partition.Partition)),
+					e.partitionLagLabelValues(owners, groupName, topic.Topic, partition.Partition)...,
 				)
 			}
 
@@ -199,6 +309,13 @@ func (e *Exporter) collectConsumerGroupLagsAdminAPI(ctx context.Context, ch chan
 				groupName,
 				topic.Topic,
 			)
+			ch <- prometheus.MustNewConstMetric(
+				e.consumerGroupTopicMaxLag,
+				prometheus.GaugeValue,
+				topicMaxLag,
+				groupName,
+				topic.Topic,
+			)
 			ch <- prometheus.MustNewConstMetric(
 				e.consumerGroupTopicOffsetSum,
 				prometheus.GaugeValue,
@@ -208,7 +325,21 @@ func (e *Exporter) collectConsumerGroupLagsAdminAPI(ctx context.Context, ch chan
 			)
 		}
 	}
-	return isOk
+	for groupName, topics := range e.minionSvc.GetOffsetRewinds(offsetsByGroup) {
+		for topicName, rewinds := range topics {
+			ch <- prometheus.MustNewConstMetric(
+				e.topicOffsetRewinds,
+				prometheus.CounterValue,
+				rewinds,
+				groupName,
+				topicName,
+			)
+		}
+	}
+
+	e.collectConsumerGroupLagStatus(ch, committedOffsets)
+	e.collectConsumerGroupLagVelocity(ch, committedOffsets)
+	return e.collectConsumerGroupTimeLags(ctx, ch, committedOffsets) && isOk
 }
 
 func (e *Exporter) waterMarksByTopic(lowMarks *kmsg.ListOffsetsResponse, highMarks *kmsg.ListOffsetsResponse) map[string]map[int32]waterMark {
@@ -0,0 +1,86 @@
+package prometheus
+
+import (
+	"context"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+
+	"github.com/cloudhut/kminion/v2/minion"
+)
+
+// groupPartitionOffset is the committed offset of a consumer group on a single partition, along with its offset lag
+type groupPartitionOffset struct {
+	groupID string
+	offset  minion.TopicPartitionOffset
+	lag     int64
+}
+
+// collectConsumerGroupTimeLags exports how many seconds consumer groups are lagging behind. The time lag of a
+// partition is the age of the record at the committed offset, or 0 if the group has consumed all records.
+func (e *Exporter) collectConsumerGroupTimeLags(ctx context.Context, ch chan<- prometheus.Metric, committedOffsets []groupPartitionOffset) bool {
+	if !e.minionSvc.Cfg.ConsumerGroups.TimeLag {
+		return true
+	}
+
+	// Only the timestamps of lagging partitions need to be fetched
+	lagging := make([]minion.TopicPartitionOffset, 0, len(committedOffsets))
+	for _, committed := range committedOffsets {
+		if committed.lag > 0 {
+			lagging = append(lagging, committed.offset)
+		}
+	}
+	timestamps, err := e.minionSvc.GetRecordTimestamps(ctx, lagging)
+	if err != nil {
+		e.logger.Error("failed to fetch record timestamps for consumer group time lags", zap.Error(err))
+		return false
+	}
+
+	type groupTopic struct {
+		groupID string
+		topic   string
+	}
+	topicLags := make(map[groupTopic]float64)
+	now := time.Now()
+	for _, committed := range committedOffsets {
+		lagSeconds := float64(0)
+		if committed.lag > 0 {
+			timestamp, exists := timestamps[committed.offset]
+			if !exists {
+				// The record may have been deleted already, in which case we don't know its age
+				continue
+			}
+			lagSeconds = math.Max(0, now.Sub(timestamp).Seconds())
+		}
+
+		key := groupTopic{groupID: committed.groupID, topic: committed.offset.Topic}
+		topicLags[key] = math.Max(topicLags[key], lagSeconds)
+
+		if e.minionSvc.ConsumerGroupGranularity(committed.groupID) == minion.ConsumerGroupGranularityTopic {
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(
+			e.consumerGroupTopicPartitionLagSecs,
+			prometheus.GaugeValue,
+			lagSeconds,
+			committed.groupID,
+			committed.offset.Topic,
+			strconv.Itoa(int(committed.offset.Partition)),
+		)
+	}
+
+	for key, lagSeconds := range topicLags {
+		ch <- prometheus.MustNewConstMetric(
+			e.consumerGroupTopicLagSecs,
+			prometheus.GaugeValue,
+			lagSeconds,
+			key.groupID,
+			key.topic,
+		)
+	}
+
+	return true
+}
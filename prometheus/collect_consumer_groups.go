@@ -4,18 +4,24 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/twmb/franz-go/pkg/kerr"
 	"github.com/twmb/franz-go/pkg/kmsg"
 	"go.uber.org/zap"
+
+	"github.com/cloudhut/kminion/v2/minion"
 )
 
+// consumerGroupStates are all states a consumer group can be in
+var consumerGroupStates = []string{"Stable", "PreparingRebalance", "CompletingRebalance", "Empty", "Dead"}
+
 func (e *Exporter) collectConsumerGroups(ctx context.Context, ch chan<- prometheus.Metric) bool {
 	if !e.minionSvc.Cfg.ConsumerGroups.Enabled {
 		return true
 	}
-	groups, err := e.minionSvc.DescribeConsumerGroups(ctx)
+	groups, err := e.minionSvc.DescribeConsumerGroupsCached(ctx)
 	if err != nil {
 		e.logger.Error("failed to collect consumer groups, because Kafka request failed", zap.Error(err))
 		return false
@@ -23,8 +29,11 @@ func (e *Exporter) collectConsumerGroups(ctx context.Context, ch chan<- promethe
 
 	// The list of groups may be incomplete due to group coordinators that might fail to respond. We do log an error
 	// message in that case (in the kafka request method) and groups will not be included in this list.
+	coordinatedGroups := make(map[int32]int)
 	for _, grp := range groups {
 		coordinator := grp.BrokerMetadata.NodeID
+		// The number of coordinated groups includes all groups, regardless of the allowed and ignored groups
+		coordinatedGroups[coordinator] += len(grp.Groups.Groups)
 		for _, group := range grp.Groups.Groups {
 			err := kerr.ErrorForCode(group.ErrorCode)
 			if err != nil {
@@ -52,6 +61,21 @@ func (e *Exporter) collectConsumerGroups(ctx context.Context, ch chan<- promethe
 				strconv.FormatInt(int64(coordinator), 10),
 			)
 
+			// One series per state, so that groups that are stuck in a state can be detected
+			for _, possibleState := range consumerGroupStates {
+				isState := 0.0
+				if group.State == possibleState {
+					isState = 1
+				}
+				ch <- prometheus.MustNewConstMetric(
+					e.consumerGroupState,
+					prometheus.GaugeValue,
+					isState,
+					group.Group,
+					possibleState,
+				)
+			}
+
 			// total number of members in consumer groups
 			ch <- prometheus.MustNewConstMetric(
 				e.consumerGroupMembers,
@@ -93,10 +117,21 @@ func (e *Exporter) collectConsumerGroups(ctx context.Context, ch chan<- promethe
 				if len(kassignment.Topics) == 0 {
 					membersWithEmptyAssignment++
 				}
+				memberPartitionsAssigned := 0
 				for _, topic := range kassignment.Topics {
 					topicConsumers[topic.Topic]++
 					topicPartitionsAssigned[topic.Topic] += len(topic.Partitions)
+					memberPartitionsAssigned += len(topic.Partitions)
 				}
+				ch <- prometheus.MustNewConstMetric(
+					e.consumerGroupMemberAssignedPartitions,
+					prometheus.GaugeValue,
+					float64(memberPartitionsAssigned),
+					group.Group,
+					member.MemberID,
+					member.ClientID,
+					member.ClientHost,
+				)
 			}
 
 			if failedAssignmentsDecode > 0 {
@@ -138,9 +173,88 @@ func (e *Exporter) collectConsumerGroups(ctx context.Context, ch chan<- promethe
 			}
 		}
 	}
+
+	e.collectCoordinatedGroups(ctx, ch, coordinatedGroups)
+	isOk := e.collectAbandonedGroups(ctx, ch, groups)
+
+	for groupID, rebalances := range e.minionSvc.GetGroupRebalances(groups) {
+		if !e.minionSvc.IsGroupAllowed(groupID) {
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(
+			e.consumerGroupRebalances,
+			prometheus.CounterValue,
+			rebalances,
+			groupID,
+		)
+	}
+
+	return isOk
+}
+
+// collectAbandonedGroups reports for each group whether it is abandoned, which means it has no members but still
+// has committed offsets. In the offsetsTopic scrape mode the age of the abandoned groups' newest commit is reported
+// as well.
+func (e *Exporter) collectAbandonedGroups(ctx context.Context, ch chan<- prometheus.Metric, groups []minion.DescribeConsumerGroupsResponse) bool {
+	abandonedGroups, err := e.minionSvc.GetAbandonedGroups(ctx, groups)
+	if err != nil {
+		e.logger.Error("failed to get abandoned consumer groups", zap.Error(err))
+		return false
+	}
+	abandonedGroupsByID := make(map[string]minion.AbandonedGroup, len(abandonedGroups))
+	for _, group := range abandonedGroups {
+		abandonedGroupsByID[group.GroupID] = group
+	}
+
+	for _, grp := range groups {
+		for _, group := range grp.Groups.Groups {
+			if kerr.ErrorForCode(group.ErrorCode) != nil || !e.minionSvc.IsGroupAllowed(group.Group) {
+				continue
+			}
+			abandonedGroup, isAbandoned := abandonedGroupsByID[group.Group]
+			if !isAbandoned {
+				ch <- prometheus.MustNewConstMetric(e.consumerGroupAbandoned, prometheus.GaugeValue, 0, group.Group)
+				continue
+			}
+			ch <- prometheus.MustNewConstMetric(e.consumerGroupAbandoned, prometheus.GaugeValue, 1, group.Group)
+			if !abandonedGroup.NewestCommit.IsZero() {
+				ch <- prometheus.MustNewConstMetric(
+					e.consumerGroupNewestCommitAge,
+					prometheus.GaugeValue,
+					time.Since(abandonedGroup.NewestCommit).Seconds(),
+					group.Group,
+				)
+			}
+		}
+	}
+
 	return true
 }
 
+// collectCoordinatedGroups reports the number of consumer groups that are coordinated by each broker, so that
+// coordination hotspots can be spotted. Brokers that don't coordinate any group are reported with 0.
+func (e *Exporter) collectCoordinatedGroups(ctx context.Context, ch chan<- prometheus.Metric, coordinatedGroups map[int32]int) {
+	metadata, err := e.minionSvc.GetMetadataCached(ctx)
+	if err != nil {
+		e.logger.Warn("failed to get metadata for coordinated groups", zap.Error(err))
+	} else {
+		for _, broker := range metadata.Brokers {
+			if _, exists := coordinatedGroups[broker.NodeID]; !exists {
+				coordinatedGroups[broker.NodeID] = 0
+			}
+		}
+	}
+
+	for brokerID, count := range coordinatedGroups {
+		ch <- prometheus.MustNewConstMetric(
+			e.brokerCoordinatedGroups,
+			prometheus.GaugeValue,
+			float64(count),
+			strconv.Itoa(int(brokerID)),
+		)
+	}
+}
+
 func decodeMemberAssignments(protocolType string, member kmsg.DescribeGroupsResponseGroupMember) (*kmsg.ConsumerMemberAssignment, error) {
 	switch protocolType {
 	case "consumer":
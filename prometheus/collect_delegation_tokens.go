@@ -0,0 +1,55 @@
+package prometheus
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+// collectDelegationTokens exports the expiry timestamp of each delegation token, as well as the seconds until the
+// next token of each owner expires. Tokens that are not renewed in time silently break the clients using them.
+func (e *Exporter) collectDelegationTokens(ctx context.Context, ch chan<- prometheus.Metric) bool {
+	if !e.minionSvc.Cfg.DelegationTokens.Enabled {
+		return true
+	}
+
+	tokens, err := e.minionSvc.DescribeDelegationTokens(ctx)
+	if err != nil {
+		e.logger.Error("failed to describe delegation tokens", zap.Error(err))
+		return false
+	}
+
+	now := time.Now()
+	secondsUntilExpiryByOwner := make(map[string]float64)
+	for _, token := range tokens.TokenDetails {
+		owner := token.PrincipalType + ":" + token.PrincipalName
+		expiry := time.UnixMilli(token.ExpiryTimestamp)
+		ch <- prometheus.MustNewConstMetric(
+			e.delegationTokenExpiry,
+			prometheus.GaugeValue,
+			float64(expiry.Unix()),
+			token.TokenID,
+			owner,
+		)
+
+		secondsUntilExpiry := expiry.Sub(now).Seconds()
+		if current, exists := secondsUntilExpiryByOwner[owner]; exists {
+			secondsUntilExpiry = math.Min(current, secondsUntilExpiry)
+		}
+		secondsUntilExpiryByOwner[owner] = secondsUntilExpiry
+	}
+
+	for owner, secondsUntilExpiry := range secondsUntilExpiryByOwner {
+		ch <- prometheus.MustNewConstMetric(
+			e.delegationTokenSecondsUntilExpiry,
+			prometheus.GaugeValue,
+			secondsUntilExpiry,
+			owner,
+		)
+	}
+
+	return true
+}
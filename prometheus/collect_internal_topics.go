@@ -0,0 +1,126 @@
+package prometheus
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/twmb/franz-go/pkg/kerr"
+	"go.uber.org/zap"
+)
+
+// internalTopics are Kafka's internal topics whose unavailability affects all consumer groups or transactional
+// producers of the cluster. The transaction state topic only exists once the first transaction has been started.
+var internalTopics = []string{"__consumer_offsets", "__transaction_state"}
+
+// collectInternalTopics exports dedicated health metrics for Kafka's internal topics, regardless of the allowed and
+// ignored topics.
+func (e *Exporter) collectInternalTopics(ctx context.Context, ch chan<- prometheus.Metric) bool {
+	if !e.minionSvc.Cfg.Topics.Enabled {
+		return true
+	}
+
+	metadata, err := e.minionSvc.GetMetadataCached(ctx)
+	if err != nil {
+		e.logger.Error("failed to get metadata", zap.Error(err))
+		return false
+	}
+
+	isOk := true
+	for _, topic := range metadata.Topics {
+		topicName := *topic.Topic
+		if !topic.IsInternal || !isInternalTopic(topicName) {
+			continue
+		}
+		typedErr := kerr.TypedErrorForCode(topic.ErrorCode)
+		if typedErr != nil {
+			isOk = false
+			e.logger.Warn("failed to get metadata of an internal topic",
+				zap.String("topic_name", topicName),
+				zap.Error(typedErr))
+			continue
+		}
+
+		offlinePartitions := 0
+		underReplicatedPartitions := 0
+		for _, partition := range topic.Partitions {
+			if partition.Leader < 0 {
+				offlinePartitions++
+			}
+			if len(partition.ISR) < len(partition.Replicas) {
+				underReplicatedPartitions++
+			}
+		}
+		ch <- prometheus.MustNewConstMetric(
+			e.internalTopicPartitions,
+			prometheus.GaugeValue,
+			float64(len(topic.Partitions)),
+			topicName,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			e.internalTopicOfflinePartitions,
+			prometheus.GaugeValue,
+			float64(offlinePartitions),
+			topicName,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			e.internalTopicUnderReplicatedPartitions,
+			prometheus.GaugeValue,
+			float64(underReplicatedPartitions),
+			topicName,
+		)
+	}
+
+	// Describing log dirs requires the Describe permission on the cluster resource, hence the size is only reported
+	// if log dirs are scraped anyway. The log dirs are shared with the log dir collector, so that the brokers are only
+	// asked once per scrape.
+	if !e.minionSvc.Cfg.LogDirs.Enabled {
+		return isOk
+	}
+	sizeByTopicName := make(map[string]int64)
+	for _, logDirRes := range e.minionSvc.DescribeLogDirsCached(ctx) {
+		if logDirRes.Err != nil {
+			e.logger.Error("failed to describe a broker's log dirs for the internal topics",
+				zap.String("broker_id", strconv.Itoa(int(logDirRes.Broker.NodeID))),
+				zap.Error(logDirRes.Err))
+			return false
+		}
+		for _, dir := range logDirRes.LogDirs.Dirs {
+			if kerr.ErrorForCode(dir.ErrorCode) != nil {
+				// Offline log dirs are reported by the log dir metrics already
+				isOk = false
+				continue
+			}
+			for _, topic := range dir.Topics {
+				if !isInternalTopic(topic.Topic) {
+					continue
+				}
+				for _, partition := range topic.Partitions {
+					sizeByTopicName[topic.Topic] += partition.Size
+				}
+			}
+		}
+	}
+	if !isOk {
+		return false
+	}
+	for topicName, size := range sizeByTopicName {
+		ch <- prometheus.MustNewConstMetric(
+			e.internalTopicSize,
+			prometheus.GaugeValue,
+			float64(size),
+			topicName,
+		)
+	}
+
+	return isOk
+}
+
+func isInternalTopic(topicName string) bool {
+	for _, internalTopic := range internalTopics {
+		if topicName == internalTopic {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,64 @@
+package prometheus
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+// collectKRaftQuorum exports the leader, epoch and high water mark of the KRaft controller quorum, as well as the
+// replication lag and the time since the last fetch of each voter.
+func (e *Exporter) collectKRaftQuorum(ctx context.Context, ch chan<- prometheus.Metric) bool {
+	if !e.minionSvc.Cfg.KRaftQuorum.Enabled {
+		return true
+	}
+
+	quorum, err := e.minionSvc.DescribeQuorum(ctx)
+	if err != nil {
+		e.logger.Error("failed to describe kraft quorum", zap.Error(err))
+		return false
+	}
+
+	ch <- prometheus.MustNewConstMetric(
+		e.kraftQuorumLeaderID,
+		prometheus.GaugeValue,
+		float64(quorum.LeaderID),
+	)
+	ch <- prometheus.MustNewConstMetric(
+		e.kraftQuorumLeaderEpoch,
+		prometheus.GaugeValue,
+		float64(quorum.LeaderEpoch),
+	)
+	ch <- prometheus.MustNewConstMetric(
+		e.kraftQuorumHighWaterMark,
+		prometheus.GaugeValue,
+		float64(quorum.HighWatermark),
+	)
+
+	now := time.Now()
+	for _, voter := range quorum.CurrentVoters {
+		replicaID := strconv.Itoa(int(voter.ReplicaID))
+		ch <- prometheus.MustNewConstMetric(
+			e.kraftQuorumVoterLag,
+			prometheus.GaugeValue,
+			float64(max(0, quorum.HighWatermark-voter.LogEndOffset)),
+			replicaID,
+		)
+
+		// The last fetch timestamp is only returned by Kafka 3.3+ and is -1 if unknown
+		if voter.LastFetchTimestamp < 0 {
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(
+			e.kraftQuorumVoterLastFetchAge,
+			prometheus.GaugeValue,
+			now.Sub(time.UnixMilli(voter.LastFetchTimestamp)).Seconds(),
+			replicaID,
+		)
+	}
+
+	return true
+}
@@ -2,11 +2,13 @@ package prometheus
 
 import (
 	"context"
+	"strconv"
+
+	"github.com/cloudhut/kminion/v2/minion"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/twmb/franz-go/pkg/kerr"
 	"github.com/twmb/franz-go/pkg/kgo"
 	"go.uber.org/zap"
-	"strconv"
 )
 
 func (e *Exporter) collectLogDirs(ctx context.Context, ch chan<- prometheus.Metric) bool {
@@ -17,8 +19,9 @@ func (e *Exporter) collectLogDirs(ctx context.Context, ch chan<- prometheus.Metr
 
 	sizeByBroker := make(map[kgo.BrokerMetadata]int64)
 	sizeByTopicName := make(map[string]int64)
+	sizeByPartition := make(map[string]map[int32]int64)
 
-	logDirsSharded := e.minionSvc.DescribeLogDirs(ctx)
+	logDirsSharded := e.minionSvc.DescribeLogDirsCached(ctx)
 	for _, logDirRes := range logDirsSharded {
 		childLogger := e.logger.With(zap.String("broker_address", logDirRes.Broker.Host),
 			zap.String("broker_id", strconv.Itoa(int(logDirRes.Broker.NodeID))))
@@ -29,8 +32,26 @@ func (e *Exporter) collectLogDirs(ctx context.Context, ch chan<- prometheus.Metr
 			continue
 		}
 
+		brokerID := strconv.Itoa(int(logDirRes.Broker.NodeID))
+		capacityByBroker := int64(0)
+		usedByBroker := int64(0)
+		hasCapacity := false
 		for _, dir := range logDirRes.LogDirs.Dirs {
 			err := kerr.ErrorForCode(dir.ErrorCode)
+
+			// A storage error indicates that the log dir is offline, e.g. because of a disk failure
+			isOffline := 0.0
+			if err == kerr.KafkaStorageError {
+				isOffline = 1
+			}
+			ch <- prometheus.MustNewConstMetric(
+				e.logDirOffline,
+				prometheus.GaugeValue,
+				isOffline,
+				brokerID,
+				dir.Dir,
+			)
+
 			if err != nil {
 				childLogger.Error("failed to describe a broker's log dir",
 					zap.String("log_dir", dir.Dir),
@@ -38,14 +59,66 @@ func (e *Exporter) collectLogDirs(ctx context.Context, ch chan<- prometheus.Metr
 				isOk = false
 				continue
 			}
+
+			dirSize := int64(0)
 			for _, topic := range dir.Topics {
+				if _, exists := sizeByPartition[topic.Topic]; !exists {
+					sizeByPartition[topic.Topic] = make(map[int32]int64)
+				}
 				topicSize := int64(0)
 				for _, partition := range topic.Partitions {
 					topicSize += partition.Size
+					sizeByPartition[topic.Topic][partition.Partition] += partition.Size
 				}
 				sizeByTopicName[topic.Topic] += topicSize
 				sizeByBroker[logDirRes.Broker] += topicSize
+				dirSize += topicSize
+			}
+			ch <- prometheus.MustNewConstMetric(
+				e.logDirSize,
+				prometheus.GaugeValue,
+				float64(dirSize),
+				brokerID,
+				dir.Dir,
+			)
+
+			// The capacity of a log dir's volume is only reported by Kafka 3.3+ (DescribeLogDirs v4), older
+			// brokers return -1.
+			if dir.TotalBytes < 0 || dir.UsableBytes < 0 {
+				continue
 			}
+			hasCapacity = true
+			capacityByBroker += dir.TotalBytes
+			usedByBroker += dir.TotalBytes - dir.UsableBytes
+			ch <- prometheus.MustNewConstMetric(
+				e.logDirCapacity,
+				prometheus.GaugeValue,
+				float64(dir.TotalBytes),
+				brokerID,
+				dir.Dir,
+			)
+			ch <- prometheus.MustNewConstMetric(
+				e.logDirUsed,
+				prometheus.GaugeValue,
+				float64(dir.TotalBytes-dir.UsableBytes),
+				brokerID,
+				dir.Dir,
+			)
+		}
+
+		if hasCapacity {
+			ch <- prometheus.MustNewConstMetric(
+				e.brokerLogDirsCapacity,
+				prometheus.GaugeValue,
+				float64(capacityByBroker),
+				brokerID,
+			)
+			ch <- prometheus.MustNewConstMetric(
+				e.brokerLogDirsUsed,
+				prometheus.GaugeValue,
+				float64(usedByBroker),
+				brokerID,
+			)
 		}
 	}
 
@@ -66,21 +139,85 @@ func (e *Exporter) collectLogDirs(ctx context.Context, ch chan<- prometheus.Metr
 		)
 	}
 
+	isOk = e.collectOfflineReplicas(ctx, ch) && isOk
+
 	// If one of the log dir responses returned an error we can not reliably report the topic log dirs, as there might
 	// be additional data on the brokers that failed to respond.
 	if !isOk {
 		return false
 	}
 
-	// Report the total log dir size per topic
+	// Report the total log dir size per topic and partition
 	for topicName, size := range sizeByTopicName {
+		if !e.minionSvc.IsLogDirTopicAllowed(topicName) {
+			continue
+		}
 		ch <- prometheus.MustNewConstMetric(
 			e.topicLogDirSize,
 			prometheus.GaugeValue,
 			float64(size),
 			topicName,
 		)
+
+		if e.minionSvc.Cfg.LogDirs.Granularity == minion.TopicGranularityTopic {
+			continue
+		}
+		for partitionID, partitionSize := range sizeByPartition[topicName] {
+			ch <- prometheus.MustNewConstMetric(
+				e.partitionLogDirSize,
+				prometheus.GaugeValue,
+				float64(partitionSize),
+				topicName,
+				strconv.Itoa(int(partitionID)),
+			)
+		}
 	}
 
 	return isOk
 }
+
+// collectOfflineReplicas exports the partitions that have an offline replica and the number of offline replicas per
+// broker. Brokers do not report the partitions of an offline log dir via DescribeLogDirs, hence the affected
+// partitions are taken from the offline replicas in the metadata.
+func (e *Exporter) collectOfflineReplicas(ctx context.Context, ch chan<- prometheus.Metric) bool {
+	metadata, err := e.minionSvc.GetMetadataCached(ctx)
+	if err != nil {
+		e.logger.Error("failed to get metadata", zap.Error(err))
+		return false
+	}
+
+	offlineReplicasByBroker := make(map[int32]int)
+	for _, broker := range metadata.Brokers {
+		offlineReplicasByBroker[broker.NodeID] = 0
+	}
+	for _, topic := range metadata.Topics {
+		topicName := *topic.Topic
+		for _, partition := range topic.Partitions {
+			for _, brokerID := range partition.OfflineReplicas {
+				offlineReplicasByBroker[brokerID]++
+				if !e.minionSvc.IsLogDirTopicAllowed(topicName) {
+					continue
+				}
+				ch <- prometheus.MustNewConstMetric(
+					e.partitionOfflineReplica,
+					prometheus.GaugeValue,
+					1,
+					topicName,
+					strconv.Itoa(int(partition.Partition)),
+					strconv.Itoa(int(brokerID)),
+				)
+			}
+		}
+	}
+
+	for brokerID, count := range offlineReplicasByBroker {
+		ch <- prometheus.MustNewConstMetric(
+			e.brokerOfflineReplicas,
+			prometheus.GaugeValue,
+			float64(count),
+			strconv.Itoa(int(brokerID)),
+		)
+	}
+
+	return true
+}
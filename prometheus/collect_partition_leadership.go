@@ -0,0 +1,127 @@
+package prometheus
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/twmb/franz-go/pkg/kerr"
+	"go.uber.org/zap"
+
+	"github.com/cloudhut/kminion/v2/minion"
+)
+
+// collectPartitionLeadership reports the leader of each partition and the number of offline partitions, which
+// don't have a leader and therefore can neither be written nor read. Additionally it reports the partitions that
+// are not led by their preferred leader (the first replica), e.g. after a rolling restart. The cluster wide and per
+// broker metrics are reported for all topics, regardless of the allowed and ignored topics.
+func (e *Exporter) collectPartitionLeadership(ctx context.Context, ch chan<- prometheus.Metric) bool {
+	metadata, err := e.minionSvc.GetMetadataCached(ctx)
+	if err != nil {
+		e.logger.Error("failed to get metadata", zap.Error(err))
+		return false
+	}
+
+	isOk := true
+	clusterOfflinePartitions := 0
+
+	// Partitions whose preferred leader is the given broker, and how many of them are led by another broker
+	preferredByBroker := make(map[int32]int, len(metadata.Brokers))
+	imbalancedByBroker := make(map[int32]int, len(metadata.Brokers))
+	for _, broker := range metadata.Brokers {
+		preferredByBroker[broker.NodeID] = 0
+		imbalancedByBroker[broker.NodeID] = 0
+	}
+	for _, topic := range metadata.Topics {
+		topicName := *topic.Topic
+		typedErr := kerr.TypedErrorForCode(topic.ErrorCode)
+		if typedErr != nil {
+			isOk = false
+			e.logger.Warn("failed to get metadata of a specific topic",
+				zap.String("topic_name", topicName),
+				zap.Error(typedErr))
+			continue
+		}
+		exportTopic := e.minionSvc.Cfg.Topics.Enabled && e.minionSvc.IsTopicAllowed(topicName)
+
+		offlinePartitions := 0
+		imbalancedPartitions := 0
+		for _, partition := range topic.Partitions {
+			if partition.Leader < 0 {
+				offlinePartitions++
+			}
+			if len(partition.Replicas) > 0 {
+				preferredLeader := partition.Replicas[0]
+				preferredByBroker[preferredLeader]++
+				if partition.Leader != preferredLeader {
+					imbalancedByBroker[preferredLeader]++
+					imbalancedPartitions++
+				}
+			}
+			if !exportTopic || e.minionSvc.Cfg.Topics.Granularity == minion.TopicGranularityTopic {
+				continue
+			}
+			ch <- prometheus.MustNewConstMetric(
+				e.partitionLeader,
+				prometheus.GaugeValue,
+				float64(partition.Leader),
+				topicName,
+				strconv.Itoa(int(partition.Partition)),
+			)
+		}
+		clusterOfflinePartitions += offlinePartitions
+
+		if !exportTopic {
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(
+			e.topicOfflinePartitions,
+			prometheus.GaugeValue,
+			float64(offlinePartitions),
+			topicName,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			e.topicPreferredLeaderImbalance,
+			prometheus.GaugeValue,
+			float64(imbalancedPartitions),
+			topicName,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			e.topicPreferredLeaderImbalanceRatio,
+			prometheus.GaugeValue,
+			ratio(imbalancedPartitions, len(topic.Partitions)),
+			topicName,
+		)
+	}
+
+	for brokerID, preferred := range preferredByBroker {
+		ch <- prometheus.MustNewConstMetric(
+			e.brokerPreferredLeaderImbalance,
+			prometheus.GaugeValue,
+			float64(imbalancedByBroker[brokerID]),
+			strconv.Itoa(int(brokerID)),
+		)
+		ch <- prometheus.MustNewConstMetric(
+			e.brokerPreferredLeaderImbalanceRatio,
+			prometheus.GaugeValue,
+			ratio(imbalancedByBroker[brokerID], preferred),
+			strconv.Itoa(int(brokerID)),
+		)
+	}
+
+	ch <- prometheus.MustNewConstMetric(
+		e.clusterOfflinePartitions,
+		prometheus.GaugeValue,
+		float64(clusterOfflinePartitions),
+	)
+
+	return isOk
+}
+
+// ratio returns part divided by total, or 0 if total is 0
+func ratio(part int, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(part) / float64(total)
+}
@@ -0,0 +1,96 @@
+package prometheus
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/twmb/franz-go/pkg/kerr"
+	"go.uber.org/zap"
+
+	"github.com/cloudhut/kminion/v2/minion"
+)
+
+// collectProducerStates exports the number of active producer ids per partition, topic and broker, as well as the
+// number of partitions per topic whose producer state count exceeds the configured threshold. Brokers keep the
+// state of each active producer id in memory, so an explosion of producer ids can exhaust the broker's heap.
+func (e *Exporter) collectProducerStates(ctx context.Context, ch chan<- prometheus.Metric) bool {
+	if !e.minionSvc.Cfg.ProducerStates.Enabled {
+		return true
+	}
+
+	shards, err := e.minionSvc.DescribeProducers(ctx)
+	if err != nil {
+		e.logger.Error("failed to describe producers", zap.Error(err))
+		return false
+	}
+
+	isOk := true
+	threshold := e.minionSvc.Cfg.ProducerStates.HighCountThreshold
+	producersByTopic := make(map[string]int)
+	highCountPartitionsByTopic := make(map[string]int)
+	for _, shard := range shards {
+		brokerID := strconv.Itoa(int(shard.Broker.NodeID))
+		if shard.Err != nil {
+			e.logger.Error("failed to describe producers of a broker",
+				zap.String("broker_id", brokerID),
+				zap.Error(shard.Err))
+			isOk = false
+			continue
+		}
+
+		producersByBroker := 0
+		for _, topic := range shard.Producers.Topics {
+			for _, partition := range topic.Partitions {
+				if err := kerr.ErrorForCode(partition.ErrorCode); err != nil {
+					e.logger.Debug("failed to describe producers of a partition",
+						zap.String("topic_name", topic.Topic),
+						zap.Int32("partition_id", partition.Partition),
+						zap.Error(err))
+					isOk = false
+					continue
+				}
+				producers := len(partition.ActiveProducers)
+				producersByBroker += producers
+				producersByTopic[topic.Topic] += producers
+				if producers >= threshold {
+					highCountPartitionsByTopic[topic.Topic]++
+				}
+
+				if e.minionSvc.Cfg.Topics.Granularity == minion.TopicGranularityTopic {
+					continue
+				}
+				ch <- prometheus.MustNewConstMetric(
+					e.partitionActiveProducers,
+					prometheus.GaugeValue,
+					float64(producers),
+					topic.Topic,
+					strconv.Itoa(int(partition.Partition)),
+				)
+			}
+		}
+		ch <- prometheus.MustNewConstMetric(
+			e.brokerActiveProducers,
+			prometheus.GaugeValue,
+			float64(producersByBroker),
+			brokerID,
+		)
+	}
+
+	for topicName, producers := range producersByTopic {
+		ch <- prometheus.MustNewConstMetric(
+			e.topicActiveProducers,
+			prometheus.GaugeValue,
+			float64(producers),
+			topicName,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			e.topicHighProducerCountPartitions,
+			prometheus.GaugeValue,
+			float64(highCountPartitionsByTopic[topicName]),
+			topicName,
+		)
+	}
+
+	return isOk
+}
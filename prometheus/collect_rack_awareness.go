@@ -0,0 +1,75 @@
+package prometheus
+
+import (
+	"context"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+// collectRackAwareness reports the number of brokers per rack and for each topic the number of partitions whose
+// replicas are not spread across as many racks as possible. A partition is expected to span the number of racks in
+// the cluster, or its replication factor if that is lower. Topic metrics are only reported if at least one broker
+// has a rack configured.
+func (e *Exporter) collectRackAwareness(ctx context.Context, ch chan<- prometheus.Metric) bool {
+	metadata, err := e.minionSvc.GetMetadataCached(ctx)
+	if err != nil {
+		e.logger.Error("failed to get kafka metadata", zap.Error(err))
+		return false
+	}
+
+	rackByBroker := make(map[int32]string, len(metadata.Brokers))
+	brokersByRack := make(map[string]int)
+	for _, broker := range metadata.Brokers {
+		rack := ""
+		if broker.Rack != nil {
+			rack = *broker.Rack
+		}
+		rackByBroker[broker.NodeID] = rack
+		brokersByRack[rack]++
+	}
+
+	for rack, brokers := range brokersByRack {
+		ch <- prometheus.MustNewConstMetric(
+			e.rackBrokers,
+			prometheus.GaugeValue,
+			float64(brokers),
+			rack,
+		)
+	}
+
+	_, hasBrokersWithoutRack := brokersByRack[""]
+	if !e.minionSvc.Cfg.Topics.Enabled || (len(brokersByRack) == 1 && hasBrokersWithoutRack) {
+		return true
+	}
+
+	for _, topic := range metadata.Topics {
+		topicName := *topic.Topic
+		if !e.minionSvc.IsTopicAllowed(topicName) {
+			continue
+		}
+
+		notRackAwarePartitions := 0
+		for _, partition := range topic.Partitions {
+			racks := make(map[string]struct{}, len(partition.Replicas))
+			for _, replica := range partition.Replicas {
+				racks[rackByBroker[replica]] = struct{}{}
+			}
+			expectedRacks := len(brokersByRack)
+			if len(partition.Replicas) < expectedRacks {
+				expectedRacks = len(partition.Replicas)
+			}
+			if len(racks) < expectedRacks {
+				notRackAwarePartitions++
+			}
+		}
+		ch <- prometheus.MustNewConstMetric(
+			e.topicNotRackAwarePartitions,
+			prometheus.GaugeValue,
+			float64(notRackAwarePartitions),
+			topicName,
+		)
+	}
+
+	return true
+}
@@ -0,0 +1,127 @@
+package prometheus
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/twmb/franz-go/pkg/kerr"
+	"go.uber.org/zap"
+
+	"github.com/cloudhut/kminion/v2/minion"
+)
+
+// offsetEarliestLocal is the special ListOffsets timestamp that returns the start offset of the local log, which
+// is the first offset that has not been moved to remote storage exclusively (KIP-405).
+const offsetEarliestLocal = -4
+
+// collectTieredStorage reports whether remote storage is enabled for each topic and for topics with remote storage
+// the approximate number of messages that are only available in remote storage and in the local log.
+func (e *Exporter) collectTieredStorage(ctx context.Context, ch chan<- prometheus.Metric) bool {
+	if !e.minionSvc.Cfg.Topics.Enabled || !e.minionSvc.Cfg.Topics.TieredStorage {
+		return true
+	}
+
+	topicConfigs, err := e.minionSvc.GetTopicConfigsCached(ctx)
+	if err != nil {
+		e.logger.Error("failed to get topic configs", zap.Error(err))
+		return false
+	}
+
+	isOk := true
+	remoteTopics := make(map[string]struct{})
+	for _, resource := range topicConfigs.Resources {
+		topicName := resource.ResourceName
+		if !e.minionSvc.IsTopicAllowed(topicName) {
+			continue
+		}
+		if err := kerr.ErrorForCode(resource.ErrorCode); err != nil {
+			isOk = false
+			continue
+		}
+
+		isEnabled := 0.0
+		for _, config := range resource.Configs {
+			if config.Name == "remote.storage.enable" && config.Value != nil && *config.Value == "true" {
+				isEnabled = 1
+				remoteTopics[topicName] = struct{}{}
+			}
+		}
+		ch <- prometheus.MustNewConstMetric(
+			e.topicRemoteStorageEnabled,
+			prometheus.GaugeValue,
+			isEnabled,
+			topicName,
+		)
+	}
+	if len(remoteTopics) == 0 {
+		return isOk
+	}
+
+	lowWaterMarks, err := e.minionSvc.ListOffsetsCached(ctx, -2)
+	if err != nil {
+		e.logger.Error("failed to fetch low water marks", zap.Error(err))
+		return false
+	}
+	highWaterMarks, err := e.minionSvc.ListOffsetsCached(ctx, -1)
+	if err != nil {
+		e.logger.Error("failed to fetch high water marks", zap.Error(err))
+		return false
+	}
+	localStartOffsets, err := e.minionSvc.ListOffsetsCached(ctx, offsetEarliestLocal)
+	if err != nil {
+		e.logger.Error("failed to fetch local log start offsets", zap.Error(err))
+		return false
+	}
+	waterMarks := e.waterMarksByTopic(lowWaterMarks, highWaterMarks)
+
+	for _, topic := range localStartOffsets.Topics {
+		if _, isRemote := remoteTopics[topic.Topic]; !isRemote {
+			continue
+		}
+
+		remoteOnlySum := int64(0)
+		localSum := int64(0)
+		hasErrors := false
+		for _, partition := range topic.Partitions {
+			mark, exists := waterMarks[topic.Topic][partition.Partition]
+			if kerr.ErrorForCode(partition.ErrorCode) != nil || !exists {
+				hasErrors = true
+				isOk = false
+				continue
+			}
+			remoteOnly := max(0, partition.Offset-mark.LowWaterMark)
+			local := max(0, mark.HighWaterMark-partition.Offset)
+			remoteOnlySum += remoteOnly
+			localSum += local
+
+			if e.minionSvc.Cfg.Topics.Granularity == minion.TopicGranularityTopic {
+				continue
+			}
+			ch <- prometheus.MustNewConstMetric(
+				e.partitionRemoteOnlyMessages,
+				prometheus.GaugeValue,
+				float64(remoteOnly),
+				topic.Topic,
+				strconv.Itoa(int(partition.Partition)),
+			)
+		}
+		if hasErrors {
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(
+			e.topicRemoteOnlyMessages,
+			prometheus.GaugeValue,
+			float64(remoteOnlySum),
+			topic.Topic,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			e.topicLocalMessages,
+			prometheus.GaugeValue,
+			float64(localSum),
+			topic.Topic,
+		)
+	}
+
+	return isOk
+}
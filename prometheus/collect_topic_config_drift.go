@@ -0,0 +1,66 @@
+package prometheus
+
+import (
+	"context"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/twmb/franz-go/pkg/kerr"
+	"go.uber.org/zap"
+)
+
+// collectTopicConfigDrift compares the actual topic configs against the configured baselines and reports for each
+// expected config whether the actual value differs (1) or not (0).
+func (e *Exporter) collectTopicConfigDrift(ctx context.Context, ch chan<- prometheus.Metric) bool {
+	if !e.minionSvc.Cfg.Topics.Enabled || len(e.minionSvc.Cfg.Topics.ConfigBaselines) == 0 {
+		return true
+	}
+
+	topicConfigs, err := e.minionSvc.GetTopicConfigsCached(ctx)
+	if err != nil {
+		e.logger.Error("failed to get topic configs", zap.Error(err))
+		return false
+	}
+
+	isOk := true
+	for _, resource := range topicConfigs.Resources {
+		topicName := resource.ResourceName
+		if !e.minionSvc.IsTopicAllowed(topicName) {
+			continue
+		}
+		expectedConfigs := e.minionSvc.ExpectedTopicConfigs(topicName)
+		if len(expectedConfigs) == 0 {
+			continue
+		}
+
+		typedErr := kerr.TypedErrorForCode(resource.ErrorCode)
+		if typedErr != nil {
+			isOk = false
+			e.logger.Warn("failed to get topic config of a specific topic",
+				zap.String("topic_name", topicName),
+				zap.Error(typedErr))
+			continue
+		}
+
+		actualConfigs := make(map[string]*string, len(resource.Configs))
+		for _, config := range resource.Configs {
+			actualConfigs[config.Name] = config.Value
+		}
+
+		for name, expectedValue := range expectedConfigs {
+			drift := 0.0
+			actualValue := actualConfigs[name]
+			if actualValue == nil || *actualValue != expectedValue {
+				drift = 1
+			}
+			ch <- prometheus.MustNewConstMetric(
+				e.topicConfigDrift,
+				prometheus.GaugeValue,
+				drift,
+				topicName,
+				name,
+			)
+		}
+	}
+
+	return isOk
+}
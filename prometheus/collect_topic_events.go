@@ -0,0 +1,48 @@
+package prometheus
+
+import (
+	"context"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+// collectTopicEvents reports how many times each topic has been created or deleted and how many partitions have
+// been added to it, by comparing the cluster metadata between subsequent scrapes.
+func (e *Exporter) collectTopicEvents(ctx context.Context, ch chan<- prometheus.Metric) bool {
+	if !e.minionSvc.Cfg.Topics.Enabled {
+		return true
+	}
+
+	metadata, err := e.minionSvc.GetMetadataCached(ctx)
+	if err != nil {
+		e.logger.Error("failed to get metadata", zap.Error(err))
+		return false
+	}
+
+	for topicName, events := range e.minionSvc.GetTopicEvents(metadata) {
+		if !e.minionSvc.IsTopicAllowed(topicName) {
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(
+			e.topicCreated,
+			prometheus.CounterValue,
+			events.Created,
+			topicName,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			e.topicDeleted,
+			prometheus.CounterValue,
+			events.Deleted,
+			topicName,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			e.topicPartitionsAdded,
+			prometheus.CounterValue,
+			events.PartitionsAdded,
+			topicName,
+		)
+	}
+
+	return true
+}
@@ -20,7 +20,7 @@ func (e *Exporter) collectTopicInfo(ctx context.Context, ch chan<- prometheus.Me
 		return false
 	}
 
-	topicConfigs, err := e.minionSvc.GetTopicConfigs(ctx)
+	topicConfigs, err := e.minionSvc.GetTopicConfigsCached(ctx)
 	if err != nil {
 		e.logger.Error("failed to get topic configs", zap.Error(err))
 		return false
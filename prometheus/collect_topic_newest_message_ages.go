@@ -0,0 +1,87 @@
+package prometheus
+
+import (
+	"context"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/twmb/franz-go/pkg/kerr"
+	"go.uber.org/zap"
+
+	"github.com/cloudhut/kminion/v2/minion"
+)
+
+// collectTopicNewestMessageAges exports the timestamp and age of the newest record in each partition, so that topics
+// which silently stopped receiving data can be detected. The newest record is the one with the largest timestamp as
+// returned by the ListOffsets request with the max timestamp spec (-3), which requires Kafka 3.0+.
+func (e *Exporter) collectTopicNewestMessageAges(ctx context.Context, ch chan<- prometheus.Metric) bool {
+	if !e.minionSvc.Cfg.Topics.NewestMessageAge {
+		return true
+	}
+
+	offsets, err := e.minionSvc.ListOffsetsCached(ctx, -3)
+	if err != nil {
+		e.logger.Error("failed to fetch max timestamp offsets for newest message ages", zap.Error(err))
+		return false
+	}
+
+	isOk := true
+	topicNewestTimestamps := make(map[string]int64)
+	now := time.Now()
+	for _, topic := range offsets.Topics {
+		if !e.minionSvc.IsTopicAllowed(topic.Topic) {
+			continue
+		}
+		for _, partition := range topic.Partitions {
+			err := kerr.ErrorForCode(partition.ErrorCode)
+			if err != nil {
+				isOk = false
+				continue
+			}
+			if partition.Timestamp < 0 {
+				// The partition is empty
+				continue
+			}
+			if partition.Timestamp > topicNewestTimestamps[topic.Topic] {
+				topicNewestTimestamps[topic.Topic] = partition.Timestamp
+			}
+
+			if e.minionSvc.Cfg.Topics.Granularity == minion.TopicGranularityTopic {
+				continue
+			}
+			ch <- prometheus.MustNewConstMetric(
+				e.partitionNewestMessageTimestamp,
+				prometheus.GaugeValue,
+				float64(partition.Timestamp)/1000,
+				topic.Topic,
+				strconv.Itoa(int(partition.Partition)),
+			)
+			ch <- prometheus.MustNewConstMetric(
+				e.partitionNewestMessageAge,
+				prometheus.GaugeValue,
+				math.Max(0, now.Sub(time.UnixMilli(partition.Timestamp)).Seconds()),
+				topic.Topic,
+				strconv.Itoa(int(partition.Partition)),
+			)
+		}
+	}
+
+	for topicName, timestamp := range topicNewestTimestamps {
+		ch <- prometheus.MustNewConstMetric(
+			e.topicNewestMessageTimestamp,
+			prometheus.GaugeValue,
+			float64(timestamp)/1000,
+			topicName,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			e.topicNewestMessageAge,
+			prometheus.GaugeValue,
+			math.Max(0, now.Sub(time.UnixMilli(timestamp)).Seconds()),
+			topicName,
+		)
+	}
+
+	return isOk
+}
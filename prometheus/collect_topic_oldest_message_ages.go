@@ -0,0 +1,62 @@
+package prometheus
+
+import (
+	"context"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+
+	"github.com/cloudhut/kminion/v2/minion"
+)
+
+// collectTopicOldestMessageAges exports the age of the oldest record in each partition, which is the record at the
+// log start offset. If retention works as expected the age stays below the topic's retention.ms (plus the time
+// until the active segment is rolled).
+func (e *Exporter) collectTopicOldestMessageAges(ctx context.Context, ch chan<- prometheus.Metric, logStartOffsets []minion.TopicPartitionOffset) bool {
+	if !e.minionSvc.Cfg.Topics.OldestMessageAge {
+		return true
+	}
+
+	timestamps, err := e.minionSvc.GetRecordTimestamps(ctx, logStartOffsets)
+	if err != nil {
+		e.logger.Error("failed to fetch record timestamps for oldest message ages", zap.Error(err))
+		return false
+	}
+
+	topicAges := make(map[string]float64)
+	now := time.Now()
+	for _, offset := range logStartOffsets {
+		timestamp, exists := timestamps[offset]
+		if !exists {
+			// The record may have been deleted in the meantime, it will be reported on the next scrape
+			continue
+		}
+		ageSeconds := math.Max(0, now.Sub(timestamp).Seconds())
+		topicAges[offset.Topic] = math.Max(topicAges[offset.Topic], ageSeconds)
+
+		if e.minionSvc.Cfg.Topics.Granularity == minion.TopicGranularityTopic {
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(
+			e.partitionOldestMessageAge,
+			prometheus.GaugeValue,
+			ageSeconds,
+			offset.Topic,
+			strconv.Itoa(int(offset.Partition)),
+		)
+	}
+
+	for topicName, ageSeconds := range topicAges {
+		ch <- prometheus.MustNewConstMetric(
+			e.topicOldestMessageAge,
+			prometheus.GaugeValue,
+			ageSeconds,
+			topicName,
+		)
+	}
+
+	return true
+}
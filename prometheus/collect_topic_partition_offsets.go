@@ -32,10 +32,12 @@ func (e *Exporter) collectTopicPartitionOffsets(ctx context.Context, ch chan<- p
 	}
 
 	// Process Low Watermarks
+	lowWaterMarksByTopic := make(map[string]map[int32]int64)
 	for _, topic := range lowWaterMarks.Topics {
 		if !e.minionSvc.IsTopicAllowed(topic.Topic) {
 			continue
 		}
+		lowWaterMarksByTopic[topic.Topic] = make(map[int32]int64, len(topic.Partitions))
 
 		waterMarkSum := int64(0)
 		hasErrors := false
@@ -47,6 +49,7 @@ func (e *Exporter) collectTopicPartitionOffsets(ctx context.Context, ch chan<- p
 				continue
 			}
 			waterMarkSum += partition.Offset
+			lowWaterMarksByTopic[topic.Topic][partition.Partition] = partition.Offset
 			// Let's end here if partition metrics shall not be exposed
 			if e.minionSvc.Cfg.Topics.Granularity == minion.TopicGranularityTopic {
 				continue
@@ -70,12 +73,16 @@ func (e *Exporter) collectTopicPartitionOffsets(ctx context.Context, ch chan<- p
 		}
 	}
 
+	// Offsets of the oldest records in all non-empty partitions
+	var logStartOffsets []minion.TopicPartitionOffset
 	for _, topic := range highWaterMarks.Topics {
 		if !e.minionSvc.IsTopicAllowed(topic.Topic) {
 			continue
 		}
 		waterMarkSum := int64(0)
+		messageCountSum := int64(0)
 		hasErrors := false
+		hasMessageCountErrors := false
 		for _, partition := range topic.Partitions {
 			err := kerr.ErrorForCode(partition.ErrorCode)
 			if err != nil {
@@ -84,6 +91,24 @@ func (e *Exporter) collectTopicPartitionOffsets(ctx context.Context, ch chan<- p
 				continue
 			}
 			waterMarkSum += partition.Offset
+
+			// The message count is approximated by the difference of both watermarks. It includes records that
+			// have been compacted away already, as well as transaction markers.
+			lowWaterMark, hasLowWaterMark := lowWaterMarksByTopic[topic.Topic][partition.Partition]
+			messageCount := partition.Offset - lowWaterMark
+			if !hasLowWaterMark {
+				hasMessageCountErrors = true
+			} else {
+				messageCountSum += messageCount
+				if messageCount > 0 {
+					logStartOffsets = append(logStartOffsets, minion.TopicPartitionOffset{
+						Topic:     topic.Topic,
+						Partition: partition.Partition,
+						Offset:    lowWaterMark,
+					})
+				}
+			}
+
 			// Let's end here if partition metrics shall not be exposed
 			if e.minionSvc.Cfg.Topics.Granularity == minion.TopicGranularityTopic {
 				continue
@@ -95,6 +120,15 @@ func (e *Exporter) collectTopicPartitionOffsets(ctx context.Context, ch chan<- p
 				topic.Topic,
 				strconv.Itoa(int(partition.Partition)),
 			)
+			if hasLowWaterMark {
+				ch <- prometheus.MustNewConstMetric(
+					e.partitionMessageCount,
+					prometheus.GaugeValue,
+					float64(messageCount),
+					topic.Topic,
+					strconv.Itoa(int(partition.Partition)),
+				)
+			}
 		}
 		// We only want to report the sum of all partition marks if we receive watermarks from all partitions
 		if !hasErrors {
@@ -105,7 +139,18 @@ func (e *Exporter) collectTopicPartitionOffsets(ctx context.Context, ch chan<- p
 				topic.Topic,
 			)
 		}
+		if !hasErrors && !hasMessageCountErrors {
+			ch <- prometheus.MustNewConstMetric(
+				e.topicMessageCount,
+				prometheus.GaugeValue,
+				float64(messageCountSum),
+				topic.Topic,
+			)
+		}
 	}
 
+	isOk = e.collectTopicOldestMessageAges(ctx, ch, logStartOffsets) && isOk
+	isOk = e.collectTopicNewestMessageAges(ctx, ch) && isOk
+
 	return isOk
 }
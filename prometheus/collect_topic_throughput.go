@@ -0,0 +1,118 @@
+package prometheus
+
+import (
+	"context"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/twmb/franz-go/pkg/kerr"
+	"go.uber.org/zap"
+)
+
+// collectTopicThroughput exports the approximate number of messages and bytes produced per second to each topic,
+// derived from the high water marks of subsequent scrapes. The bytes per second are estimated by multiplying the
+// message rate with the topic's average message size, which requires the log dirs to be scraped.
+func (e *Exporter) collectTopicThroughput(ctx context.Context, ch chan<- prometheus.Metric) bool {
+	if !e.minionSvc.Cfg.Topics.Enabled || !e.minionSvc.Cfg.Topics.Throughput {
+		return true
+	}
+
+	highWaterMarks, err := e.minionSvc.ListOffsetsCached(ctx, -1)
+	if err != nil {
+		e.logger.Error("failed to fetch high water marks", zap.Error(err))
+		return false
+	}
+	messageRates := e.minionSvc.GetTopicMessageRates(highWaterMarks)
+
+	for topicName, messageRate := range messageRates {
+		if !e.minionSvc.IsTopicAllowed(topicName) {
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(
+			e.topicMessagesPerSecond,
+			prometheus.GaugeValue,
+			messageRate,
+			topicName,
+		)
+	}
+
+	if !e.minionSvc.Cfg.LogDirs.Enabled {
+		return true
+	}
+	avgMessageSizes, err := e.averageMessageSizes(ctx)
+	if err != nil {
+		e.logger.Error("failed to estimate average message sizes", zap.Error(err))
+		return false
+	}
+	for topicName, messageRate := range messageRates {
+		avgMessageSize, exists := avgMessageSizes[topicName]
+		if !exists || !e.minionSvc.IsTopicAllowed(topicName) {
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(
+			e.topicBytesPerSecond,
+			prometheus.GaugeValue,
+			messageRate*avgMessageSize,
+			topicName,
+		)
+	}
+
+	return true
+}
+
+// averageMessageSizes estimates the average message size in bytes of each topic, by dividing the size of a single
+// replica by the number of messages in the topic. Topics without messages and topics whose size or message count is
+// incomplete due to errors are omitted.
+func (e *Exporter) averageMessageSizes(ctx context.Context) (map[string]float64, error) {
+	metadata, err := e.minionSvc.GetMetadataCached(ctx)
+	if err != nil {
+		return nil, err
+	}
+	lowWaterMarks, err := e.minionSvc.ListOffsetsCached(ctx, -2)
+	if err != nil {
+		return nil, err
+	}
+	highWaterMarks, err := e.minionSvc.ListOffsetsCached(ctx, -1)
+	if err != nil {
+		return nil, err
+	}
+
+	sizeByTopic := make(map[string]int64)
+	for _, logDirRes := range e.minionSvc.DescribeLogDirsCached(ctx) {
+		if logDirRes.Err != nil {
+			return nil, logDirRes.Err
+		}
+		for _, dir := range logDirRes.LogDirs.Dirs {
+			if err := kerr.ErrorForCode(dir.ErrorCode); err != nil {
+				return nil, err
+			}
+			for _, topic := range dir.Topics {
+				for _, partition := range topic.Partitions {
+					sizeByTopic[topic.Topic] += partition.Size
+				}
+			}
+		}
+	}
+
+	messageCountByTopic := make(map[string]int64)
+	for topicName, partitions := range e.waterMarksByTopic(lowWaterMarks, highWaterMarks) {
+		for _, mark := range partitions {
+			messageCountByTopic[topicName] += max(0, mark.HighWaterMark-mark.LowWaterMark)
+		}
+	}
+
+	avgMessageSizes := make(map[string]float64)
+	for _, topic := range metadata.Topics {
+		topicName := *topic.Topic
+		if kerr.ErrorForCode(topic.ErrorCode) != nil || len(topic.Partitions) == 0 {
+			continue
+		}
+		replicationFactor := len(topic.Partitions[0].Replicas)
+		messageCount := messageCountByTopic[topicName]
+		if replicationFactor == 0 || messageCount == 0 {
+			continue
+		}
+		avgMessageSizes[topicName] = float64(sizeByTopic[topicName]) / float64(replicationFactor) / float64(messageCount)
+	}
+
+	return avgMessageSizes, nil
+}
@@ -0,0 +1,144 @@
+package prometheus
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+// transactionStates are all states a transaction can be in
+var transactionStates = []string{
+	"Empty", "Ongoing", "PrepareCommit", "PrepareAbort", "CompleteCommit", "CompleteAbort", "Dead", "PrepareEpochFence",
+}
+
+// isOngoingTransactionState returns whether a transaction in the given state has been started, but not completed yet.
+func isOngoingTransactionState(state string) bool {
+	return state == "Ongoing" || state == "PrepareCommit" || state == "PrepareAbort"
+}
+
+// collectTransactions exports the number of transactions per state and the age of ongoing transactions. The age is
+// only reported per transactional id for transactions that exceed the configured max age, so that the cardinality
+// stays bounded.
+func (e *Exporter) collectTransactions(ctx context.Context, ch chan<- prometheus.Metric) bool {
+	if !e.minionSvc.Cfg.Transactions.Enabled {
+		return true
+	}
+
+	transactions, err := e.minionSvc.ListTransactions(ctx)
+	if err != nil {
+		e.logger.Error("failed to list transactions", zap.Error(err))
+		return false
+	}
+
+	countByState := make(map[string]int)
+	for _, state := range transactionStates {
+		countByState[state] = 0
+	}
+	ongoingIDs := make([]string, 0)
+	for _, transaction := range transactions {
+		countByState[transaction.TransactionState]++
+		if isOngoingTransactionState(transaction.TransactionState) {
+			ongoingIDs = append(ongoingIDs, transaction.TransactionalID)
+		}
+	}
+	for state, count := range countByState {
+		ch <- prometheus.MustNewConstMetric(
+			e.transactions,
+			prometheus.GaugeValue,
+			float64(count),
+			state,
+		)
+	}
+
+	described, err := e.minionSvc.DescribeTransactions(ctx, ongoingIDs)
+	if err != nil {
+		e.logger.Error("failed to describe ongoing transactions", zap.Error(err))
+		return false
+	}
+
+	now := time.Now()
+	maxAge := e.minionSvc.Cfg.Transactions.MaxAge
+	oldestAge := 0.0
+	exceedingMaxAge := 0
+	for _, transaction := range described {
+		if !isOngoingTransactionState(transaction.State) || transaction.StartTimestamp < 0 {
+			// The transaction has completed in the meantime
+			continue
+		}
+		age := now.Sub(time.UnixMilli(transaction.StartTimestamp))
+		if age.Seconds() > oldestAge {
+			oldestAge = age.Seconds()
+		}
+		if age < maxAge {
+			continue
+		}
+		exceedingMaxAge++
+		ch <- prometheus.MustNewConstMetric(
+			e.transactionAge,
+			prometheus.GaugeValue,
+			age.Seconds(),
+			transaction.TransactionalID,
+		)
+	}
+	ch <- prometheus.MustNewConstMetric(
+		e.transactionOldestAge,
+		prometheus.GaugeValue,
+		oldestAge,
+	)
+	ch <- prometheus.MustNewConstMetric(
+		e.transactionsExceedingMaxAge,
+		prometheus.GaugeValue,
+		float64(exceedingMaxAge),
+	)
+
+	return true
+}
+
+// collectStuckLastStableOffsets exports the partitions whose last stable offset has not advanced for the configured
+// duration while their high water mark has, as well as the number of such partitions per topic. These partitions
+// block read_committed consumers, which is usually caused by a hanging transaction.
+func (e *Exporter) collectStuckLastStableOffsets(ctx context.Context, ch chan<- prometheus.Metric) bool {
+	if !e.minionSvc.Cfg.Transactions.StuckLastStableOffsets {
+		return true
+	}
+
+	stuck, err := e.minionSvc.GetStuckLastStableOffsets(ctx)
+	if err != nil {
+		e.logger.Error("failed to get stuck last stable offsets", zap.Error(err))
+		return false
+	}
+
+	metadata, err := e.minionSvc.GetMetadataCached(ctx)
+	if err != nil {
+		e.logger.Error("failed to get metadata", zap.Error(err))
+		return false
+	}
+
+	for _, topic := range metadata.Topics {
+		topicName := *topic.Topic
+		if !e.minionSvc.IsTopicAllowed(topicName) {
+			continue
+		}
+
+		for partitionID, stuckFor := range stuck[topicName] {
+			ch <- prometheus.MustNewConstMetric(
+				e.partitionLastStableOffsetStuck,
+				prometheus.GaugeValue,
+				stuckFor.Seconds(),
+				topicName,
+				strconv.Itoa(int(partitionID)),
+			)
+		}
+		ch <- prometheus.MustNewConstMetric(
+			e.topicLastStableOffsetStuckPartitions,
+			prometheus.GaugeValue,
+			float64(len(stuck[topicName])),
+			topicName,
+		)
+	}
+
+	return true
+}
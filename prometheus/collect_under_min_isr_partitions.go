@@ -0,0 +1,110 @@
+package prometheus
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/twmb/franz-go/pkg/kerr"
+	"go.uber.org/zap"
+)
+
+// collectUnderMinISRPartitions reports the number of partitions per topic whose in-sync replica set is smaller than
+// the topic's min.insync.replicas. Producers with acks=all can't write to these partitions. Additionally topics
+// whose min.insync.replicas is not below their replication factor are reported, as they become unwritable for
+// producers with acks=all as soon as a single broker fails.
+func (e *Exporter) collectUnderMinISRPartitions(ctx context.Context, ch chan<- prometheus.Metric) bool {
+	if !e.minionSvc.Cfg.Topics.Enabled {
+		return true
+	}
+
+	metadata, err := e.minionSvc.GetMetadataCached(ctx)
+	if err != nil {
+		e.logger.Error("failed to get metadata", zap.Error(err))
+		return false
+	}
+
+	topicConfigs, err := e.minionSvc.GetTopicConfigsCached(ctx)
+	if err != nil {
+		e.logger.Error("failed to get topic configs", zap.Error(err))
+		return false
+	}
+
+	isOk := true
+	minISRByTopic := make(map[string]int)
+	for _, resource := range topicConfigs.Resources {
+		if kerr.ErrorForCode(resource.ErrorCode) != nil {
+			// Already logged when collecting the topic info
+			continue
+		}
+		for _, config := range resource.Configs {
+			if config.Name != "min.insync.replicas" || config.Value == nil {
+				continue
+			}
+			minISR, err := strconv.Atoi(*config.Value)
+			if err != nil {
+				e.logger.Warn("failed to parse min.insync.replicas of topic",
+					zap.String("topic_name", resource.ResourceName),
+					zap.String("value", *config.Value),
+					zap.Error(err))
+				isOk = false
+				continue
+			}
+			minISRByTopic[resource.ResourceName] = minISR
+		}
+	}
+
+	for _, topic := range metadata.Topics {
+		topicName := *topic.Topic
+		if !e.minionSvc.IsTopicAllowed(topicName) || kerr.ErrorForCode(topic.ErrorCode) != nil {
+			continue
+		}
+		replicationFactor := 0
+		for _, partition := range topic.Partitions {
+			replicationFactor = max(replicationFactor, len(partition.Replicas))
+		}
+		if minReplicationFactor := e.minionSvc.Cfg.Topics.MinReplicationFactor; minReplicationFactor > 0 {
+			isBelowMinimum := 0.0
+			if replicationFactor < minReplicationFactor {
+				isBelowMinimum = 1
+			}
+			ch <- prometheus.MustNewConstMetric(
+				e.topicReplicationFactorBelowMinimum,
+				prometheus.GaugeValue,
+				isBelowMinimum,
+				topicName,
+			)
+		}
+
+		minISR, exists := minISRByTopic[topicName]
+		if !exists {
+			continue
+		}
+
+		isMisconfigured := 0.0
+		if minISR >= replicationFactor {
+			isMisconfigured = 1
+		}
+		ch <- prometheus.MustNewConstMetric(
+			e.topicMinISRMisconfigured,
+			prometheus.GaugeValue,
+			isMisconfigured,
+			topicName,
+		)
+
+		underMinISR := 0
+		for _, partition := range topic.Partitions {
+			if len(partition.ISR) < minISR {
+				underMinISR++
+			}
+		}
+		ch <- prometheus.MustNewConstMetric(
+			e.topicPartitionsUnderMinISR,
+			prometheus.GaugeValue,
+			float64(underMinISR),
+			topicName,
+		)
+	}
+
+	return isOk
+}
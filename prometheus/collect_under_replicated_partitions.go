@@ -0,0 +1,98 @@
+package prometheus
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/twmb/franz-go/pkg/kerr"
+	"go.uber.org/zap"
+
+	"github.com/cloudhut/kminion/v2/minion"
+)
+
+// collectUnderReplicatedPartitions reports partitions whose in-sync replica set is smaller than their replica set.
+// Topic and partition metrics respect the allowed and ignored topics, while the per broker aggregate counts the
+// under-replicated partitions of all topics led by each broker.
+func (e *Exporter) collectUnderReplicatedPartitions(ctx context.Context, ch chan<- prometheus.Metric) bool {
+	if !e.minionSvc.Cfg.Topics.Enabled {
+		return true
+	}
+
+	metadata, err := e.minionSvc.GetMetadataCached(ctx)
+	if err != nil {
+		e.logger.Error("failed to get metadata", zap.Error(err))
+		return false
+	}
+
+	isOk := true
+	underReplicatedByBroker := make(map[int32]int, len(metadata.Brokers))
+	for _, broker := range metadata.Brokers {
+		underReplicatedByBroker[broker.NodeID] = 0
+	}
+
+	for _, topic := range metadata.Topics {
+		topicName := *topic.Topic
+		typedErr := kerr.TypedErrorForCode(topic.ErrorCode)
+		if typedErr != nil {
+			isOk = false
+			e.logger.Warn("failed to get metadata of a specific topic",
+				zap.String("topic_name", topicName),
+				zap.Error(typedErr))
+			continue
+		}
+		isAllowed := e.minionSvc.IsTopicAllowed(topicName)
+
+		underReplicatedPartitions := 0
+		for _, partition := range topic.Partitions {
+			isUnderReplicated := len(partition.ISR) < len(partition.Replicas)
+			if isUnderReplicated {
+				underReplicatedByBroker[partition.Leader]++
+			}
+			if !isAllowed {
+				continue
+			}
+
+			value := float64(0)
+			if isUnderReplicated {
+				underReplicatedPartitions++
+				value = 1
+			}
+			if e.minionSvc.Cfg.Topics.Granularity == minion.TopicGranularityTopic {
+				continue
+			}
+			ch <- prometheus.MustNewConstMetric(
+				e.partitionUnderReplicated,
+				prometheus.GaugeValue,
+				value,
+				topicName,
+				strconv.Itoa(int(partition.Partition)),
+			)
+		}
+
+		if !isAllowed {
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(
+			e.topicUnderReplicatedPartitions,
+			prometheus.GaugeValue,
+			float64(underReplicatedPartitions),
+			topicName,
+		)
+	}
+
+	for brokerID, count := range underReplicatedByBroker {
+		if brokerID < 0 {
+			// Partitions without a leader are offline, which is not attributable to a broker
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(
+			e.brokerUnderReplicatedPartitions,
+			prometheus.GaugeValue,
+			float64(count),
+			strconv.Itoa(int(brokerID)),
+		)
+	}
+
+	return isOk
+}
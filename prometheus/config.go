@@ -4,6 +4,10 @@ type Config struct {
 	Host      string `koanf:"host"`
 	Port      int    `koanf:"port"`
 	Namespace string `koanf:"namespace"`
+
+	// EnableOpenMetrics serves the OpenMetrics exposition format to scrapers that request it. This is required for
+	// exporting exemplars, which are attached to the end-to-end latency histograms.
+	EnableOpenMetrics bool `koanf:"enableOpenMetrics"`
 }
 
 func (c *Config) SetDefaults() {
@@ -24,12 +24,33 @@ type Exporter struct {
 
 	// Kafka metrics
 	// General
-	clusterInfo *prometheus.Desc
-	brokerInfo  *prometheus.Desc
+	clusterInfo              *prometheus.Desc
+	clusterControllerID      *prometheus.Desc
+	brokerInfo               *prometheus.Desc
+	clusterOfflinePartitions *prometheus.Desc
+	brokerLeaderPartitions   *prometheus.Desc
+	brokerReplicaPartitions  *prometheus.Desc
+	brokerLeaderSkew         *prometheus.Desc
+	brokerReplicaSkew        *prometheus.Desc
+	brokerConfigInfo         *prometheus.Desc
+	brokerVersionInfo        *prometheus.Desc
+	brokerAPIMinVersion      *prometheus.Desc
+	brokerAPIMaxVersion      *prometheus.Desc
+	brokerUp                 *prometheus.Desc
+	brokerProbeDialDuration  *prometheus.Desc
 
 	// Log Dir Sizes
-	brokerLogDirSize *prometheus.Desc
-	topicLogDirSize  *prometheus.Desc
+	brokerLogDirSize        *prometheus.Desc
+	topicLogDirSize         *prometheus.Desc
+	partitionLogDirSize     *prometheus.Desc
+	brokerLogDirsCapacity   *prometheus.Desc
+	brokerLogDirsUsed       *prometheus.Desc
+	logDirSize              *prometheus.Desc
+	logDirCapacity          *prometheus.Desc
+	logDirUsed              *prometheus.Desc
+	logDirOffline           *prometheus.Desc
+	brokerOfflineReplicas   *prometheus.Desc
+	partitionOfflineReplica *prometheus.Desc
 
 	// Topic / Partition
 	topicInfo              *prometheus.Desc
@@ -38,33 +59,143 @@ type Exporter struct {
 	topicLowWaterMarkSum   *prometheus.Desc
 	partitionLowWaterMark  *prometheus.Desc
 
+	// Message counts and ages
+	topicMessageCount         *prometheus.Desc
+	partitionMessageCount     *prometheus.Desc
+	topicOldestMessageAge     *prometheus.Desc
+	partitionOldestMessageAge *prometheus.Desc
+
+	// Newest message timestamps and ages
+	topicNewestMessageTimestamp     *prometheus.Desc
+	partitionNewestMessageTimestamp *prometheus.Desc
+	topicNewestMessageAge           *prometheus.Desc
+	partitionNewestMessageAge       *prometheus.Desc
+
+	// Topic config drift
+	topicConfigDrift *prometheus.Desc
+
+	// Throughput
+	topicMessagesPerSecond *prometheus.Desc
+	topicBytesPerSecond    *prometheus.Desc
+
+	// Tiered storage
+	topicRemoteStorageEnabled   *prometheus.Desc
+	topicRemoteOnlyMessages     *prometheus.Desc
+	partitionRemoteOnlyMessages *prometheus.Desc
+	topicLocalMessages          *prometheus.Desc
+
+	// Internal topics
+	internalTopicPartitions                *prometheus.Desc
+	internalTopicOfflinePartitions         *prometheus.Desc
+	internalTopicUnderReplicatedPartitions *prometheus.Desc
+	internalTopicSize                      *prometheus.Desc
+
+	// Rack awareness
+	rackBrokers                 *prometheus.Desc
+	topicNotRackAwarePartitions *prometheus.Desc
+
+	// Topic events
+	topicCreated         *prometheus.Desc
+	topicDeleted         *prometheus.Desc
+	topicPartitionsAdded *prometheus.Desc
+
+	// Under-replicated partitions
+	partitionUnderReplicated           *prometheus.Desc
+	topicUnderReplicatedPartitions     *prometheus.Desc
+	brokerUnderReplicatedPartitions    *prometheus.Desc
+	topicPartitionsUnderMinISR         *prometheus.Desc
+	topicMinISRMisconfigured           *prometheus.Desc
+	topicReplicationFactorBelowMinimum *prometheus.Desc
+
+	// Partition leadership
+	partitionLeader                     *prometheus.Desc
+	topicOfflinePartitions              *prometheus.Desc
+	topicPreferredLeaderImbalance       *prometheus.Desc
+	topicPreferredLeaderImbalanceRatio  *prometheus.Desc
+	brokerPreferredLeaderImbalance      *prometheus.Desc
+	brokerPreferredLeaderImbalanceRatio *prometheus.Desc
+
+	// ACLs
+	aclCount         *prometheus.Desc
+	aclWildcardCount *prometheus.Desc
+
+	// Client Quotas
+	clientQuota *prometheus.Desc
+
+	// Delegation Tokens
+	delegationTokenExpiry             *prometheus.Desc
+	delegationTokenSecondsUntilExpiry *prometheus.Desc
+
+	// KRaft Quorum
+	kraftQuorumLeaderID          *prometheus.Desc
+	kraftQuorumLeaderEpoch       *prometheus.Desc
+	kraftQuorumHighWaterMark     *prometheus.Desc
+	kraftQuorumVoterLag          *prometheus.Desc
+	kraftQuorumVoterLastFetchAge *prometheus.Desc
+
+	// Producer states
+	partitionActiveProducers         *prometheus.Desc
+	topicActiveProducers             *prometheus.Desc
+	brokerActiveProducers            *prometheus.Desc
+	topicHighProducerCountPartitions *prometheus.Desc
+
+	// Transactions
+	transactions                         *prometheus.Desc
+	transactionAge                       *prometheus.Desc
+	transactionOldestAge                 *prometheus.Desc
+	transactionsExceedingMaxAge          *prometheus.Desc
+	partitionLastStableOffsetStuck       *prometheus.Desc
+	topicLastStableOffsetStuckPartitions *prometheus.Desc
+
 	// Consumer Groups
-	consumerGroupInfo                    *prometheus.Desc
-	consumerGroupMembers                 *prometheus.Desc
-	consumerGroupMembersEmpty            *prometheus.Desc
-	consumerGroupTopicMembers            *prometheus.Desc
-	consumerGroupAssignedTopicPartitions *prometheus.Desc
-	consumerGroupTopicOffsetSum          *prometheus.Desc
-	consumerGroupTopicPartitionLag       *prometheus.Desc
-	consumerGroupTopicLag                *prometheus.Desc
-	offsetCommits                        *prometheus.Desc
+	consumerGroupInfo                     *prometheus.Desc
+	consumerGroupState                    *prometheus.Desc
+	consumerGroupRebalances               *prometheus.Desc
+	consumerGroupAbandoned                *prometheus.Desc
+	consumerGroupNewestCommitAge          *prometheus.Desc
+	brokerCoordinatedGroups               *prometheus.Desc
+	consumerGroupMemberAssignedPartitions *prometheus.Desc
+	consumerGroupMembers                  *prometheus.Desc
+	consumerGroupMembersEmpty             *prometheus.Desc
+	consumerGroupTopicMembers             *prometheus.Desc
+	consumerGroupAssignedTopicPartitions  *prometheus.Desc
+	consumerGroupTopicOffsetSum           *prometheus.Desc
+	consumerGroupTopicPartitionLag        *prometheus.Desc
+	consumerGroupTopicLag                 *prometheus.Desc
+	consumerGroupTopicMaxLag              *prometheus.Desc
+	consumerGroupTopicLagVelocity         *prometheus.Desc
+	consumerGroupTopicLagETA              *prometheus.Desc
+	consumerGroupLagStatus                *prometheus.Desc
+	consumerGroupTopicPartitionLagSecs    *prometheus.Desc
+	consumerGroupTopicLagSecs             *prometheus.Desc
+	offsetCommits                         *prometheus.Desc
+	topicOffsetCommits                    *prometheus.Desc
+	topicOffsetRewinds                    *prometheus.Desc
+
+	// extendedDescs are the Descs whose metrics get the labels extracted by the label rules, see newDesc
+	extendedDescs map[*prometheus.Desc]extendedDesc
 }
 
 func NewExporter(cfg Config, logger *zap.Logger, minionSvc *minion.Service) (*Exporter, error) {
-	return &Exporter{cfg: cfg, logger: logger.Named("prometheus"), minionSvc: minionSvc}, nil
+	return &Exporter{
+		cfg:           cfg,
+		logger:        logger.Named("prometheus"),
+		minionSvc:     minionSvc,
+		extendedDescs: make(map[*prometheus.Desc]extendedDesc),
+	}, nil
 }
 
 func (e *Exporter) InitializeMetrics() {
 	// Exporter / internal metrics
 	// Exporter up
-	e.exporterUp = prometheus.NewDesc(
+	e.exporterUp = e.newDesc(
 		prometheus.BuildFQName(e.cfg.Namespace, "exporter", "up"),
 		"Build info about this Prometheus Exporter. Gauge value is 0 if one or more scrapes have failed.",
 		nil,
 		map[string]string{"version": os.Getenv("VERSION")},
 	)
 	// OffsetConsumer records consumed
-	e.offsetConsumerRecordsConsumed = prometheus.NewDesc(
+	e.offsetConsumerRecordsConsumed = e.newDesc(
 		prometheus.BuildFQName(e.cfg.Namespace, "exporter", "offset_consumer_records_consumed_total"),
 		"The number of offset records that have been consumed by the internal offset consumer",
 		[]string{},
@@ -73,33 +204,166 @@ func (e *Exporter) InitializeMetrics() {
 
 	// Kafka metrics
 	// Cluster info
-	e.clusterInfo = prometheus.NewDesc(
+	e.clusterInfo = e.newDesc(
 		prometheus.BuildFQName(e.cfg.Namespace, "kafka", "cluster_info"),
 		"Kafka cluster information",
 		[]string{"cluster_version", "broker_count", "controller_id", "cluster_id"},
 		nil,
 	)
+	// Controller ID, as numeric value so that controller changes can be detected with changes()
+	e.clusterControllerID = e.newDesc(
+		prometheus.BuildFQName(e.cfg.Namespace, "kafka", "cluster_controller_id"),
+		"Broker id of the current controller",
+		[]string{"cluster_id"},
+		nil,
+	)
 	// Broker Info
-	e.brokerInfo = prometheus.NewDesc(
+	e.brokerInfo = e.newDesc(
 		prometheus.BuildFQName(e.cfg.Namespace, "kafka", "broker_info"),
 		"Kafka broker information",
 		[]string{"broker_id", "address", "port", "rack_id", "is_controller"},
 		nil,
 	)
+	// Broker probes
+	e.brokerUp = e.newDesc(
+		prometheus.BuildFQName(e.cfg.Namespace, "kafka", "broker_up"),
+		"Whether the most recent probe of the broker succeeded (1) or not (0)",
+		[]string{"broker_id", "address"},
+		nil,
+	)
+	e.brokerProbeDialDuration = e.newDesc(
+		prometheus.BuildFQName(e.cfg.Namespace, "kafka", "broker_probe_dial_duration_seconds"),
+		"Time it took to establish the connection to the broker during the most recent successful probe, including the TLS handshake. Only reported if that probe established a new connection rather than reusing one",
+		[]string{"broker_id", "address"},
+		nil,
+	)
+	// Broker configs
+	e.brokerConfigInfo = e.newDesc(
+		prometheus.BuildFQName(e.cfg.Namespace, "kafka", "broker_config_info"),
+		"Kafka broker config values of the allowed config keys",
+		[]string{"broker_id", "config_key", "config_value"},
+		nil,
+	)
+	// Broker API versions
+	e.brokerVersionInfo = e.newDesc(
+		prometheus.BuildFQName(e.cfg.Namespace, "kafka", "broker_version_info"),
+		"Kafka version of the broker, as guessed from its supported API versions",
+		[]string{"broker_id", "version"},
+		nil,
+	)
+	e.brokerAPIMinVersion = e.newDesc(
+		prometheus.BuildFQName(e.cfg.Namespace, "kafka", "broker_api_min_version"),
+		"Minimum version of the API key that is supported by the broker",
+		[]string{"broker_id", "api_key"},
+		nil,
+	)
+	e.brokerAPIMaxVersion = e.newDesc(
+		prometheus.BuildFQName(e.cfg.Namespace, "kafka", "broker_api_max_version"),
+		"Maximum version of the API key that is supported by the broker",
+		[]string{"broker_id", "api_key"},
+		nil,
+	)
+	// Broker leader and replica counts
+	e.brokerLeaderPartitions = e.newDesc(
+		prometheus.BuildFQName(e.cfg.Namespace, "kafka", "broker_leader_partitions"),
+		"Number of partitions across all topics that are led by the broker",
+		[]string{"broker_id"},
+		nil,
+	)
+	e.brokerReplicaPartitions = e.newDesc(
+		prometheus.BuildFQName(e.cfg.Namespace, "kafka", "broker_replica_partitions"),
+		"Number of partition replicas across all topics that are assigned to the broker",
+		[]string{"broker_id"},
+		nil,
+	)
+	e.brokerLeaderSkew = e.newDesc(
+		prometheus.BuildFQName(e.cfg.Namespace, "kafka", "broker_leader_skew_percent"),
+		"Deviation of the broker's leader count from the cluster average in percent",
+		[]string{"broker_id"},
+		nil,
+	)
+	e.brokerReplicaSkew = e.newDesc(
+		prometheus.BuildFQName(e.cfg.Namespace, "kafka", "broker_replica_skew_percent"),
+		"Deviation of the broker's replica count from the cluster average in percent",
+		[]string{"broker_id"},
+		nil,
+	)
+
+	// Offline partitions
+	e.clusterOfflinePartitions = e.newDesc(
+		prometheus.BuildFQName(e.cfg.Namespace, "kafka", "cluster_offline_partitions"),
+		"Number of partitions across all topics that don't have a leader",
+		nil,
+		nil,
+	)
 
 	// LogDir sizes
-	e.brokerLogDirSize = prometheus.NewDesc(
+	e.brokerLogDirSize = e.newDesc(
 		prometheus.BuildFQName(e.cfg.Namespace, "kafka", "broker_log_dir_size_total_bytes"),
 		"The summed size in bytes of all log dirs for a given broker",
 		[]string{"broker_id", "address", "port", "rack_id"},
 		nil,
 	)
-	e.topicLogDirSize = prometheus.NewDesc(
+	e.topicLogDirSize = e.newDesc(
 		prometheus.BuildFQName(e.cfg.Namespace, "kafka", "topic_log_dir_size_total_bytes"),
 		"The summed size in bytes of partitions for a given topic. This includes the used space for replica partitions.",
 		[]string{"topic_name"},
 		nil,
 	)
+	e.partitionLogDirSize = e.newDesc(
+		prometheus.BuildFQName(e.cfg.Namespace, "kafka", "topic_partition_log_dir_size_total_bytes"),
+		"The summed size in bytes of a given partition. This includes the used space for replica partitions.",
+		[]string{"topic_name", "partition_id"},
+		nil,
+	)
+	e.brokerLogDirsCapacity = e.newDesc(
+		prometheus.BuildFQName(e.cfg.Namespace, "kafka", "broker_log_dirs_capacity_bytes"),
+		"The summed capacity in bytes of the volumes of all log dirs for a given broker. Requires Kafka 3.3+.",
+		[]string{"broker_id"},
+		nil,
+	)
+	e.brokerLogDirsUsed = e.newDesc(
+		prometheus.BuildFQName(e.cfg.Namespace, "kafka", "broker_log_dirs_used_bytes"),
+		"The summed used space in bytes of the volumes of all log dirs for a given broker. Requires Kafka 3.3+.",
+		[]string{"broker_id"},
+		nil,
+	)
+	e.logDirSize = e.newDesc(
+		prometheus.BuildFQName(e.cfg.Namespace, "kafka", "log_dir_size_bytes"),
+		"The summed size in bytes of all partitions in a given log dir",
+		[]string{"broker_id", "log_dir"},
+		nil,
+	)
+	e.logDirCapacity = e.newDesc(
+		prometheus.BuildFQName(e.cfg.Namespace, "kafka", "log_dir_capacity_bytes"),
+		"The capacity in bytes of the volume of a given log dir. Requires Kafka 3.3+.",
+		[]string{"broker_id", "log_dir"},
+		nil,
+	)
+	e.logDirUsed = e.newDesc(
+		prometheus.BuildFQName(e.cfg.Namespace, "kafka", "log_dir_used_bytes"),
+		"The used space in bytes of the volume of a given log dir. Requires Kafka 3.3+.",
+		[]string{"broker_id", "log_dir"},
+		nil,
+	)
+	e.logDirOffline = e.newDesc(
+		prometheus.BuildFQName(e.cfg.Namespace, "kafka", "log_dir_offline"),
+		"Whether a given log dir is offline (1) or not (0), e.g. because of a disk failure",
+		[]string{"broker_id", "log_dir"},
+		nil,
+	)
+	e.brokerOfflineReplicas = e.newDesc(
+		prometheus.BuildFQName(e.cfg.Namespace, "kafka", "broker_offline_replicas"),
+		"Number of partition replicas that are offline on a given broker, e.g. because their log dir failed",
+		[]string{"broker_id"},
+		nil,
+	)
+	e.partitionOfflineReplica = e.newDesc(
+		prometheus.BuildFQName(e.cfg.Namespace, "kafka", "topic_partition_offline_replica"),
+		"Reports 1 for each partition replica that is offline on a given broker. Only offline replicas are reported.",
+		[]string{"topic_name", "partition_id", "broker_id"},
+		nil,
+	)
 
 	// Topic / Partition metrics
 	// Topic info
@@ -108,105 +372,580 @@ func (e *Exporter) InitializeMetrics() {
 		// prometheus does not allow . in label keys
 		labels = append(labels, strings.ReplaceAll(key, ".", "_"))
 	}
-	e.topicInfo = prometheus.NewDesc(
+	e.topicInfo = e.newDesc(
 		prometheus.BuildFQName(e.cfg.Namespace, "kafka", "topic_info"),
 		"Info labels for a given topic",
 		labels,
 		nil,
 	)
 	// Partition Low Water Mark
-	e.partitionLowWaterMark = prometheus.NewDesc(
+	e.partitionLowWaterMark = e.newDesc(
 		prometheus.BuildFQName(e.cfg.Namespace, "kafka", "topic_partition_low_water_mark"),
 		"Partition Low Water Mark",
 		[]string{"topic_name", "partition_id"},
 		nil,
 	)
 	// Topic Low Water Mark Sum
-	e.topicLowWaterMarkSum = prometheus.NewDesc(
+	e.topicLowWaterMarkSum = e.newDesc(
 		prometheus.BuildFQName(e.cfg.Namespace, "kafka", "topic_low_water_mark_sum"),
 		"Sum of all the topic's partition low water marks",
 		[]string{"topic_name"},
 		nil,
 	)
+	// Approximate message counts
+	e.partitionMessageCount = e.newDesc(
+		prometheus.BuildFQName(e.cfg.Namespace, "kafka", "topic_partition_approx_message_count"),
+		"Approximate number of messages in the partition (high water mark minus low water mark)",
+		[]string{"topic_name", "partition_id"},
+		nil,
+	)
+	e.topicMessageCount = e.newDesc(
+		prometheus.BuildFQName(e.cfg.Namespace, "kafka", "topic_approx_message_count"),
+		"Approximate number of messages in the topic (sum of all partitions' high water mark minus low water mark)",
+		[]string{"topic_name"},
+		nil,
+	)
+	// Oldest message ages
+	e.partitionOldestMessageAge = e.newDesc(
+		prometheus.BuildFQName(e.cfg.Namespace, "kafka", "topic_partition_oldest_message_age_seconds"),
+		"Age of the oldest message in the partition, which is the message at the low water mark",
+		[]string{"topic_name", "partition_id"},
+		nil,
+	)
+	e.topicOldestMessageAge = e.newDesc(
+		prometheus.BuildFQName(e.cfg.Namespace, "kafka", "topic_oldest_message_age_seconds"),
+		"Age of the oldest message across all of the topic's partitions",
+		[]string{"topic_name"},
+		nil,
+	)
+	// Newest message timestamps and ages
+	e.partitionNewestMessageTimestamp = e.newDesc(
+		prometheus.BuildFQName(e.cfg.Namespace, "kafka", "topic_partition_newest_message_timestamp_seconds"),
+		"Unix timestamp in seconds of the newest message in the partition, which is the message with the largest timestamp",
+		[]string{"topic_name", "partition_id"},
+		nil,
+	)
+	e.topicNewestMessageTimestamp = e.newDesc(
+		prometheus.BuildFQName(e.cfg.Namespace, "kafka", "topic_newest_message_timestamp_seconds"),
+		"Unix timestamp in seconds of the newest message across all of the topic's partitions",
+		[]string{"topic_name"},
+		nil,
+	)
+	e.partitionNewestMessageAge = e.newDesc(
+		prometheus.BuildFQName(e.cfg.Namespace, "kafka", "topic_partition_newest_message_age_seconds"),
+		"Age of the newest message in the partition, which is the time since the partition last received data",
+		[]string{"topic_name", "partition_id"},
+		nil,
+	)
+	e.topicNewestMessageAge = e.newDesc(
+		prometheus.BuildFQName(e.cfg.Namespace, "kafka", "topic_newest_message_age_seconds"),
+		"Age of the newest message across all of the topic's partitions, which is the time since the topic last received data",
+		[]string{"topic_name"},
+		nil,
+	)
 	// Partition High Water Mark
-	e.partitionHighWaterMark = prometheus.NewDesc(
+	e.partitionHighWaterMark = e.newDesc(
 		prometheus.BuildFQName(e.cfg.Namespace, "kafka", "topic_partition_high_water_mark"),
 		"Partition High Water Mark",
 		[]string{"topic_name", "partition_id"},
 		nil,
 	)
 	// Topic Low Water Mark Sum
-	e.topicHighWaterMarkSum = prometheus.NewDesc(
+	e.topicHighWaterMarkSum = e.newDesc(
 		prometheus.BuildFQName(e.cfg.Namespace, "kafka", "topic_high_water_mark_sum"),
 		"Sum of all the topic's partition high water marks",
 		[]string{"topic_name"},
 		nil,
 	)
 
+	// Under-replicated partitions
+	// Partition under-replicated
+	e.partitionUnderReplicated = e.newDesc(
+		prometheus.BuildFQName(e.cfg.Namespace, "kafka", "topic_partition_under_replicated"),
+		"Whether the partition has fewer in-sync replicas than replicas (1) or not (0)",
+		[]string{"topic_name", "partition_id"},
+		nil,
+	)
+	// Topic under-replicated partitions
+	e.topicUnderReplicatedPartitions = e.newDesc(
+		prometheus.BuildFQName(e.cfg.Namespace, "kafka", "topic_under_replicated_partitions"),
+		"Number of the topic's partitions that have fewer in-sync replicas than replicas",
+		[]string{"topic_name"},
+		nil,
+	)
+	// Broker under-replicated partitions
+	e.brokerUnderReplicatedPartitions = e.newDesc(
+		prometheus.BuildFQName(e.cfg.Namespace, "kafka", "broker_under_replicated_partitions"),
+		"Number of under-replicated partitions the broker is the leader of, across all topics",
+		[]string{"broker_id"},
+		nil,
+	)
+
+	// Topic partitions under min ISR
+	e.topicPartitionsUnderMinISR = e.newDesc(
+		prometheus.BuildFQName(e.cfg.Namespace, "kafka", "topic_partitions_under_min_isr"),
+		"Number of the topic's partitions that have fewer in-sync replicas than the topic's min.insync.replicas. Producers with acks=all fail to write to these partitions",
+		[]string{"topic_name"},
+		nil,
+	)
+	e.topicMinISRMisconfigured = e.newDesc(
+		prometheus.BuildFQName(e.cfg.Namespace, "kafka", "topic_min_insync_replicas_misconfigured"),
+		"Reports 1 if the topic's min.insync.replicas is not lower than its replication factor, otherwise 0. Producers with acks=all fail to write to such topics as soon as a single broker fails",
+		[]string{"topic_name"},
+		nil,
+	)
+	e.topicReplicationFactorBelowMinimum = e.newDesc(
+		prometheus.BuildFQName(e.cfg.Namespace, "kafka", "topic_replication_factor_below_minimum"),
+		"Reports 1 if the topic's replication factor is lower than the configured minimum replication factor, otherwise 0",
+		[]string{"topic_name"},
+		nil,
+	)
+
+	// Partition leadership
+	// Partition leader
+	e.partitionLeader = e.newDesc(
+		prometheus.BuildFQName(e.cfg.Namespace, "kafka", "topic_partition_leader"),
+		"The broker id of the partition's leader, -1 if the partition is offline",
+		[]string{"topic_name", "partition_id"},
+		nil,
+	)
+	// Topic offline partitions
+	e.topicOfflinePartitions = e.newDesc(
+		prometheus.BuildFQName(e.cfg.Namespace, "kafka", "topic_offline_partitions"),
+		"Number of the topic's partitions that don't have a leader",
+		[]string{"topic_name"},
+		nil,
+	)
+
+	// Topic config drift
+	e.topicConfigDrift = e.newDesc(
+		prometheus.BuildFQName(e.cfg.Namespace, "kafka", "topic_config_drift"),
+		"Whether the topic's config value differs from the value declared in the config baselines (1) or not (0)",
+		[]string{"topic_name", "config_key"},
+		nil,
+	)
+	// Throughput
+	e.topicMessagesPerSecond = e.newDesc(
+		prometheus.BuildFQName(e.cfg.Namespace, "kafka", "topic_messages_per_second"),
+		"Approximate number of messages produced per second to the topic since the previous scrape",
+		[]string{"topic_name"},
+		nil,
+	)
+	e.topicBytesPerSecond = e.newDesc(
+		prometheus.BuildFQName(e.cfg.Namespace, "kafka", "topic_bytes_per_second"),
+		"Estimated number of bytes produced per second to the topic since the previous scrape, based on the topic's average message size",
+		[]string{"topic_name"},
+		nil,
+	)
+
+	// Tiered storage
+	e.topicRemoteStorageEnabled = e.newDesc(
+		prometheus.BuildFQName(e.cfg.Namespace, "kafka", "topic_remote_storage_enabled"),
+		"Whether remote storage (tiered storage) is enabled for the topic (1) or not (0)",
+		[]string{"topic_name"},
+		nil,
+	)
+	e.partitionRemoteOnlyMessages = e.newDesc(
+		prometheus.BuildFQName(e.cfg.Namespace, "kafka", "topic_partition_remote_only_approx_message_count"),
+		"Approximate number of messages in the partition that are only available in remote storage (local log start offset minus low water mark)",
+		[]string{"topic_name", "partition_id"},
+		nil,
+	)
+	e.topicRemoteOnlyMessages = e.newDesc(
+		prometheus.BuildFQName(e.cfg.Namespace, "kafka", "topic_remote_only_approx_message_count"),
+		"Approximate number of messages in the topic that are only available in remote storage",
+		[]string{"topic_name"},
+		nil,
+	)
+	e.topicLocalMessages = e.newDesc(
+		prometheus.BuildFQName(e.cfg.Namespace, "kafka", "topic_local_approx_message_count"),
+		"Approximate number of messages in the topic that are available in the brokers' local logs (high water mark minus local log start offset)",
+		[]string{"topic_name"},
+		nil,
+	)
+
+	// Internal topics
+	e.internalTopicPartitions = e.newDesc(
+		prometheus.BuildFQName(e.cfg.Namespace, "kafka", "internal_topic_partitions"),
+		"Number of partitions of an internal topic",
+		[]string{"topic_name"},
+		nil,
+	)
+	e.internalTopicOfflinePartitions = e.newDesc(
+		prometheus.BuildFQName(e.cfg.Namespace, "kafka", "internal_topic_offline_partitions"),
+		"Number of partitions of an internal topic without an available leader",
+		[]string{"topic_name"},
+		nil,
+	)
+	e.internalTopicUnderReplicatedPartitions = e.newDesc(
+		prometheus.BuildFQName(e.cfg.Namespace, "kafka", "internal_topic_under_replicated_partitions"),
+		"Number of partitions of an internal topic whose in-sync replica set is smaller than their replica set",
+		[]string{"topic_name"},
+		nil,
+	)
+	e.internalTopicSize = e.newDesc(
+		prometheus.BuildFQName(e.cfg.Namespace, "kafka", "internal_topic_log_dir_size_total_bytes"),
+		"The summed size in bytes of all replicas of an internal topic",
+		[]string{"topic_name"},
+		nil,
+	)
+
+	// Rack awareness
+	e.rackBrokers = e.newDesc(
+		prometheus.BuildFQName(e.cfg.Namespace, "kafka", "rack_brokers"),
+		"Number of brokers in the rack. Brokers without a configured rack are reported with an empty rack_id.",
+		[]string{"rack_id"},
+		nil,
+	)
+	e.topicNotRackAwarePartitions = e.newDesc(
+		prometheus.BuildFQName(e.cfg.Namespace, "kafka", "topic_not_rack_aware_partitions"),
+		"Number of the topic's partitions whose replicas are spread across fewer racks than the number of racks in the cluster (or the replication factor if lower)",
+		[]string{"topic_name"},
+		nil,
+	)
+	// Topic events
+	e.topicCreated = e.newDesc(
+		prometheus.BuildFQName(e.cfg.Namespace, "kafka", "topic_created_total"),
+		"Number of times the topic has been created since kminion started",
+		[]string{"topic_name"},
+		nil,
+	)
+	e.topicDeleted = e.newDesc(
+		prometheus.BuildFQName(e.cfg.Namespace, "kafka", "topic_deleted_total"),
+		"Number of times the topic has been deleted since kminion started",
+		[]string{"topic_name"},
+		nil,
+	)
+	e.topicPartitionsAdded = e.newDesc(
+		prometheus.BuildFQName(e.cfg.Namespace, "kafka", "topic_partitions_added_total"),
+		"Number of partitions that have been added to the topic since kminion started",
+		[]string{"topic_name"},
+		nil,
+	)
+	// Topic preferred leader imbalance
+	e.topicPreferredLeaderImbalance = e.newDesc(
+		prometheus.BuildFQName(e.cfg.Namespace, "kafka", "topic_preferred_leader_imbalance"),
+		"Number of the topic's partitions that are not led by their preferred leader (the first replica)",
+		[]string{"topic_name"},
+		nil,
+	)
+	e.topicPreferredLeaderImbalanceRatio = e.newDesc(
+		prometheus.BuildFQName(e.cfg.Namespace, "kafka", "topic_preferred_leader_imbalance_ratio"),
+		"Ratio of the topic's partitions that are not led by their preferred leader (the first replica)",
+		[]string{"topic_name"},
+		nil,
+	)
+	// Broker preferred leader imbalance
+	e.brokerPreferredLeaderImbalance = e.newDesc(
+		prometheus.BuildFQName(e.cfg.Namespace, "kafka", "broker_preferred_leader_imbalance"),
+		"Number of partitions across all topics whose preferred leader is the broker, but that are led by another broker or offline",
+		[]string{"broker_id"},
+		nil,
+	)
+	e.brokerPreferredLeaderImbalanceRatio = e.newDesc(
+		prometheus.BuildFQName(e.cfg.Namespace, "kafka", "broker_preferred_leader_imbalance_ratio"),
+		"Ratio of partitions across all topics whose preferred leader is the broker, but that are led by another broker or offline",
+		[]string{"broker_id"},
+		nil,
+	)
+
+	// ACL Metrics
+	aclLabels := []string{"resource_type", "pattern_type", "operation", "permission_type"}
+	e.aclCount = e.newDesc(
+		prometheus.BuildFQName(e.cfg.Namespace, "kafka", "acls"),
+		"Number of ACLs by resource type, pattern type, operation and permission type",
+		aclLabels,
+		nil,
+	)
+	e.aclWildcardCount = e.newDesc(
+		prometheus.BuildFQName(e.cfg.Namespace, "kafka", "acls_wildcard"),
+		"Number of ACLs whose resource name or principal is a wildcard, by resource type, pattern type, operation and permission type",
+		aclLabels,
+		nil,
+	)
+
+	// Client Quota Metrics
+	e.clientQuota = e.newDesc(
+		prometheus.BuildFQName(e.cfg.Namespace, "kafka", "client_quota"),
+		"Configured quota value of a quota entity, which is a combination of user, client id and ip",
+		[]string{"user", "client_id", "ip", "quota_key"},
+		nil,
+	)
+
+	// Delegation Token Metrics
+	e.delegationTokenExpiry = e.newDesc(
+		prometheus.BuildFQName(e.cfg.Namespace, "kafka", "delegation_token_expiry_timestamp_seconds"),
+		"Unix timestamp at which the delegation token expires unless it is renewed",
+		[]string{"token_id", "owner"},
+		nil,
+	)
+	e.delegationTokenSecondsUntilExpiry = e.newDesc(
+		prometheus.BuildFQName(e.cfg.Namespace, "kafka", "delegation_token_seconds_until_expiry"),
+		"Seconds until the owner's next delegation token expires. Negative if a token has expired already.",
+		[]string{"owner"},
+		nil,
+	)
+
+	// KRaft Quorum Metrics
+	e.kraftQuorumLeaderID = e.newDesc(
+		prometheus.BuildFQName(e.cfg.Namespace, "kafka", "kraft_quorum_leader_id"),
+		"Node id of the active controller that leads the KRaft quorum",
+		nil,
+		nil,
+	)
+	e.kraftQuorumLeaderEpoch = e.newDesc(
+		prometheus.BuildFQName(e.cfg.Namespace, "kafka", "kraft_quorum_leader_epoch"),
+		"Leader epoch of the KRaft quorum. Increases with every controller election.",
+		nil,
+		nil,
+	)
+	e.kraftQuorumHighWaterMark = e.newDesc(
+		prometheus.BuildFQName(e.cfg.Namespace, "kafka", "kraft_quorum_high_water_mark"),
+		"High water mark of the cluster metadata log replicated by the KRaft quorum",
+		nil,
+		nil,
+	)
+	e.kraftQuorumVoterLag = e.newDesc(
+		prometheus.BuildFQName(e.cfg.Namespace, "kafka", "kraft_quorum_voter_lag"),
+		"Number of cluster metadata log records the voter lags behind the high water mark",
+		[]string{"replica_id"},
+		nil,
+	)
+	e.kraftQuorumVoterLastFetchAge = e.newDesc(
+		prometheus.BuildFQName(e.cfg.Namespace, "kafka", "kraft_quorum_voter_last_fetch_age_seconds"),
+		"Seconds since the voter fetched from the quorum leader the last time",
+		[]string{"replica_id"},
+		nil,
+	)
+
+	// Producer State Metrics
+	e.partitionActiveProducers = e.newDesc(
+		prometheus.BuildFQName(e.cfg.Namespace, "kafka", "topic_partition_active_producers"),
+		"Number of active producer ids whose state is kept by the partition leader",
+		[]string{"topic_name", "partition_id"},
+		nil,
+	)
+	e.topicActiveProducers = e.newDesc(
+		prometheus.BuildFQName(e.cfg.Namespace, "kafka", "topic_active_producers"),
+		"Number of active producer ids summed across all partitions of the topic",
+		[]string{"topic_name"},
+		nil,
+	)
+	e.brokerActiveProducers = e.newDesc(
+		prometheus.BuildFQName(e.cfg.Namespace, "kafka", "broker_active_producers"),
+		"Number of active producer ids summed across all partitions led by the broker",
+		[]string{"broker_id"},
+		nil,
+	)
+	e.topicHighProducerCountPartitions = e.newDesc(
+		prometheus.BuildFQName(e.cfg.Namespace, "kafka", "topic_high_producer_count_partitions"),
+		"Number of the topic's partitions whose active producer ids exceed the configured threshold",
+		[]string{"topic_name"},
+		nil,
+	)
+
+	// Transaction Metrics
+	e.transactions = e.newDesc(
+		prometheus.BuildFQName(e.cfg.Namespace, "kafka", "transactions"),
+		"Number of transactions in the given state",
+		[]string{"state"},
+		nil,
+	)
+	e.transactionOldestAge = e.newDesc(
+		prometheus.BuildFQName(e.cfg.Namespace, "kafka", "transaction_oldest_age_seconds"),
+		"Age in seconds of the oldest ongoing transaction, 0 if there is none",
+		nil,
+		nil,
+	)
+	e.transactionsExceedingMaxAge = e.newDesc(
+		prometheus.BuildFQName(e.cfg.Namespace, "kafka", "transactions_exceeding_max_age"),
+		"Number of ongoing transactions that are older than the configured max age",
+		nil,
+		nil,
+	)
+	e.transactionAge = e.newDesc(
+		prometheus.BuildFQName(e.cfg.Namespace, "kafka", "transaction_age_seconds"),
+		"Age in seconds of an ongoing transaction. Only reported for transactions that are older than the configured max age.",
+		[]string{"transactional_id"},
+		nil,
+	)
+	e.partitionLastStableOffsetStuck = e.newDesc(
+		prometheus.BuildFQName(e.cfg.Namespace, "kafka", "topic_partition_last_stable_offset_stuck_seconds"),
+		"Seconds since the last stable offset of the partition advanced, while its high water mark has advanced. Only reported for partitions that are stuck for longer than the configured duration.",
+		[]string{"topic_name", "partition_id"},
+		nil,
+	)
+	e.topicLastStableOffsetStuckPartitions = e.newDesc(
+		prometheus.BuildFQName(e.cfg.Namespace, "kafka", "topic_last_stable_offset_stuck_partitions"),
+		"Number of the topic's partitions whose last stable offset is stuck for longer than the configured duration",
+		[]string{"topic_name"},
+		nil,
+	)
+
 	// Consumer Group Metrics
 	// Group Info
-	e.consumerGroupInfo = prometheus.NewDesc(
+	e.consumerGroupInfo = e.newDesc(
 		prometheus.BuildFQName(e.cfg.Namespace, "kafka", "consumer_group_info"),
 		"Consumer Group info metrics. It will report 1 if the group is in the stable state, otherwise 0.",
 		[]string{"group_id", "protocol", "protocol_type", "state", "coordinator_id"},
 		nil,
 	)
+	// Group State
+	e.consumerGroupState = e.newDesc(
+		prometheus.BuildFQName(e.cfg.Namespace, "kafka", "consumer_group_state"),
+		"It will report 1 for the state the consumer group is currently in and 0 for all other states",
+		[]string{"group_id", "state"},
+		nil,
+	)
+	// Group Rebalances
+	e.consumerGroupRebalances = e.newDesc(
+		prometheus.BuildFQName(e.cfg.Namespace, "kafka", "consumer_group_rebalances_total"),
+		"Number of rebalances of the consumer group that have been observed since kminion started",
+		[]string{"group_id"},
+		nil,
+	)
+	// Abandoned Groups
+	e.consumerGroupAbandoned = e.newDesc(
+		prometheus.BuildFQName(e.cfg.Namespace, "kafka", "consumer_group_abandoned"),
+		"It will report 1 if the consumer group has no members but still has committed offsets, otherwise 0",
+		[]string{"group_id"},
+		nil,
+	)
+	e.consumerGroupNewestCommitAge = e.newDesc(
+		prometheus.BuildFQName(e.cfg.Namespace, "kafka", "consumer_group_newest_commit_age_seconds"),
+		"Age of the newest offset commit of an abandoned consumer group. Only reported in the offsetsTopic scrape mode.",
+		[]string{"group_id"},
+		nil,
+	)
+	// Coordinated Groups
+	e.brokerCoordinatedGroups = e.newDesc(
+		prometheus.BuildFQName(e.cfg.Namespace, "kafka", "broker_coordinated_consumer_groups"),
+		"Number of consumer groups that are coordinated by the broker, regardless of the allowed and ignored groups",
+		[]string{"broker_id"},
+		nil,
+	)
 	// Group Members
-	e.consumerGroupMembers = prometheus.NewDesc(
+	e.consumerGroupMembers = e.newDesc(
 		prometheus.BuildFQName(e.cfg.Namespace, "kafka", "consumer_group_members"),
 		"Consumer Group member count metrics. It will report the number of members in the consumer group",
 		[]string{"group_id"},
 		nil,
 	)
 	// Group Empty Memmbers
-	e.consumerGroupMembersEmpty = prometheus.NewDesc(
+	e.consumerGroupMembersEmpty = e.newDesc(
 		prometheus.BuildFQName(e.cfg.Namespace, "kafka", "consumer_group_empty_members"),
 		"It will report the number of members in the consumer group with no partition assigned",
 		[]string{"group_id"},
 		nil,
 	)
+	// Group Member Assigned Partitions
+	e.consumerGroupMemberAssignedPartitions = e.newDesc(
+		prometheus.BuildFQName(e.cfg.Namespace, "kafka", "consumer_group_member_assigned_partitions"),
+		"It will report the number of partitions assigned to a member of the consumer group",
+		[]string{"group_id", "member_id", "client_id", "client_host"},
+		nil,
+	)
 	// Group Topic Members
-	e.consumerGroupTopicMembers = prometheus.NewDesc(
+	e.consumerGroupTopicMembers = e.newDesc(
 		prometheus.BuildFQName(e.cfg.Namespace, "kafka", "consumer_group_topic_members"),
 		"It will report the number of members in the consumer group assigned on a given topic",
 		[]string{"group_id", "topic_name"},
 		nil,
 	)
 	// Group Topic Assigned Partitions
-	e.consumerGroupAssignedTopicPartitions = prometheus.NewDesc(
+	e.consumerGroupAssignedTopicPartitions = e.newDesc(
 		prometheus.BuildFQName(e.cfg.Namespace, "kafka", "consumer_group_topic_assigned_partitions"),
 		"It will report the number of partitions assigned in the consumer group for a given topic",
 		[]string{"group_id", "topic_name"},
 		nil,
 	)
 	// Topic / Partition Offset Sum (useful for calculating the consumed messages / sec on a topic)
-	e.consumerGroupTopicOffsetSum = prometheus.NewDesc(
+	e.consumerGroupTopicOffsetSum = e.newDesc(
 		prometheus.BuildFQName(e.cfg.Namespace, "kafka", "consumer_group_topic_offset_sum"),
 		"The sum of all committed group offsets across all partitions in a topic",
 		[]string{"group_id", "topic_name"},
 		nil,
 	)
 	// Partition Lag
-	e.consumerGroupTopicPartitionLag = prometheus.NewDesc(
+	partitionLagLabels := []string{"group_id", "topic_name", "partition_id"}
+	if e.minionSvc.Cfg.ConsumerGroups.MemberLabels {
+		partitionLagLabels = append(partitionLagLabels, "client_id", "client_host")
+	}
+	e.consumerGroupTopicPartitionLag = e.newDesc(
 		prometheus.BuildFQName(e.cfg.Namespace, "kafka", "consumer_group_topic_partition_lag"),
 		"The number of messages a consumer group is lagging behind the latest offset of a partition",
-		[]string{"group_id", "topic_name", "partition_id"},
+		partitionLagLabels,
 		nil,
 	)
 	// Topic Lag (sum of all partition lags)
-	e.consumerGroupTopicLag = prometheus.NewDesc(
+	e.consumerGroupTopicLag = e.newDesc(
 		prometheus.BuildFQName(e.cfg.Namespace, "kafka", "consumer_group_topic_lag"),
 		"The number of messages a consumer group is lagging behind across all partitions in a topic",
 		[]string{"group_id", "topic_name"},
 		nil,
 	)
+	// Topic Max Lag (max of all partition lags)
+	e.consumerGroupTopicMaxLag = e.newDesc(
+		prometheus.BuildFQName(e.cfg.Namespace, "kafka", "consumer_group_topic_max_lag"),
+		"The maximum number of messages a consumer group is lagging behind across all partitions in a topic",
+		[]string{"group_id", "topic_name"},
+		nil,
+	)
+	// Lag velocity and estimated time until caught up
+	e.consumerGroupTopicLagVelocity = e.newDesc(
+		prometheus.BuildFQName(e.cfg.Namespace, "kafka", "consumer_group_topic_lag_velocity"),
+		"The number of messages per second by which the lag of a consumer group on a topic changed within the lag velocity window. Positive if the group is falling behind, negative if it is catching up.",
+		[]string{"group_id", "topic_name"},
+		nil,
+	)
+	e.consumerGroupTopicLagETA = e.newDesc(
+		prometheus.BuildFQName(e.cfg.Namespace, "kafka", "consumer_group_topic_lag_eta_seconds"),
+		"Estimated number of seconds until a consumer group has caught up on a topic at the current lag velocity. +Inf if the lag is not decreasing.",
+		[]string{"group_id", "topic_name"},
+		nil,
+	)
+	// Lag status according to the configured lag thresholds
+	e.consumerGroupLagStatus = e.newDesc(
+		prometheus.BuildFQName(e.cfg.Namespace, "kafka", "consumer_group_lag_status"),
+		"Lag status of a consumer group according to the configured lag thresholds. It will report 1 for the group's current status, otherwise 0.",
+		[]string{"group_id", "status"},
+		nil,
+	)
+	// Partition Lag in seconds
+	e.consumerGroupTopicPartitionLagSecs = e.newDesc(
+		prometheus.BuildFQName(e.cfg.Namespace, "kafka", "consumer_group_topic_partition_lag_seconds"),
+		"The number of seconds a consumer group is lagging behind, based on the timestamp of the record at the committed offset",
+		[]string{"group_id", "topic_name", "partition_id"},
+		nil,
+	)
+	// Topic Lag in seconds (max of all partition lags)
+	e.consumerGroupTopicLagSecs = e.newDesc(
+		prometheus.BuildFQName(e.cfg.Namespace, "kafka", "consumer_group_topic_lag_seconds"),
+		"The maximum number of seconds a consumer group is lagging behind across all partitions in a topic",
+		[]string{"group_id", "topic_name"},
+		nil,
+	)
 	// Offset commits by group id
-	e.offsetCommits = prometheus.NewDesc(
+	e.offsetCommits = e.newDesc(
 		prometheus.BuildFQName(e.cfg.Namespace, "kafka", "consumer_group_offset_commits_total"),
 		"The number of offsets committed by a group",
 		[]string{"group_id"},
 		nil,
 	)
+	// Offset commits by group id and topic
+	e.topicOffsetCommits = e.newDesc(
+		prometheus.BuildFQName(e.cfg.Namespace, "kafka", "consumer_group_topic_offset_commits_total"),
+		"The number of offsets committed by a group for a topic",
+		[]string{"group_id", "topic_name"},
+		nil,
+	)
+	// Offset rewinds by group id and topic
+	e.topicOffsetRewinds = e.newDesc(
+		prometheus.BuildFQName(e.cfg.Namespace, "kafka", "consumer_group_topic_offset_rewinds_total"),
+		"The number of times a committed offset of a group moved backwards for a partition of the topic, e.g. because the offsets have been reset",
+		[]string{"group_id", "topic_name"},
+		nil,
+	)
 
 }
 
@@ -221,6 +960,26 @@ func (e *Exporter) Describe(ch chan<- *prometheus.Desc) {
 }
 
 func (e *Exporter) Collect(ch chan<- prometheus.Metric) {
+	if len(e.extendedDescs) == 0 {
+		e.collect(ch)
+		return
+	}
+
+	// Add the labels extracted by the label rules to the collected metrics
+	metrics := make(chan prometheus.Metric)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for metric := range metrics {
+			ch <- e.addExtractedLabels(metric)
+		}
+	}()
+	e.collect(metrics)
+	close(metrics)
+	<-done
+}
+
+func (e *Exporter) collect(ch chan<- prometheus.Metric) {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*60)
 	defer cancel()
 
@@ -231,11 +990,30 @@ func (e *Exporter) Collect(ch chan<- prometheus.Metric) {
 	ok := e.collectClusterInfo(ctx, ch)
 	ok = e.collectExporterMetrics(ctx, ch) && ok
 	ok = e.collectBrokerInfo(ctx, ch) && ok
+	ok = e.collectBrokerProbes(ctx, ch) && ok
+	ok = e.collectBrokerConfigs(ctx, ch) && ok
+	ok = e.collectBrokerAPIVersions(ctx, ch) && ok
+	ok = e.collectRackAwareness(ctx, ch) && ok
+	ok = e.collectInternalTopics(ctx, ch) && ok
 	ok = e.collectLogDirs(ctx, ch) && ok
 	ok = e.collectConsumerGroups(ctx, ch) && ok
 	ok = e.collectTopicPartitionOffsets(ctx, ch) && ok
 	ok = e.collectConsumerGroupLags(ctx, ch) && ok
 	ok = e.collectTopicInfo(ctx, ch) && ok
+	ok = e.collectUnderReplicatedPartitions(ctx, ch) && ok
+	ok = e.collectUnderMinISRPartitions(ctx, ch) && ok
+	ok = e.collectPartitionLeadership(ctx, ch) && ok
+	ok = e.collectTopicConfigDrift(ctx, ch) && ok
+	ok = e.collectTopicEvents(ctx, ch) && ok
+	ok = e.collectTieredStorage(ctx, ch) && ok
+	ok = e.collectTopicThroughput(ctx, ch) && ok
+	ok = e.collectACLs(ctx, ch) && ok
+	ok = e.collectClientQuotas(ctx, ch) && ok
+	ok = e.collectDelegationTokens(ctx, ch) && ok
+	ok = e.collectKRaftQuorum(ctx, ch) && ok
+	ok = e.collectTransactions(ctx, ch) && ok
+	ok = e.collectStuckLastStableOffsets(ctx, ch) && ok
+	ok = e.collectProducerStates(ctx, ch) && ok
 
 	if ok {
 		ch <- prometheus.MustNewConstMetric(e.exporterUp, prometheus.GaugeValue, 1.0)
@@ -0,0 +1,107 @@
+package prometheus
+
+import (
+	"slices"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"go.uber.org/zap"
+)
+
+// extendedDesc is the Desc of a metric that carries a topic_name or group_id label, extended by the labels that are
+// extracted from topic names and group ids via the configured label rules.
+type extendedDesc struct {
+	desc *prometheus.Desc
+
+	// labels are the variable labels of the original Desc
+	labels []string
+
+	// topicLabels and groupLabels are the indices of the extracted topic and group labels that are appended to the
+	// original labels.
+	topicLabels []int
+	groupLabels []int
+}
+
+// newDesc creates a Desc just like prometheus.NewDesc. If the Desc has a topic_name or group_id label, the labels
+// extracted by the label rules are added to all metrics of the Desc when they are collected.
+func (e *Exporter) newDesc(fqName, help string, variableLabels []string, constLabels prometheus.Labels) *prometheus.Desc {
+	desc := prometheus.NewDesc(fqName, help, variableLabels, constLabels)
+
+	labels := slices.Clone(variableLabels)
+	extended := extendedDesc{labels: variableLabels}
+	if slices.Contains(variableLabels, "topic_name") {
+		for i, name := range e.minionSvc.TopicLabelNames() {
+			if slices.Contains(labels, name) {
+				continue
+			}
+			labels = append(labels, name)
+			extended.topicLabels = append(extended.topicLabels, i)
+		}
+	}
+	if slices.Contains(variableLabels, "group_id") {
+		for i, name := range e.minionSvc.GroupLabelNames() {
+			if slices.Contains(labels, name) {
+				continue
+			}
+			labels = append(labels, name)
+			extended.groupLabels = append(extended.groupLabels, i)
+		}
+	}
+	if len(labels) == len(variableLabels) {
+		return desc
+	}
+
+	extended.desc = prometheus.NewDesc(fqName, help, labels, constLabels)
+	e.extendedDescs[desc] = extended
+	return desc
+}
+
+// addExtractedLabels returns the given metric with the labels that are extracted from its topic_name and group_id
+// label values. Metrics without extracted labels are returned as is.
+func (e *Exporter) addExtractedLabels(metric prometheus.Metric) prometheus.Metric {
+	extended, exists := e.extendedDescs[metric.Desc()]
+	if !exists {
+		return metric
+	}
+
+	var m dto.Metric
+	if err := metric.Write(&m); err != nil {
+		e.logger.Warn("failed to add extracted labels to metric", zap.Error(err))
+		return metric
+	}
+	labelValuesByName := make(map[string]string, len(m.Label))
+	for _, pair := range m.Label {
+		labelValuesByName[pair.GetName()] = pair.GetValue()
+	}
+
+	labelValues := make([]string, 0, len(extended.labels)+len(extended.topicLabels)+len(extended.groupLabels))
+	for _, label := range extended.labels {
+		labelValues = append(labelValues, labelValuesByName[label])
+	}
+	if len(extended.topicLabels) > 0 {
+		topicLabelValues := e.minionSvc.TopicLabelValues(labelValuesByName["topic_name"])
+		for _, i := range extended.topicLabels {
+			labelValues = append(labelValues, topicLabelValues[i])
+		}
+	}
+	if len(extended.groupLabels) > 0 {
+		groupLabelValues := e.minionSvc.GroupLabelValues(labelValuesByName["group_id"])
+		for _, i := range extended.groupLabels {
+			labelValues = append(labelValues, groupLabelValues[i])
+		}
+	}
+
+	valueType, value := prometheus.UntypedValue, m.GetUntyped().GetValue()
+	switch {
+	case m.Gauge != nil:
+		valueType, value = prometheus.GaugeValue, m.GetGauge().GetValue()
+	case m.Counter != nil:
+		valueType, value = prometheus.CounterValue, m.GetCounter().GetValue()
+	}
+	extendedMetric, err := prometheus.NewConstMetric(extended.desc, valueType, value, labelValues...)
+	if err != nil {
+		e.logger.Warn("failed to add extracted labels to metric", zap.Error(err))
+		return metric
+	}
+	return extendedMetric
+}